@@ -27,13 +27,22 @@ var (
 
 func main() {
 	f := cmdutil.NewDefaultFactory(apiVersion, releaseURL)
-	ctx := context.Background()
-	updateMessageChan := make(chan string)
+	// Ctrl+C cancels the context of the running command, which reports what it left behind
+	ctx, stop := cmdutil.NotifyInterrupt(context.Background())
+	defer stop()
+	// buffered so that the update check never blocks, even when its result is not read
+	updateMessageChan := make(chan string, 1)
 	rootCmd := root.NewCmdRoot(f, version, buildDate, commit, updateMessageChan)
 
 	cmd, err := rootCmd.ExecuteContextC(ctx)
 	if err != nil {
+		if cmdutil.Interrupted(ctx) {
+			fmt.Fprintf(f.IOStreams().ErrOut, "%s Interrupted: %s\n", f.IOStreams().ColorScheme().FailureIcon(), err)
+			stop()
+			os.Exit(cmdutil.InterruptedExitCode)
+		}
 		printError(f.IOStreams(), err, cmd, updateMessageChan)
+		stop()
 		os.Exit(1)
 	}
 }
@@ -42,6 +51,9 @@ func printError(out *iostreams.IOStreams, err error, cmd *cobra.Command, updateM
 	c := out.ColorScheme()
 	var flagError *cmdutil.FlagError
 	var httpErr api.Error
+	if errors.Is(err, cmdutil.ErrSilent) {
+		return
+	}
 	//nolint
 	if errors.As(err, &flagError) || strings.HasPrefix(err.Error(), "unknown command ") {
 		fmt.Fprintf(out.ErrOut, "%s\n", err)
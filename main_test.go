@@ -87,10 +87,8 @@ func Test_printError(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ios, _, stdout, stderr := iostreams.Test()
-			mockUpdateMessageChan := make(chan string)
-			go func() {
-				mockUpdateMessageChan <- tt.mock.latestVersion
-			}()
+			mockUpdateMessageChan := make(chan string, 1)
+			mockUpdateMessageChan <- tt.mock.latestVersion
 
 			printError(ios, tt.mock.err, tt.mock.cmd, mockUpdateMessageChan)
 
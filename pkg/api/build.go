@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+)
+
+// Build statuses of a BuildEvent.
+const (
+	BuildStatusStarted   = "started"
+	BuildStatusCompleted = "completed"
+	BuildStatusFailed    = "failed"
+)
+
+// BuildFailedLogLines is how many of the last build log lines a BuildFailedError holds.
+const BuildFailedLogLines = 20
+
+// completedRegex and failedRegex tell the end of a build from the text frames of the servers that
+// don't send build events yet.
+var completedRegex = regexp.MustCompile(`(?i)(status.*completed|completed.*status)`)
+var failedRegex = regexp.MustCompile(`(?i)(status.*failed|failed.*status|failed to watch build logs)`)
+
+// BuildEvent is a JSON frame of the build watch stream of a package. An event with a phase and a status
+// starts or ends that phase, an event with a log line belongs to the current phase, and an event with a
+// status but no phase ends the build.
+type BuildEvent struct {
+	Phase     string    `json:"phase,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	Log       string    `json:"log,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// parseBuildEvent returns the event of a frame, and false for the frames of the legacy text protocol.
+func parseBuildEvent(frame []byte) (BuildEvent, bool) {
+	var event BuildEvent
+	if err := json.Unmarshal(frame, &event); err != nil || event.Timestamp.IsZero() {
+		return BuildEvent{}, false
+	}
+	return event, true
+}
+
+// BuildFailedError is returned when the build of a package fails.
+type BuildFailedError struct {
+	PackageID string
+	// Phase is the build step that failed, empty when the server does not report it.
+	Phase  string
+	Reason string
+	// LastLines are the last lines of the build log, at most BuildFailedLogLines.
+	LastLines []string
+}
+
+func (e *BuildFailedError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "error while building package %s", e.PackageID)
+	if e.Phase != "" {
+		fmt.Fprintf(&sb, " in phase %q", e.Phase)
+	}
+	if e.Reason != "" {
+		fmt.Fprintf(&sb, ": %s", e.Reason)
+	}
+	if len(e.LastLines) > 0 {
+		fmt.Fprintf(&sb, "\nlast %d build log lines:", len(e.LastLines))
+		for _, line := range e.LastLines {
+			fmt.Fprintf(&sb, "\n  %s", line)
+		}
+	}
+	return sb.String()
+}
+
+// buildWatcher renders the frames of a build watch stream and writes them to the build log.
+type buildWatcher struct {
+	out       *iostreams.IOStreams
+	log       io.Writer
+	packageID string
+
+	phase     string
+	started   map[string]time.Time
+	lastLines []string
+}
+
+func newBuildWatcher(out *iostreams.IOStreams, log io.Writer, packageID string) *buildWatcher {
+	if log == nil {
+		log = io.Discard
+	}
+	return &buildWatcher{out: out, log: log, packageID: packageID, started: map[string]time.Time{}}
+}
+
+// handle processes a frame, and reports whether the build is over, with an error if it failed.
+func (w *buildWatcher) handle(frame []byte) (bool, error) {
+	event, ok := parseBuildEvent(frame)
+	if !ok {
+		return w.handleLegacy(string(frame))
+	}
+
+	c := w.out.ColorScheme()
+	if event.Phase != "" {
+		w.phase = event.Phase
+	}
+	if event.Log != "" {
+		fmt.Fprintf(w.log, "%s [%s] %s\n", event.Timestamp.Format(time.RFC3339), w.phase, event.Log)
+		fmt.Fprintf(w.out.Out, "  %s\n", event.Log)
+		w.addLine(event.Log)
+	}
+	if event.Status == "" {
+		return false, nil
+	}
+	status := event.Status
+	if event.Error != "" {
+		status += ": " + event.Error
+	}
+	fmt.Fprintf(w.log, "%s [%s] %s\n", event.Timestamp.Format(time.RFC3339), event.Phase, status)
+
+	if event.Phase == "" {
+		if event.Status == BuildStatusFailed {
+			return true, w.failed(event.Error)
+		}
+		return event.Status == BuildStatusCompleted, nil
+	}
+
+	switch event.Status {
+	case BuildStatusStarted:
+		w.started[event.Phase] = event.Timestamp
+		fmt.Fprintf(w.out.Out, "%s %s\n", c.Cyan("▸"), event.Phase)
+	case BuildStatusCompleted:
+		fmt.Fprintf(w.out.Out, "%s %s%s\n", c.SuccessIcon(), event.Phase, w.duration(event))
+	case BuildStatusFailed:
+		fmt.Fprintf(w.out.Out, "%s %s failed%s\n", c.FailureIcon(), event.Phase, w.duration(event))
+		return true, w.failed(event.Error)
+	}
+	return false, nil
+}
+
+func (w *buildWatcher) handleLegacy(frame string) (bool, error) {
+	fmt.Fprintf(w.log, "%s\n", frame)
+	fmt.Fprintf(w.out.Out, "%s\n", frame)
+	w.addLine(frame)
+	if completedRegex.MatchString(frame) {
+		return true, nil
+	}
+	if failedRegex.MatchString(frame) {
+		return true, w.failed("")
+	}
+	return false, nil
+}
+
+func (w *buildWatcher) failed(reason string) error {
+	return &BuildFailedError{PackageID: w.packageID, Phase: w.phase, Reason: reason, LastLines: w.lastLines}
+}
+
+func (w *buildWatcher) addLine(line string) {
+	w.lastLines = append(w.lastLines, line)
+	if len(w.lastLines) > BuildFailedLogLines {
+		w.lastLines = w.lastLines[len(w.lastLines)-BuildFailedLogLines:]
+	}
+}
+
+// duration returns how long the phase of event took, e.g. " (12.3s)", or nothing when its start is unknown.
+func (w *buildWatcher) duration(event BuildEvent) string {
+	start, ok := w.started[event.Phase]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", event.Timestamp.Sub(start).Round(100*time.Millisecond))
+}
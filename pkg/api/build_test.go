@@ -0,0 +1,122 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildWatcher(t *testing.T) {
+	tests := []struct {
+		name       string
+		frames     []string
+		wantDone   bool
+		wantErr    string
+		wantStdout string
+		wantLog    string
+	}{
+		{
+			name: "typed-success",
+			frames: []string{
+				`{"phase":"install","status":"started","timestamp":"2026-01-01T10:00:00Z"}`,
+				`{"phase":"install","log":"added 12 packages, 0 failed status checks","timestamp":"2026-01-01T10:00:01Z"}`,
+				`{"phase":"install","status":"completed","timestamp":"2026-01-01T10:00:12.34Z"}`,
+				`{"status":"completed","timestamp":"2026-01-01T10:00:13Z"}`,
+			},
+			wantDone: true,
+			wantStdout: "▸ install\n" +
+				"  added 12 packages, 0 failed status checks\n" +
+				"✓ install (12.3s)\n",
+			wantLog: "2026-01-01T10:00:00Z [install] started\n" +
+				"2026-01-01T10:00:01Z [install] added 12 packages, 0 failed status checks\n" +
+				"2026-01-01T10:00:12Z [install] completed\n" +
+				"2026-01-01T10:00:13Z [] completed\n",
+		},
+		{
+			name: "typed-phase-failure",
+			frames: []string{
+				`{"phase":"build","status":"started","timestamp":"2026-01-01T10:00:00Z"}`,
+				`{"phase":"build","log":"npm ERR! missing script: build","timestamp":"2026-01-01T10:00:01Z"}`,
+				`{"phase":"build","status":"failed","error":"exit code 1","timestamp":"2026-01-01T10:00:02Z"}`,
+			},
+			wantDone:   true,
+			wantErr:    "error while building package package-id in phase \"build\": exit code 1\nlast 1 build log lines:\n  npm ERR! missing script: build",
+			wantStdout: "▸ build\n  npm ERR! missing script: build\nX build failed (2s)\n",
+		},
+		{
+			name: "typed-build-failure",
+			frames: []string{
+				`{"phase":"build","status":"started","timestamp":"2026-01-01T10:00:00Z"}`,
+				`{"status":"failed","error":"timeout","timestamp":"2026-01-01T10:10:00Z"}`,
+			},
+			wantDone:   true,
+			wantErr:    "error while building package package-id in phase \"build\": timeout",
+			wantStdout: "▸ build\n",
+		},
+		{
+			name:       "typed-in-progress",
+			frames:     []string{`{"phase":"build","status":"started","timestamp":"2026-01-01T10:00:00Z"}`},
+			wantStdout: "▸ build\n",
+		},
+		{
+			name:       "legacy-success",
+			frames:     []string{"building...", `{"status": "completed"}`},
+			wantDone:   true,
+			wantStdout: "building...\n{\"status\": \"completed\"}\n",
+			wantLog:    "building...\n{\"status\": \"completed\"}\n",
+		},
+		{
+			name:       "legacy-failure",
+			frames:     []string{"npm ERR!", "status: failed"},
+			wantDone:   true,
+			wantErr:    "error while building package package-id\nlast 2 build log lines:\n  npm ERR!\n  status: failed",
+			wantStdout: "npm ERR!\nstatus: failed\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ios, _, stdout, _ := iostreams.Test()
+			var log strings.Builder
+			w := newBuildWatcher(ios, &log, "package-id")
+
+			var done bool
+			var err error
+			for _, frame := range tt.frames {
+				require.False(t, done, "the build should not end before the last frame")
+				done, err = w.handle([]byte(frame))
+			}
+			require.Equal(t, tt.wantDone, done)
+			require.Equal(t, tt.wantStdout, stdout.String())
+			if tt.wantLog != "" {
+				require.Equal(t, tt.wantLog, log.String())
+			}
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+			var buildErr *BuildFailedError
+			require.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &buildErr))
+		})
+	}
+}
+
+func TestBuildWatcherKeepsLastLines(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	w := newBuildWatcher(ios, nil, "package-id")
+	for i := 0; i < BuildFailedLogLines+5; i++ {
+		_, err := w.handle([]byte(fmt.Sprintf(`{"phase":"build","log":"line %d","timestamp":"2026-01-01T10:00:00Z"}`, i)))
+		require.NoError(t, err)
+	}
+	_, err := w.handle([]byte(`{"phase":"build","status":"failed","timestamp":"2026-01-01T10:00:00Z"}`))
+	var buildErr *BuildFailedError
+	require.ErrorAs(t, err, &buildErr)
+	require.Len(t, buildErr.LastLines, BuildFailedLogLines)
+	require.Equal(t, "line 5", buildErr.LastLines[0])
+	require.Equal(t, "build", buildErr.Phase)
+}
@@ -20,6 +20,55 @@ func NewDatastore(gqlClient Doer) *Datastore {
 	}
 }
 
+// DefaultPageSize is how many rows a paginated query fetches at a time.
+const DefaultPageSize = 100
+
+// pageParams are the variables of a paginated query. They are embedded in the variables of the
+// queries that have their own.
+type pageParams struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// fetchPages calls fetch for each page of pageSize rows and passes the rows to fn, until a page
+// comes back short. The offset of the next page is the cursor, so paginated queries must be ordered
+// on a unique key for the pages not to overlap.
+func fetchPages[T any](ctx context.Context, pageSize int, fetch func(ctx context.Context, page pageParams) ([]T, error), fn func(rows []T) error) error {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	for offset := 0; ; offset += pageSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rows, err := fetch(ctx, pageParams{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return err
+		}
+		if len(rows) > 0 {
+			if err := fn(rows); err != nil {
+				return err
+			}
+		}
+		if len(rows) < pageSize {
+			return nil
+		}
+	}
+}
+
+// fetchAll returns the rows of all the pages of a paginated query.
+func fetchAll[T any](ctx context.Context, fetch func(ctx context.Context, page pageParams) ([]T, error)) ([]T, error) {
+	all := []T{}
+	err := fetchPages(ctx, DefaultPageSize, fetch, func(rows []T) error {
+		all = append(all, rows...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
 type listRegionResponseData struct {
 	Regions []Region `json:"Regions"`
 }
@@ -27,27 +76,32 @@ type listRegionResponse struct {
 	Data listRegionResponseData `json:"data"`
 }
 
-// ListRegions lists all the available runtimes.
+// ListRegions lists all the enabled regions.
 func (ds *Datastore) ListRegions(ctx context.Context) ([]Region, error) {
 	const query = `
-query MyQuery {
-  Regions(where: {enabled: {_eq: true}}) {
- 	name    
-	alias
+query MyQuery ($limit: Int!, $offset: Int!) {
+  Regions(where: {enabled: {_eq: true}}, order_by: {alias: asc}, limit: $limit, offset: $offset) {
+    name
+    alias
 	deployment_api_url
+	marketplace_api_url
 	assets_api_url
+	endpoint_url_scheme
 	debugger_url_scheme
 	host_template
   }
 }`
-	req := GQLRequest{
-		Query: query,
-	}
-	var resp listRegionResponse
-	if err := ds.gqlClient.Do(ctx, req, &resp); err != nil {
-		return nil, err
-	}
-	return resp.Data.Regions, nil
+	return fetchAll(ctx, func(ctx context.Context, page pageParams) ([]Region, error) {
+		req := GQLRequest{
+			Query:     query,
+			Variables: page,
+		}
+		var resp listRegionResponse
+		if err := ds.gqlClient.Do(ctx, req, &resp); err != nil {
+			return nil, err
+		}
+		return resp.Data.Regions, nil
+	})
 }
 
 func (ds *Datastore) GetRegion(ctx context.Context, alias string) (Region, error) {
@@ -87,47 +141,120 @@ type listInstancesResponse struct {
 	Data listInstancesResponseData `json:"data"`
 }
 
+// Sort orders of ListInstancesPages.
+const (
+	InstanceSortUpdated = "updated"
+	InstanceSortCreated = "created"
+	InstanceSortName    = "name"
+)
+
+// InstanceSorts are the orders ListInstancesPages accepts.
+var InstanceSorts = []string{InstanceSortUpdated, InstanceSortCreated, InstanceSortName}
+
+// InstanceQuery selects and orders the instances of ListInstancesPages. Empty fields match all
+// instances.
+type InstanceQuery struct {
+	// ServiceName matches the instances whose service name contains it, case-insensitive.
+	ServiceName      string
+	ProjectName      string
+	Region           string
+	APIApplicationID string
+	Runtime          string
+	// Sort is one of InstanceSorts, the most recently updated instances come first by default.
+	Sort string
+}
+
+// where returns the Hasura boolean expression of the query.
+func (q InstanceQuery) where() map[string]any {
+	where := map[string]any{"deleted": map[string]any{"_eq": false}}
+	if q.ServiceName != "" {
+		where["service_name"] = map[string]any{"_ilike": "%" + q.ServiceName + "%"}
+	}
+	if q.ProjectName != "" {
+		where["Project"] = map[string]any{"name": map[string]any{"_eq": q.ProjectName}}
+	}
+	if q.Region != "" {
+		where["region"] = map[string]any{"_eq": q.Region}
+	}
+	if q.APIApplicationID != "" {
+		where["api_application_id"] = map[string]any{"_eq": q.APIApplicationID}
+	}
+	if q.Runtime != "" {
+		where["runtime"] = map[string]any{"_eq": q.Runtime}
+	}
+	return where
+}
+
+// orderBy returns the Hasura order of the query, with the ID as tiebreak so that pages don't overlap.
+func (q InstanceQuery) orderBy() []map[string]string {
+	var order map[string]string
+	switch q.Sort {
+	case InstanceSortName:
+		order = map[string]string{"name": "asc"}
+	case InstanceSortCreated:
+		order = map[string]string{"created_at": "desc"}
+	default:
+		order = map[string]string{"updated_at": "desc"}
+	}
+	return []map[string]string{order, {"id": "asc"}}
+}
+
 type listInstancesParams struct {
-	ServiceName string `json:"service_name"`
+	Where   map[string]any      `json:"where"`
+	OrderBy []map[string]string `json:"order_by"`
+	pageParams
 }
 
 // ListInstances lists all non-deleted instances ordered by last-updated date descending.
 // If filter is non-empty, only instances whose service_name contains the filter string
 // (case-insensitive) are returned, using a GraphQL _ilike operator.
 func (ds *Datastore) ListInstances(ctx context.Context, filter string) ([]InstanceListItem, error) {
-	const queryNoFilter = `
-query MyQuery {
-  Instances(order_by: {updated_at: desc}, where: {deleted: {_eq: false}}) {
-    id
-    api_application_id
-    name
-    service_name
-  }
-}`
-	const queryWithFilter = `
-query MyQuery ($service_name: String!) {
-  Instances(order_by: {updated_at: desc}, where: {deleted: {_eq: false}, service_name: {_ilike: $service_name}}) {
+	var all []InstanceListItem
+	err := ds.ListInstancesPages(ctx, InstanceQuery{ServiceName: filter}, DefaultPageSize, func(page []InstanceListItem) error {
+		all = append(all, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if all == nil {
+		all = []InstanceListItem{}
+	}
+	return all, nil
+}
+
+// ListInstancesPages lists the non-deleted instances matching query, fetching pageSize instances at a
+// time and passing each page to fn as soon as it arrives. It stops at the first error returned by fn.
+func (ds *Datastore) ListInstancesPages(ctx context.Context, query InstanceQuery, pageSize int, fn func(page []InstanceListItem) error) error {
+	const gqlQuery = `
+query MyQuery ($where: Instances_bool_exp!, $order_by: [Instances_order_by!]!, $limit: Int!, $offset: Int!) {
+  Instances(where: $where, order_by: $order_by, limit: $limit, offset: $offset) {
     id
     api_application_id
     name
     service_name
+    region
+    runtime
+    created_at
+    updated_at
+    Project {
+      name
+    }
   }
 }`
-	var req GQLRequest
-	if filter == "" {
-		req = GQLRequest{Query: queryNoFilter}
-	} else {
-		req = GQLRequest{
-			Query:     queryWithFilter,
-			Variables: listInstancesParams{ServiceName: "%" + filter + "%"},
+	where, orderBy := query.where(), query.orderBy()
+	fetch := func(ctx context.Context, page pageParams) ([]InstanceListItem, error) {
+		req := GQLRequest{
+			Query:     gqlQuery,
+			Variables: listInstancesParams{Where: where, OrderBy: orderBy, pageParams: page},
 		}
+		var resp listInstancesResponse
+		if err := ds.gqlClient.Do(ctx, req, &resp); err != nil {
+			return nil, err
+		}
+		return resp.Data.Instances, nil
 	}
-
-	var resp listInstancesResponse
-	if err := ds.gqlClient.Do(ctx, req, &resp); err != nil {
-		return nil, err
-	}
-	return resp.Data.Instances, nil
+	return fetchPages(ctx, pageSize, fetch, fn)
 }
 
 type getByProjAndInstNameData struct {
@@ -242,26 +369,29 @@ type listRuntimeResponse struct {
 	Data listRuntimeResponseData `json:"data"`
 }
 
-// List lists all the available runtimes.
+// ListRuntimes lists all the available runtimes.
 func (ds *Datastore) ListRuntimes(ctx context.Context) ([]Runtime, error) {
 	const query = `
-query MyQuery {
-  Runtimes(where: {enabled: {_eq: true}}, order_by: {name: asc}) {
+query MyQuery ($limit: Int!, $offset: Int!) {
+  Runtimes(where: {enabled: {_eq: true}}, order_by: [{name: asc}, {id: asc}], limit: $limit, offset: $offset) {
     id
     name
     language
+	api_version
 	comments
   }
 }`
-
-	req := GQLRequest{
-		Query: query,
-	}
-	var resp listRuntimeResponse
-	if err := ds.gqlClient.Do(ctx, req, &resp); err != nil {
-		return nil, err
-	}
-	return resp.Data.Runtimes, nil
+	return fetchAll(ctx, func(ctx context.Context, page pageParams) ([]Runtime, error) {
+		req := GQLRequest{
+			Query:     query,
+			Variables: page,
+		}
+		var resp listRuntimeResponse
+		if err := ds.gqlClient.Do(ctx, req, &resp); err != nil {
+			return nil, err
+		}
+		return resp.Data.Runtimes, nil
+	})
 }
 
 type getParams struct {
@@ -302,6 +432,111 @@ query MyQuery ($api_account_id: String!, $name: String!) {
 	return resp.Data.Projects[0], nil
 }
 
+type listProjectsParams struct {
+	APIAccountID string `json:"api_account_id"`
+	pageParams
+}
+
+type projectRow struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	CreatedAt          string `json:"created_at"`
+	UpdatedAt          string `json:"updated_at"`
+	InstancesAggregate struct {
+		Aggregate struct {
+			Count int `json:"count"`
+		} `json:"aggregate"`
+	} `json:"Instances_aggregate"`
+}
+
+type listProjectsResponseData struct {
+	Projects []projectRow `json:"Projects"`
+}
+
+type listProjectsResponse struct {
+	Data listProjectsResponseData `json:"data"`
+}
+
+// ListProjects lists the non-deleted projects of an account ordered by name, with the number of
+// their non-deleted instances.
+func (ds *Datastore) ListProjects(ctx context.Context, accountID string) ([]ProjectListItem, error) {
+	const query = `
+query MyQuery ($api_account_id: String!, $limit: Int!, $offset: Int!) {
+  Projects(where: {api_account_id: {_eq: $api_account_id}, deleted: {_eq: false}}, order_by: [{name: asc}, {id: asc}], limit: $limit, offset: $offset) {
+    id
+    name
+    created_at
+    updated_at
+    Instances_aggregate(where: {deleted: {_eq: false}}) {
+      aggregate {
+        count
+      }
+    }
+  }
+}`
+	rows, err := fetchAll(ctx, func(ctx context.Context, page pageParams) ([]projectRow, error) {
+		req := GQLRequest{
+			Query:     query,
+			Variables: listProjectsParams{APIAccountID: accountID, pageParams: page},
+		}
+		var resp listProjectsResponse
+		if err := ds.gqlClient.Do(ctx, req, &resp); err != nil {
+			return nil, err
+		}
+		return resp.Data.Projects, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]ProjectListItem, 0, len(rows))
+	for _, r := range rows {
+		projects = append(projects, ProjectListItem{
+			ID:            r.ID,
+			Name:          r.Name,
+			CreatedAt:     r.CreatedAt,
+			UpdatedAt:     r.UpdatedAt,
+			InstanceCount: r.InstancesAggregate.Aggregate.Count,
+		})
+	}
+	return projects, nil
+}
+
+type listInstancesByProjectIDParams struct {
+	ProjectID string `json:"project_id"`
+	pageParams
+}
+
+// ListInstancesByProjectID lists the non-deleted instances of a project ordered by name.
+func (ds *Datastore) ListInstancesByProjectID(ctx context.Context, projectID string) ([]InstanceListItem, error) {
+	const query = `
+query MyQuery ($project_id: uuid!, $limit: Int!, $offset: Int!) {
+  Instances(where: {Project: {id: {_eq: $project_id}}, deleted: {_eq: false}}, order_by: [{name: asc}, {id: asc}], limit: $limit, offset: $offset) {
+    id
+    api_application_id
+    name
+    service_name
+    region
+    runtime
+    created_at
+    updated_at
+    Project {
+      name
+    }
+  }
+}`
+	return fetchAll(ctx, func(ctx context.Context, page pageParams) ([]InstanceListItem, error) {
+		req := GQLRequest{
+			Query:     query,
+			Variables: listInstancesByProjectIDParams{ProjectID: projectID, pageParams: page},
+		}
+		var resp listInstancesResponse
+		if err := ds.gqlClient.Do(ctx, req, &resp); err != nil {
+			return nil, err
+		}
+		return resp.Data.Instances, nil
+	})
+}
+
 type listProductResponseData struct {
 	Products []Product `json:"Products"`
 }
@@ -311,23 +546,58 @@ type listProductResponse struct {
 
 func (ds *Datastore) ListProducts(ctx context.Context) ([]Product, error) {
 	const query = `
-query MyQuery {
-  Products(where: {ProductVersions: {code_template_enabled: {_eq: true}}, type: {_eq: public}}, order_by: {name: asc}) {
+query MyQuery ($limit: Int!, $offset: Int!) {
+  Products(where: {ProductVersions: {code_template_enabled: {_eq: true}}, type: {_eq: public}}, order_by: [{name: asc}, {id: asc}], limit: $limit, offset: $offset) {
     id
     name
     programming_language
   }
 }`
+	return fetchAll(ctx, func(ctx context.Context, page pageParams) ([]Product, error) {
+		req := GQLRequest{
+			Query:     query,
+			Variables: page,
+		}
+		var resp listProductResponse
+		if err := ds.gqlClient.Do(ctx, req, &resp); err != nil {
+			return nil, err
+		}
+		return resp.Data.Products, nil
+	})
+}
 
-	req := GQLRequest{
-		Query: query,
-	}
-	var resp listProductResponse
+type listProductTemplatesResponseData struct {
+	Products []ProductTemplate `json:"Products"`
+}
+type listProductTemplatesResponse struct {
+	Data listProductTemplatesResponseData `json:"data"`
+}
 
-	if err := ds.gqlClient.Do(ctx, req, &resp); err != nil {
-		return nil, err
-	}
-	return resp.Data.Products, nil
+// ListProductTemplates lists the same products as ListProducts along with their latest version, as
+// GetLatestProductVersionByID returns it, in a single query per page instead of one query per product.
+func (ds *Datastore) ListProductTemplates(ctx context.Context) ([]ProductTemplate, error) {
+	const query = `
+query MyQuery ($limit: Int!, $offset: Int!) {
+  Products(where: {ProductVersions: {code_template_enabled: {_eq: true}}, type: {_eq: public}}, order_by: [{name: asc}, {id: asc}], limit: $limit, offset: $offset) {
+    id
+    name
+    programming_language
+    latest_versions: ProductVersions(where: {Product: {active_version_id: {_is_null: false}}}, order_by: {created_at: desc}, limit: 1) {
+      id
+    }
+  }
+}`
+	return fetchAll(ctx, func(ctx context.Context, page pageParams) ([]ProductTemplate, error) {
+		req := GQLRequest{
+			Query:     query,
+			Variables: page,
+		}
+		var resp listProductTemplatesResponse
+		if err := ds.gqlClient.Do(ctx, req, &resp); err != nil {
+			return nil, err
+		}
+		return resp.Data.Products, nil
+	})
 }
 
 type getLatestProductVersionByIDParams struct {
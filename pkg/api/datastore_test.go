@@ -751,6 +751,40 @@ func TestListProducts(t *testing.T) {
 	}
 }
 
+func TestListProductTemplates(t *testing.T) {
+	httpClient := resty.New()
+	httpmock.ActivateNonDefault(httpClient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	var queries []string
+	httpmock.RegisterResponder("POST", "https://example.com",
+		func(req *http.Request) (*http.Response, error) {
+			var body GQLRequest
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			queries = append(queries, body.Query)
+			resp := httpmock.NewStringResponse(http.StatusOK, `{"data": {"Products": [
+				{"id": "Product1-id", "name": "Product1", "programming_language": "Python", "latest_versions": [{"id": "Version1-id"}]},
+				{"id": "Product2-id", "name": "Product2", "programming_language": "NodeJS", "latest_versions": []}
+			]}}`)
+			resp.Header.Set("Content-Type", "application/json")
+			return resp, nil
+		})
+
+	datastoreClient := NewDatastore(NewGraphQLClient("https://example.com", httpClient))
+	products, err := datastoreClient.ListProductTemplates(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []ProductTemplate{
+		{Product: Product{ID: "Product1-id", Name: "Product1", ProgrammingLanguage: "Python"}, LatestVersions: []ProductVersion{{ID: "Version1-id"}}},
+		{Product: Product{ID: "Product2-id", Name: "Product2", ProgrammingLanguage: "NodeJS"}, LatestVersions: []ProductVersion{}},
+	}, products)
+	require.Equal(t, "Version1-id", products[0].LatestVersion())
+	require.Empty(t, products[1].LatestVersion())
+	require.Len(t, queries, 1, "the latest versions should be fetched with the products")
+	require.Contains(t, queries[0], "latest_versions: ProductVersions(")
+}
+
 func TestGetLatestProductVersionByID(t *testing.T) {
 	httpClient := resty.New()
 	httpmock.ActivateNonDefault(httpClient.GetClient())
@@ -1080,3 +1114,163 @@ func TestListInstances(t *testing.T) {
 		})
 	}
 }
+
+func TestListInstancesPages(t *testing.T) {
+	httpClient := resty.New()
+	httpmock.ActivateNonDefault(httpClient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	all := []InstanceListItem{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}, {ID: "5"}}
+	var offsets []int
+	httpmock.RegisterResponder("POST", "https://example.com",
+		func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				Variables listInstancesParams `json:"variables"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			require.Equal(t, map[string]any{
+				"deleted":      map[string]any{"_eq": false},
+				"service_name": map[string]any{"_ilike": "%prod%"},
+				"region":       map[string]any{"_eq": "aws.euw1"},
+			}, body.Variables.Where)
+			require.Equal(t, []map[string]string{{"name": "asc"}, {"id": "asc"}}, body.Variables.OrderBy)
+			offsets = append(offsets, body.Variables.Offset)
+			end := min(body.Variables.Offset+body.Variables.Limit, len(all))
+			return httpmock.NewJsonResponse(http.StatusOK, listInstancesResponse{
+				Data: listInstancesResponseData{Instances: all[body.Variables.Offset:end]},
+			})
+		})
+
+	datastoreClient := NewDatastore(NewGraphQLClient("https://example.com", httpClient))
+	query := InstanceQuery{ServiceName: "prod", Region: "aws.euw1", Sort: InstanceSortName}
+
+	var pages [][]InstanceListItem
+	err := datastoreClient.ListInstancesPages(t.Context(), query, 2, func(page []InstanceListItem) error {
+		pages = append(pages, page)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]InstanceListItem{all[:2], all[2:4], all[4:]}, pages)
+	require.Equal(t, []int{0, 2, 4}, offsets)
+
+	// an error of the callback stops the pagination
+	offsets = nil
+	errStop := errors.New("stop")
+	err = datastoreClient.ListInstancesPages(t.Context(), query, 2, func(_ []InstanceListItem) error {
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, []int{0}, offsets)
+
+	// a full last page costs one more request, which comes back empty
+	offsets = nil
+	err = datastoreClient.ListInstancesPages(t.Context(), query, 5, func(_ []InstanceListItem) error { return nil })
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 5}, offsets)
+}
+
+func TestInstanceQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       InstanceQuery
+		wantWhere   map[string]any
+		wantOrderBy []map[string]string
+	}{
+		{
+			name:        "empty",
+			query:       InstanceQuery{},
+			wantWhere:   map[string]any{"deleted": map[string]any{"_eq": false}},
+			wantOrderBy: []map[string]string{{"updated_at": "desc"}, {"id": "asc"}},
+		},
+		{
+			name: "all-filters",
+			query: InstanceQuery{
+				ServiceName:      "svc",
+				ProjectName:      "my-project",
+				Region:           "aws.use1",
+				APIApplicationID: "app-id",
+				Runtime:          "nodejs22",
+				Sort:             InstanceSortCreated,
+			},
+			wantWhere: map[string]any{
+				"deleted":            map[string]any{"_eq": false},
+				"service_name":       map[string]any{"_ilike": "%svc%"},
+				"Project":            map[string]any{"name": map[string]any{"_eq": "my-project"}},
+				"region":             map[string]any{"_eq": "aws.use1"},
+				"api_application_id": map[string]any{"_eq": "app-id"},
+				"runtime":            map[string]any{"_eq": "nodejs22"},
+			},
+			wantOrderBy: []map[string]string{{"created_at": "desc"}, {"id": "asc"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantWhere, tt.query.where())
+			require.Equal(t, tt.wantOrderBy, tt.query.orderBy())
+		})
+	}
+}
+
+func TestListProjects(t *testing.T) {
+	httpClient := resty.New()
+	httpmock.ActivateNonDefault(httpClient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "https://example.com",
+		func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				Variables listProjectsParams `json:"variables"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			require.Equal(t, "account", body.Variables.APIAccountID)
+			return httpmock.NewStringResponse(http.StatusOK, `{"data": {"Projects": [
+				{"id": "p1", "name": "alpha", "created_at": "2024-01-01T00:00:00", "updated_at": "2024-02-01T00:00:00", "Instances_aggregate": {"aggregate": {"count": 2}}},
+				{"id": "p2", "name": "beta", "created_at": "2024-03-01T00:00:00", "updated_at": "2024-03-01T00:00:00", "Instances_aggregate": {"aggregate": {"count": 0}}}
+			]}}`), nil
+		})
+
+	datastoreClient := NewDatastore(NewGraphQLClient("https://example.com", httpClient))
+	projects, err := datastoreClient.ListProjects(t.Context(), "account")
+	require.NoError(t, err)
+	require.Equal(t, []ProjectListItem{
+		{ID: "p1", Name: "alpha", CreatedAt: "2024-01-01T00:00:00", UpdatedAt: "2024-02-01T00:00:00", InstanceCount: 2},
+		{ID: "p2", Name: "beta", CreatedAt: "2024-03-01T00:00:00", UpdatedAt: "2024-03-01T00:00:00"},
+	}, projects)
+
+	httpmock.RegisterResponder("POST", "https://example.com", httpmock.NewErrorResponder(errors.New("transport error")))
+	_, err = datastoreClient.ListProjects(t.Context(), "account")
+	require.Error(t, err)
+}
+
+func TestListInstancesByProjectID(t *testing.T) {
+	httpClient := resty.New()
+	httpmock.ActivateNonDefault(httpClient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	instances := []InstanceListItem{
+		{ID: "i1", APIApplicationID: "app", Name: "dev", ServiceName: "alpha-dev"},
+		{ID: "i2", APIApplicationID: "app", Name: "prod", ServiceName: "alpha-prod"},
+	}
+	httpmock.RegisterResponder("POST", "https://example.com",
+		func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				Variables listInstancesByProjectIDParams `json:"variables"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			require.Equal(t, "p1", body.Variables.ProjectID)
+			return httpmock.NewJsonResponse(http.StatusOK, listInstancesResponse{
+				Data: listInstancesResponseData{Instances: instances},
+			})
+		})
+
+	datastoreClient := NewDatastore(NewGraphQLClient("https://example.com", httpClient))
+	output, err := datastoreClient.ListInstancesByProjectID(t.Context(), "p1")
+	require.NoError(t, err)
+	require.Equal(t, instances, output)
+}
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// redacted replaces secret values in the debug output.
+	redacted = "[REDACTED]"
+
+	// maxDebugBodySize is how much of a body is written to the debug output.
+	maxDebugBodySize = 4096
+)
+
+// secretHeaders are the headers whose value is never written to the debug output.
+var secretHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// secretKeyRegexp matches the JSON keys, form fields and query parameters that hold a secret value.
+var secretKeyRegexp = regexp.MustCompile(`(?i)secret|password|token|private_?key|credential|authorization`)
+
+// HTTPDebugger writes the requests sent to the API and their responses, with secret values masked.
+// The methods of a nil HTTPDebugger do nothing.
+type HTTPDebugger struct {
+	mu      sync.Mutex
+	out     io.Writer
+	secrets []string
+}
+
+// NewHTTPDebugger returns a debugger writing to out, which also masks the given secrets wherever
+// they appear.
+func NewHTTPDebugger(out io.Writer, secrets ...string) *HTTPDebugger {
+	d := &HTTPDebugger{out: out}
+	for _, s := range secrets {
+		if s != "" {
+			d.secrets = append(d.secrets, s)
+		}
+	}
+	return d
+}
+
+// Attach makes the debugger log every attempt of every request sent by client.
+func (d *HTTPDebugger) Attach(client *resty.Client) {
+	if d == nil {
+		return
+	}
+	client.SetTransport(&debugTransport{debugger: d, next: client.GetClient().Transport})
+}
+
+// LogDial logs the handshake of a websocket connection to url.
+func (d *HTTPDebugger) LogDial(url string, header http.Header, resp *http.Response, err error, latency time.Duration) {
+	if d == nil {
+		return
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "> GET %s (websocket)\n", redactURL(url))
+	writeHeader(&sb, ">", header)
+	writeResult(&sb, resp, err, latency)
+	if resp != nil {
+		writeHeader(&sb, "<", resp.Header)
+	}
+	d.write(sb.String())
+}
+
+func (d *HTTPDebugger) write(s string) {
+	for _, secret := range d.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, _ = io.WriteString(d.out, s+"\n")
+}
+
+type debugTransport struct {
+	debugger *HTTPDebugger
+	next     http.RoundTripper
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "> %s %s\n", req.Method, redactURL(req.URL.String()))
+	writeHeader(&sb, ">", req.Header)
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			writeBody(&sb, ">", req.Header.Get("Content-Type"), data)
+		}
+	}
+
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	writeResult(&sb, resp, err, time.Since(start))
+	if err == nil {
+		writeHeader(&sb, "<", resp.Header)
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if readErr != nil {
+			fmt.Fprintf(&sb, "< failed to read body: %s\n", readErr)
+		} else {
+			writeBody(&sb, "<", resp.Header.Get("Content-Type"), data)
+		}
+	}
+	t.debugger.write(sb.String())
+	return resp, err
+}
+
+func writeResult(sb *strings.Builder, resp *http.Response, err error, latency time.Duration) {
+	latency = latency.Round(time.Millisecond)
+	switch {
+	case resp != nil:
+		fmt.Fprintf(sb, "< %s (%s)\n", resp.Status, latency)
+	case err != nil:
+		fmt.Fprintf(sb, "< error: %s (%s)\n", err, latency)
+	}
+}
+
+func writeHeader(sb *strings.Builder, prefix string, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if secretHeaders[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		fmt.Fprintf(sb, "%s %s: %s\n", prefix, name, value)
+	}
+}
+
+// writeBody writes a text body with its secret values masked, and only the size of any other body.
+func writeBody(sb *strings.Builder, prefix, contentType string, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	var text string
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		text = redactJSON(data)
+	case mediaType == "application/x-www-form-urlencoded":
+		text = redactQuery(string(data))
+	case strings.HasPrefix(mediaType, "text/"):
+		text = string(data)
+	default:
+		fmt.Fprintf(sb, "%s [%d bytes of %s]\n", prefix, len(data), contentType)
+		return
+	}
+	if len(text) > maxDebugBodySize {
+		text = fmt.Sprintf("%s... (%d more bytes)", text[:maxDebugBodySize], len(text)-maxDebugBodySize)
+	}
+	fmt.Fprintf(sb, "%s %s\n", prefix, text)
+}
+
+func redactJSON(data []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return string(data)
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return string(data)
+	}
+	return string(out)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if secretKeyRegexp.MatchString(k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValue(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+	}
+	return v
+}
+
+func redactQuery(query string) string {
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		if name, err := url.QueryUnescape(key); err == nil && secretKeyRegexp.MatchString(name) {
+			params[i] = key + "=" + redacted
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.RawQuery == "" {
+		return rawURL
+	}
+	u.RawQuery = redactQuery(u.RawQuery)
+	return u.String()
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBodyRedactsSecrets(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{
+			name:        "json",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"secrets":[{"name":"db","value":"hunter2"}],"apiSecret":"s","nested":{"token":"t","name":"n"}}`,
+			want:        `> {"apiSecret":"[REDACTED]","nested":{"name":"n","token":"[REDACTED]"},"secrets":"[REDACTED]"}` + "\n",
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "name=app&client_secret=s",
+			want:        "> name=app&client_secret=[REDACTED]\n",
+		},
+		{
+			name:        "binary",
+			contentType: "application/gzip",
+			body:        "\x1f\x8b",
+			want:        "> [2 bytes of application/gzip]\n",
+		},
+		{
+			name:        "truncated",
+			contentType: "text/plain",
+			body:        strings.Repeat("a", maxDebugBodySize+10),
+			want:        "> " + strings.Repeat("a", maxDebugBodySize) + "... (10 more bytes)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			writeBody(&sb, ">", tt.contentType, []byte(tt.body))
+			require.Equal(t, tt.want, sb.String())
+		})
+	}
+}
+
+func TestHTTPDebuggerLogDial(t *testing.T) {
+	var out bytes.Buffer
+	d := NewHTTPDebugger(&out, "my-secret")
+
+	header := http.Header{"Authorization": []string{"Basic abc"}, TraceIDHeader: []string{"trace"}}
+	d.LogDial("wss://example.com/ws?token=my-secret&id=1", header, nil, errors.New("connection refused"), 1500*time.Microsecond)
+	require.Equal(t, strings.Join([]string{
+		"> GET wss://example.com/ws?token=[REDACTED]&id=1 (websocket)",
+		"> Authorization: [REDACTED]",
+		"> X-Neru-Traceid: trace",
+		"< error: connection refused (2ms)",
+		"",
+		"",
+	}, "\n"), out.String())
+
+	// a nil debugger logs nothing
+	var nilDebugger *HTTPDebugger
+	nilDebugger.LogDial("wss://example.com", nil, nil, nil, 0)
+}
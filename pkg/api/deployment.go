@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
-	"regexp"
 	"strings"
 
 	"github.com/cli/cli/v2/pkg/iostreams"
@@ -94,17 +94,24 @@ func (c *DeploymentClient) ListVonageApplications(ctx context.Context, filter st
 	return output, nil
 }
 
-func (c *DeploymentClient) GenerateVonageApplicationKeys(ctx context.Context, appID string) error {
+// GenerateVonageApplicationKeysOutput holds the private key of the newly generated key pair.
+type GenerateVonageApplicationKeysOutput struct {
+	PrivateKey string `json:"privateKey"`
+}
+
+func (c *DeploymentClient) GenerateVonageApplicationKeys(ctx context.Context, appID string) (GenerateVonageApplicationKeysOutput, error) {
+	var output GenerateVonageApplicationKeysOutput
 	resp, err := c.httpClient.R().
 		SetContext(ctx).
+		SetResult(&output).
 		Patch(c.baseURL + "/applications/" + appID + "/keys")
 	if err != nil {
-		return fmt.Errorf("%w: trace_id = %s", err, traceIDFromHTTPResponse(resp))
+		return GenerateVonageApplicationKeysOutput{}, fmt.Errorf("%w: trace_id = %s", err, traceIDFromHTTPResponse(resp))
 	}
 	if resp.IsError() {
-		return NewErrorFromHTTPResponse(resp)
+		return GenerateVonageApplicationKeysOutput{}, NewErrorFromHTTPResponse(resp)
 	}
-	return nil
+	return output, nil
 }
 
 func (c *DeploymentClient) DeleteVonageApplication(ctx context.Context, appID string) error {
@@ -123,6 +130,106 @@ func (c *DeploymentClient) DeleteVonageApplication(ctx context.Context, appID st
 	return nil
 }
 
+// VonageApplication describes a Vonage application and the webhooks of its capabilities, as returned by
+// GET and PATCH /applications/{id}:
+//
+//	{"id": "...", "name": "...", "capabilities": {"voice": {"answerUrl": "...", "eventUrl": "..."},
+//	 "messages": {"inboundUrl": "...", "statusUrl": "..."}, "rtc": {"eventUrl": "..."}}, "keys": {"publicKey": "..."}}
+//
+// A capability is enabled when it is not nil.
+type VonageApplication struct {
+	ID           string                  `json:"id"`
+	Name         string                  `json:"name"`
+	Capabilities ApplicationCapabilities `json:"capabilities"`
+	Keys         ApplicationKeys         `json:"keys"`
+}
+
+type ApplicationCapabilities struct {
+	Voice    *VoiceCapability    `json:"voice,omitempty"`
+	Messages *MessagesCapability `json:"messages,omitempty"`
+	RTC      *RTCCapability      `json:"rtc,omitempty"`
+}
+
+type VoiceCapability struct {
+	AnswerURL string `json:"answerUrl,omitempty"`
+	EventURL  string `json:"eventUrl,omitempty"`
+}
+
+type MessagesCapability struct {
+	InboundURL string `json:"inboundUrl,omitempty"`
+	StatusURL  string `json:"statusUrl,omitempty"`
+}
+
+type RTCCapability struct {
+	EventURL string `json:"eventUrl,omitempty"`
+}
+
+type ApplicationKeys struct {
+	PublicKey string `json:"publicKey"`
+}
+
+// GetVonageApplication returns the application with the given ID, or ErrNotFound.
+func (c *DeploymentClient) GetVonageApplication(ctx context.Context, appID string) (VonageApplication, error) {
+	var output VonageApplication
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetResult(&output).
+		Get(c.baseURL + "/applications/" + url.PathEscape(appID))
+	if err != nil {
+		return VonageApplication{}, fmt.Errorf("%w: trace_id = %s", err, traceIDFromHTTPResponse(resp))
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return VonageApplication{}, ErrNotFound
+	}
+	if resp.IsError() {
+		return VonageApplication{}, NewErrorFromHTTPResponse(resp)
+	}
+	// an application without ID means the response is not in the shape VonageApplication decodes
+	if output.ID == "" {
+		return VonageApplication{}, fmt.Errorf("unexpected response for application %q: no application ID, trace_id = %s", appID, traceIDFromHTTPResponse(resp))
+	}
+	return output, nil
+}
+
+// UpdateVonageApplicationInput lists the changes to make to an application, sent as the body of
+// PATCH /applications/{id}. The capability flags are those of the POST /applications body used by
+// CreateVonageApplication. Nil capability flags and empty webhook URLs leave the current value unchanged.
+type UpdateVonageApplicationInput struct {
+	EnableVoice    *bool  `json:"enableVoice,omitempty"`
+	EnableMessages *bool  `json:"enableMessages,omitempty"`
+	EnableRTC      *bool  `json:"enableRtc,omitempty"`
+	AnswerURL      string `json:"answerUrl,omitempty"`
+	EventURL       string `json:"eventUrl,omitempty"`
+	InboundURL     string `json:"inboundUrl,omitempty"`
+	StatusURL      string `json:"statusUrl,omitempty"`
+	RTCEventURL    string `json:"rtcEventUrl,omitempty"`
+}
+
+// UpdateVonageApplication applies the changes to the application and returns it as updated. Callers
+// should check the changes against the returned application rather than assume they were all applied.
+func (c *DeploymentClient) UpdateVonageApplication(ctx context.Context, appID string, input UpdateVonageApplicationInput) (VonageApplication, error) {
+	var output VonageApplication
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetBody(input).
+		SetResult(&output).
+		Patch(c.baseURL + "/applications/" + url.PathEscape(appID))
+	if err != nil {
+		return VonageApplication{}, fmt.Errorf("%w: trace_id = %s", err, traceIDFromHTTPResponse(resp))
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return VonageApplication{}, ErrNotFound
+	}
+	if resp.IsError() {
+		return VonageApplication{}, NewErrorFromHTTPResponse(resp)
+	}
+	// an application without ID means the response is not in the shape VonageApplication decodes
+	if output.ID == "" {
+		return VonageApplication{}, fmt.Errorf("unexpected response for application %q: no application ID, trace_id = %s", appID, traceIDFromHTTPResponse(resp))
+	}
+	return output, nil
+}
+
 type deployRequest struct {
 	Runtime          string       `json:"runtime"`
 	Region           string       `json:"region"`
@@ -269,6 +376,20 @@ func (c *DeploymentClient) CreateProject(ctx context.Context, projectName string
 	return result, nil
 }
 
+// DeleteProject deletes a project, which must not have instances left.
+func (c *DeploymentClient) DeleteProject(ctx context.Context, projectID string) error {
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		Delete(fmt.Sprintf("%s/projects/%s", c.baseURL, projectID))
+	if err != nil {
+		return fmt.Errorf("%w: trace_id = %s", err, traceIDFromHTTPResponse(resp))
+	}
+	if resp.IsError() {
+		return NewErrorFromHTTPResponse(resp)
+	}
+	return nil
+}
+
 type DeployInstanceArgs struct {
 	PackageID           string           `json:"packageId"`
 	ProjectID           string           `json:"projectId"`
@@ -338,39 +459,33 @@ func (c *DeploymentClient) UploadTgz(ctx context.Context, fileBytes []byte) (Upl
 	return result, nil
 }
 
-var completedRegex = regexp.MustCompile(`(?i)(status.*completed|completed.*status)`)
-var failedRegex = regexp.MustCompile(`(?i)(status.*failed|failed.*status|failed to watch build logs)`)
-
-func (c *DeploymentClient) WatchDeployment(ctx context.Context, out *iostreams.IOStreams, packageID string) error {
+// WatchDeployment follows the build of a package until it ends, rendering its phases and log lines to
+// out and writing the full build log to buildLog. A failed build returns a *BuildFailedError.
+func (c *DeploymentClient) WatchDeployment(ctx context.Context, out *iostreams.IOStreams, packageID string, buildLog io.Writer) error {
 	url := fmt.Sprintf("%s/packages/%s/build/watch", strings.Replace(c.baseURL, "http", "ws", 1), packageID)
-	err := c.websocketConnectionClient.ConnectWithRetry(url)
+	err := c.websocketConnectionClient.ConnectWithRetry(ctx, url)
 	if err != nil {
 		return err
 	}
-	defer c.websocketConnectionClient.conn.Close()
+	defer func() { c.websocketConnectionClient.conn.Close() }()
+	watcher := newBuildWatcher(out, buildLog, packageID)
 	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context exceeds deadline")
-		default:
-			_, message, err := c.websocketConnectionClient.conn.ReadMessage()
-			if err == nil {
-				fmt.Fprintf(out.Out, "%s\n", message)
-				if completedRegex.MatchString(string(message)) {
-					return nil
-				}
-				if failedRegex.MatchString(string(message)) {
-					return fmt.Errorf("error while building package %s", packageID)
-				}
-				continue
-			}
-			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
-				return fmt.Errorf("error while building package %s, normal closure from server", packageID)
-			}
-			c.websocketConnectionClient.conn.Close()
-			if err := c.websocketConnectionClient.ConnectWithRetry(url); err != nil {
+		message, err := c.websocketConnectionClient.ReadMessage(ctx)
+		if err == nil {
+			if done, err := watcher.handle(message); done {
 				return err
 			}
+			continue
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("stopped watching the build: %w", err)
+		}
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			return fmt.Errorf("error while building package %s, normal closure from server", packageID)
+		}
+		c.websocketConnectionClient.conn.Close()
+		if err := c.websocketConnectionClient.ConnectWithRetry(ctx, url); err != nil {
+			return err
 		}
 	}
 }
@@ -10,9 +10,12 @@ import (
 	"strings"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
 )
 
-const traceIDHeaderName = "X-Neru-Traceid"
+// TraceIDHeader identifies a request in the logs of the platform. Every request gets its own trace ID,
+// which is shown in the errors of the requests that fail.
+const TraceIDHeader = "X-Neru-Traceid"
 const traceIDNotAvailable = "n/a"
 
 var (
@@ -116,23 +119,29 @@ func (e Error) Error() string {
 	return sb.String()
 }
 
+// NewTraceID returns a new trace ID to send in the TraceIDHeader of a request.
+func NewTraceID() string {
+	return uuid.New().String()
+}
+
 func traceIDFromHTTPResponse(resp *resty.Response) string {
 	if resp == nil {
 		return traceIDNotAvailable
 	}
-	if t := resp.Header().Get(traceIDHeaderName); t != "" {
+	if t := resp.Header().Get(TraceIDHeader); t != "" {
 		return t
 	}
-	if t := resp.Request.Header.Get(traceIDHeaderName); t != "" {
+	if t := resp.Request.Header.Get(TraceIDHeader); t != "" {
 		return t
 	}
 	return traceIDNotAvailable
 }
+
 func traceIDFromWebsocketResponse(resp *http.Response) string {
-	if t := resp.Header.Get(traceIDHeaderName); t != "" {
+	if t := resp.Header.Get(TraceIDHeader); t != "" {
 		return t
 	}
-	if t := resp.Request.Header.Get(traceIDHeaderName); t != "" {
+	if t := resp.Request.Header.Get(TraceIDHeader); t != "" {
 		return t
 	}
 	return traceIDNotAvailable
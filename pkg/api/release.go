@@ -2,10 +2,15 @@ package api
 
 import (
 	"context"
+	"net/http"
+	"net/url"
 
 	"github.com/go-resty/resty/v2"
 )
 
+// releasesPerPage is the number of releases returned by ListReleases, the maximum allowed by GitHub.
+const releasesPerPage = "100"
+
 type ReleaseClient struct {
 	baseURL    string
 	httpClient *resty.Client
@@ -35,6 +40,42 @@ func (r *ReleaseClient) GetLatestRelease(ctx context.Context) (Release, error) {
 	return output, nil
 }
 
+// GetReleaseByTag returns the release with the given tag, or ErrNotFound if it does not exist.
+func (r *ReleaseClient) GetReleaseByTag(ctx context.Context, tag string) (Release, error) {
+	var output Release
+	resp, err := r.httpClient.R().
+		SetContext(ctx).
+		SetResult(&output).
+		Get(r.baseURL + "/releases/tags/" + url.PathEscape(tag))
+	if err != nil {
+		return Release{}, err
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return Release{}, ErrNotFound
+	}
+	if resp.IsError() {
+		return Release{}, NewErrorFromHTTPResponse(resp)
+	}
+	return output, nil
+}
+
+// ListReleases returns the most recent releases, including pre-releases, newest first.
+func (r *ReleaseClient) ListReleases(ctx context.Context) ([]Release, error) {
+	var output []Release
+	resp, err := r.httpClient.R().
+		SetContext(ctx).
+		SetQueryParam("per_page", releasesPerPage).
+		SetResult(&output).
+		Get(r.baseURL + "/releases")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, NewErrorFromHTTPResponse(resp)
+	}
+	return output, nil
+}
+
 func (r *ReleaseClient) GetAsset(ctx context.Context, url string) ([]byte, error) {
 	resp, err := r.httpClient.R().
 		SetContext(ctx).
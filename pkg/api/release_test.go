@@ -148,3 +148,102 @@ func TestGetAsset(t *testing.T) {
 		})
 	}
 }
+
+func TestGetReleaseByTag(t *testing.T) {
+	client := resty.New()
+	httpmock.ActivateNonDefault(client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	type mock struct {
+		mockResponse string
+		status       int
+	}
+
+	type want struct {
+		output Release
+		err    error
+	}
+
+	tests := []struct {
+		name string
+		mock mock
+		want want
+	}{
+		{
+			name: "200-happy-path",
+			mock: mock{
+				mockResponse: `{"tag_name": "v1.4.2", "prerelease": true, "assets": [{"name": "checksums.txt", "browser_download_url": "https://example.com/checksums.txt"}]}`,
+				status:       http.StatusOK,
+			},
+			want: want{
+				output: Release{
+					TagName:    "v1.4.2",
+					Prerelease: true,
+					Assets:     []Asset{{Name: "checksums.txt", BrowserDownloadURL: "https://example.com/checksums.txt"}},
+				},
+			},
+		},
+		{
+			name: "404-not-found",
+			mock: mock{
+				mockResponse: `{"message": "Not Found"}`,
+				status:       http.StatusNotFound,
+			},
+			want: want{
+				err: ErrNotFound,
+			},
+		},
+		{
+			name: "500-error",
+			mock: mock{
+				mockResponse: "internal error",
+				status:       http.StatusInternalServerError,
+			},
+			want: want{
+				err: errors.New("API Error Encountered: ( HTTP status: 500 Detailed message: internal error Trace ID: n/a )"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			httpmock.RegisterResponder("GET", "https://example.com/releases/tags/v1.4.2",
+				func(_ *http.Request) (*http.Response, error) {
+					resp := httpmock.NewStringResponse(tt.mock.status, tt.mock.mockResponse)
+					resp.Header.Set("Content-Type", "application/json")
+					return resp, nil
+				})
+
+			releaseClient := NewReleaseClient("https://example.com", client)
+
+			output, err := releaseClient.GetReleaseByTag(t.Context(), "v1.4.2")
+			if tt.want.err != nil {
+				require.EqualError(t, err, tt.want.err.Error())
+				httpmock.Reset()
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.output, output)
+			httpmock.Reset()
+		})
+	}
+}
+
+func TestListReleases(t *testing.T) {
+	client := resty.New()
+	httpmock.ActivateNonDefault(client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponderWithQuery("GET", "https://example.com/releases", "per_page=100",
+		func(_ *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(http.StatusOK, `[{"tag_name": "v1.5.0-beta.1", "prerelease": true}, {"tag_name": "v1.4.2"}]`)
+			resp.Header.Set("Content-Type", "application/json")
+			return resp, nil
+		})
+
+	releaseClient := NewReleaseClient("https://example.com", client)
+
+	output, err := releaseClient.ListReleases(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []Release{{TagName: "v1.5.0-beta.1", Prerelease: true}, {TagName: "v1.4.2"}}, output)
+}
@@ -1,6 +1,10 @@
 package api
 
-import "time"
+import (
+	"strings"
+	"text/template"
+	"time"
+)
 
 type Region struct {
 	Name              string `json:"name"`
@@ -13,16 +17,41 @@ type Region struct {
 	HostTemplate      string `json:"host_template"`
 }
 
+// HostURL returns the URL an instance with the given service name is served at in the region,
+// rendered from its host template. The platform returns a Go template of the full URL, e.g.
+// "https://{{.ServiceName}}.euw1.runtime.vonage.cloud", which 'vcr debug' has always rendered this way.
+func (r Region) HostURL(serviceName string) (string, error) {
+	t, err := template.New("host").Parse(r.HostTemplate)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, struct{ ServiceName string }{serviceName}); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
 type Instance struct {
 	ID          string `json:"id,omitempty"`
 	ServiceName string `json:"service_name,omitempty"`
 }
 
 type InstanceListItem struct {
-	ID               string `json:"id"`
-	APIApplicationID string `json:"api_application_id"`
-	Name             string `json:"name"`
-	ServiceName      string `json:"service_name"`
+	ID               string          `json:"id"`
+	APIApplicationID string          `json:"api_application_id"`
+	Name             string          `json:"name"`
+	ServiceName      string          `json:"service_name"`
+	Region           string          `json:"region"`
+	Runtime          string          `json:"runtime"`
+	CreatedAt        string          `json:"created_at"`
+	UpdatedAt        string          `json:"updated_at"`
+	Project          InstanceProject `json:"Project"`
+}
+
+// InstanceProject is the project an InstanceListItem belongs to.
+type InstanceProject struct {
+	Name string `json:"name"`
 }
 
 type Runtime struct {
@@ -33,6 +62,22 @@ type Runtime struct {
 	Comments   string `json:"comments"`
 }
 
+// Deprecated reports whether the runtime should no longer be used for new deployments, which the
+// platform marks with a comment starting with "deprecated", e.g. "deprecated, use nodejs22".
+func (r Runtime) Deprecated() bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(r.Comments)), "deprecated")
+}
+
+// DeprecationNote returns what the comment of a deprecated runtime says besides that it is
+// deprecated, e.g. "use nodejs22", or nothing.
+func (r Runtime) DeprecationNote() string {
+	if !r.Deprecated() {
+		return ""
+	}
+	note := strings.TrimSpace(r.Comments)[len("deprecated"):]
+	return strings.TrimSpace(strings.TrimLeft(note, " ,:;-"))
+}
+
 type Template struct {
 	Key     string `json:"key"`
 	Content []byte `json:"content"`
@@ -53,9 +98,20 @@ type Project struct {
 	UpdatedAt    string `json:"updated_at"`
 }
 
+type ProjectListItem struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	// InstanceCount is the number of non-deleted instances of the project.
+	InstanceCount int `json:"instance_count"`
+}
+
 type Release struct {
-	TagName string  `json:"tag_name"`
-	Assets  []Asset `json:"assets"`
+	TagName    string  `json:"tag_name"`
+	Prerelease bool    `json:"prerelease,omitempty"`
+	Draft      bool    `json:"draft,omitempty"`
+	Assets     []Asset `json:"assets"`
 }
 
 type Asset struct {
@@ -73,6 +129,21 @@ type ProductVersion struct {
 	ID string `json:"id,omitempty"`
 }
 
+// ProductTemplate is a product template with its latest version.
+type ProductTemplate struct {
+	Product
+	// LatestVersions holds the latest version of the product, it is empty when the product has no active version.
+	LatestVersions []ProductVersion `json:"latest_versions"`
+}
+
+// LatestVersion returns the ID of the latest version of the product, or "" if it has none.
+func (p ProductTemplate) LatestVersion() string {
+	if len(p.LatestVersions) == 0 {
+		return ""
+	}
+	return p.LatestVersions[0].ID
+}
+
 type Log struct {
 	LogLevel   string    `json:"log_level"`
 	SourceType string    `json:"source_type"`
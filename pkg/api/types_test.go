@@ -0,0 +1,17 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegionHostURL(t *testing.T) {
+	region := Region{HostTemplate: "https://{{.ServiceName}}.euw1.runtime.vonage.cloud"}
+	url, err := region.HostURL("my-svc")
+	require.NoError(t, err)
+	require.Equal(t, "https://my-svc.euw1.runtime.vonage.cloud", url)
+
+	_, err = Region{HostTemplate: "https://{{.ServiceName"}.HostURL("my-svc")
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
@@ -9,32 +10,61 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// WebsocketDialer opens websocket connections, logging their handshake when Debugger is set.
+// A nil WebsocketDialer dials with the default dialer.
+type WebsocketDialer struct {
+	Dialer   *websocket.Dialer
+	Debugger *HTTPDebugger
+}
+
+func (d *WebsocketDialer) Dial(url string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	return d.DialContext(context.Background(), url, header)
+}
+
+// DialContext opens a websocket connection, giving up on the handshake when ctx is done.
+func (d *WebsocketDialer) DialContext(ctx context.Context, url string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	dialer := websocket.DefaultDialer
+	if d != nil && d.Dialer != nil {
+		dialer = d.Dialer
+	}
+	start := time.Now()
+	conn, resp, err := dialer.DialContext(ctx, url, header)
+	if d != nil {
+		d.Debugger.LogDial(url, header, resp, err, time.Since(start))
+	}
+	return conn, resp, err
+}
+
 type WebsocketConnectionClient struct {
 	apiKey    string
 	apiSecret string
+	dialer    *WebsocketDialer
 	conn      *websocket.Conn
 }
 
-func NewWebsocketConnectionClient(apiKey string, apiSecret string) *WebsocketConnectionClient {
+func NewWebsocketConnectionClient(apiKey string, apiSecret string, dialer *WebsocketDialer) *WebsocketConnectionClient {
 	return &WebsocketConnectionClient{
 		apiKey:    apiKey,
 		apiSecret: apiSecret,
+		dialer:    dialer,
 	}
 }
 
-func (c *WebsocketConnectionClient) Connect(url string) error {
+func (c *WebsocketConnectionClient) Connect(ctx context.Context, url string) error {
 	headers := http.Header{}
 	headers.Add("X-Neru-Apiaccountid", c.apiKey)
 	authHeaderVal := base64.StdEncoding.EncodeToString([]byte(
 		fmt.Sprintf("%s:%s", c.apiKey, c.apiSecret),
 	))
 	headers.Add("Authorization", "Basic "+authHeaderVal)
-	newConn, resp, err := websocket.DefaultDialer.Dial(url, headers)
+	traceID := NewTraceID()
+	headers.Set(TraceIDHeader, traceID)
+	newConn, resp, err := c.dialer.DialContext(ctx, url, headers)
 	if err != nil {
 		if resp != nil {
 			return NewErrorFromWebsocketResponse(resp)
 		}
-		return fmt.Errorf("failed to dial ws server: %w: trace_id = %s", err, traceIDFromWebsocketResponse(resp))
+		return fmt.Errorf("failed to dial ws server: %w: trace_id = %s", err, traceID)
 	}
 	defer resp.Body.Close()
 
@@ -44,7 +74,7 @@ func (c *WebsocketConnectionClient) Connect(url string) error {
 	return nil
 }
 
-func (c *WebsocketConnectionClient) ConnectWithRetry(url string) error {
+func (c *WebsocketConnectionClient) ConnectWithRetry(ctx context.Context, url string) error {
 	backOffs := []time.Duration{
 		100 * time.Millisecond,
 		200 * time.Millisecond,
@@ -53,13 +83,31 @@ func (c *WebsocketConnectionClient) ConnectWithRetry(url string) error {
 		1000 * time.Millisecond,
 	}
 	for _, backDur := range backOffs {
-		if err := c.Connect(url); err == nil {
+		if err := c.Connect(ctx, url); err == nil {
 			return nil
 		}
-		time.Sleep(backDur)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backDur):
+		}
 	}
-	if err := c.Connect(url); err != nil {
+	if err := c.Connect(ctx, url); err != nil {
 		return fmt.Errorf("retried connecting %v times: %w", len(backOffs), err)
 	}
 	return nil
 }
+
+// ReadMessage returns the next message of the connection. Unlike the read of the connection, it stops
+// blocking as soon as ctx is done, returning the error of ctx; the connection can't be read from then on.
+func (c *WebsocketConnectionClient) ReadMessage(ctx context.Context) ([]byte, error) {
+	stop := context.AfterFunc(ctx, func() {
+		_ = c.conn.SetReadDeadline(time.Now())
+	})
+	defer stop()
+	_, message, err := c.conn.ReadMessage()
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return message, err
+}
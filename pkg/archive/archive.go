@@ -0,0 +1,311 @@
+// Package archive reads template archives and writes them to disk safely.
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConflictPolicy decides what happens to existing files that a template would overwrite.
+type ConflictPolicy string
+
+const (
+	ConflictAbort     ConflictPolicy = "abort"
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	ConflictSkip      ConflictPolicy = "skip"
+	ConflictKeepBoth  ConflictPolicy = "keep-both"
+
+	// KeepBothSuffix is added to the name of template files that conflict with existing files when keeping both.
+	KeepBothSuffix = ".template"
+)
+
+var ConflictPolicies = []ConflictPolicy{ConflictAbort, ConflictOverwrite, ConflictSkip, ConflictKeepBoth}
+
+var ErrUnsafe = errors.New("unsafe template archive")
+
+// Entry is a validated entry of a template archive. Name is relative to the destination directory.
+type Entry struct {
+	Name     string
+	Type     byte
+	Mode     os.FileMode
+	Data     []byte
+	Linkname string
+}
+
+// Read reads a tar.gz template archive and rejects it if any entry would be written outside of
+// the destination directory: absolute paths, ".." components, symbolic links pointing outside or through
+// other symbolic links, and hard links to anything but an earlier file of the archive. Other entry types,
+// e.g. devices, are ignored.
+func Read(fileBytes []byte) ([]Entry, error) {
+	gzr, err := gzip.NewReader(bytes.NewReader(fileBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a new gzip reader: %w", err)
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	var entries []Entry
+	files := make(map[string]Entry)
+	symlinks := make(map[string]bool)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tar file: %w", err)
+		}
+		name, err := safeRelPath(header.Name)
+		if err != nil {
+			return nil, err
+		}
+		if name == "." {
+			continue
+		}
+		entry := Entry{Name: name, Type: header.Typeflag, Mode: os.FileMode(header.Mode).Perm()}
+		switch header.Typeflag {
+		case tar.TypeDir:
+		case tar.TypeReg:
+			if entry.Data, err = io.ReadAll(tr); err != nil {
+				return nil, fmt.Errorf("failed to read file %s: %w", header.Name, err)
+			}
+			files[name] = entry
+		case tar.TypeSymlink:
+			if filepath.IsAbs(header.Linkname) || strings.HasPrefix(header.Linkname, "/") {
+				return nil, fmt.Errorf("%w: symbolic link %q points to absolute path %q", ErrUnsafe, header.Name, header.Linkname)
+			}
+			if _, err := safeRelPath(filepath.Join(filepath.Dir(name), header.Linkname)); err != nil {
+				return nil, fmt.Errorf("%w: symbolic link %q points outside of the destination", ErrUnsafe, header.Name)
+			}
+			entry.Linkname = header.Linkname
+			symlinks[name] = true
+		case tar.TypeLink:
+			// hard links are written as copies of the file they point to, which must come earlier in the archive
+			target, err := safeRelPath(header.Linkname)
+			if err != nil {
+				return nil, fmt.Errorf("%w: hard link %q: %w", ErrUnsafe, header.Name, err)
+			}
+			file, ok := files[target]
+			if !ok {
+				return nil, fmt.Errorf("%w: hard link %q points to %q, which is not a file of the archive", ErrUnsafe, header.Name, header.Linkname)
+			}
+			file.Name = name
+			entry = file
+			files[name] = file
+		default:
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := checkSymlinks(entries, symlinks); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// checkSymlinks rejects the entries written inside a symbolic link of the archive, whatever their order,
+// and the symbolic links whose target goes through another one. The targets of the links are only checked
+// as text, which holds as long as no link is followed to reach them: "a/b -> .." and "c -> a/b/.." each
+// look inside the destination, but together c points to its parent.
+func checkSymlinks(entries []Entry, symlinks map[string]bool) error {
+	for _, entry := range entries {
+		for parent := filepath.Dir(entry.Name); parent != "."; parent = filepath.Dir(parent) {
+			if symlinks[parent] {
+				return fmt.Errorf("%w: %q is inside symbolic link %q", ErrUnsafe, entry.Name, parent)
+			}
+		}
+		if entry.Type != tar.TypeSymlink {
+			continue
+		}
+		var path []string
+		if dir := filepath.Dir(entry.Name); dir != "." {
+			path = strings.Split(dir, string(filepath.Separator))
+		}
+		parts := strings.Split(filepath.ToSlash(entry.Linkname), "/")
+		for i, part := range parts {
+			switch part {
+			case "", ".":
+				continue
+			case "..":
+				if len(path) == 0 {
+					return fmt.Errorf("%w: symbolic link %q points outside of the destination", ErrUnsafe, entry.Name)
+				}
+				path = path[:len(path)-1]
+				continue
+			}
+			path = append(path, part)
+			// the link may point to another link, which is checked on its own, but not through it
+			if through := filepath.Join(path...); i < len(parts)-1 && symlinks[through] {
+				return fmt.Errorf("%w: symbolic link %q points through symbolic link %q", ErrUnsafe, entry.Name, through)
+			}
+		}
+	}
+	return nil
+}
+
+// safeRelPath cleans an archive path and returns an error if it is absolute or leaves its root.
+func safeRelPath(name string) (string, error) {
+	if strings.HasPrefix(name, "/") || strings.HasPrefix(name, `\`) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("%w: %q is an absolute path", ErrUnsafe, name)
+	}
+	clean := filepath.Clean(filepath.FromSlash(name))
+	if clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %q is outside of the destination", ErrUnsafe, name)
+	}
+	return clean, nil
+}
+
+// FindConflicts returns the entries that would replace existing files in dest. It fails if an entry
+// would be written through a symbolic link that already exists in dest, or replace a directory.
+func FindConflicts(entries []Entry, dest string) ([]string, error) {
+	var conflicts []string
+	for _, entry := range entries {
+		for parent := filepath.Dir(entry.Name); parent != "."; parent = filepath.Dir(parent) {
+			info, err := os.Lstat(filepath.Join(dest, parent))
+			if err == nil && info.Mode()&os.ModeSymlink != 0 {
+				return nil, fmt.Errorf("%w: %q would be written through the existing symbolic link %q", ErrUnsafe, entry.Name, parent)
+			}
+		}
+		info, err := os.Lstat(filepath.Join(dest, entry.Name))
+		switch {
+		case os.IsNotExist(err):
+		case err != nil:
+			return nil, err
+		case entry.Type == tar.TypeDir && !info.IsDir():
+			return nil, fmt.Errorf("cannot create directory %q, a file with the same name already exists", entry.Name)
+		case entry.Type != tar.TypeDir && info.IsDir():
+			return nil, fmt.Errorf("cannot create file %q, a directory with the same name already exists", entry.Name)
+		case entry.Type != tar.TypeDir:
+			conflicts = append(conflicts, entry.Name)
+		}
+	}
+	return conflicts, nil
+}
+
+// Write writes the entries of a template archive to dest, resolving conflicts with existing
+// files according to policy. Files are never written through existing symbolic links, and symbolic links
+// are created last so that no file of the archive is written through them either.
+func Write(entries []Entry, dest string, policy ConflictPolicy) error {
+	var links []Entry
+	for _, entry := range entries {
+		target := filepath.Join(dest, entry.Name)
+		switch entry.Type {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, os.ModePerm); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", target, err)
+			}
+			continue
+		case tar.TypeSymlink:
+			links = append(links, entry)
+			continue
+		}
+
+		target, write, err := resolveTarget(target, policy)
+		if err != nil {
+			return err
+		}
+		if !write {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		if err := writeFile(target, entry.Data, entry.Mode); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range links {
+		target, write, err := resolveTarget(filepath.Join(dest, entry.Name), policy)
+		if err != nil {
+			return err
+		}
+		if !write {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.Symlink(entry.Linkname, target); err != nil {
+			return fmt.Errorf("failed to create symbolic link %s: %w", target, err)
+		}
+	}
+	return nil
+}
+
+// resolveTarget returns where an entry should be written, and false if it should be skipped.
+func resolveTarget(target string, policy ConflictPolicy) (string, bool, error) {
+	if _, err := os.Lstat(target); os.IsNotExist(err) {
+		return target, true, nil
+	}
+	switch policy {
+	case ConflictOverwrite:
+		// removing the file first replaces a symbolic link instead of writing to what it points to
+		if err := os.Remove(target); err != nil {
+			return "", false, fmt.Errorf("failed to remove %s: %w", target, err)
+		}
+		return target, true, nil
+	case ConflictSkip:
+		return "", false, nil
+	case ConflictKeepBoth:
+		return keepBothName(target), true, nil
+	default:
+		return "", false, fmt.Errorf("%s already exists", target)
+	}
+}
+
+// keepBothName returns a free name for a template file next to the existing one, e.g. index.template.js.
+func keepBothName(path string) string {
+	dir, base := filepath.Split(path)
+	ext := filepath.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+	if stem == "" {
+		stem, ext = base, ""
+	}
+	name := filepath.Join(dir, stem+KeepBothSuffix+ext)
+	for i := 2; ; i++ {
+		if _, err := os.Lstat(name); os.IsNotExist(err) {
+			return name
+		}
+		name = filepath.Join(dir, fmt.Sprintf("%s%s-%d%s", stem, KeepBothSuffix, i, ext))
+	}
+}
+
+func writeFile(path string, data []byte, mode os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to copy file %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Extract writes the files of a template archive to dest as they are. It fails if any of them already
+// exists.
+func Extract(data []byte, dest string) error {
+	entries, err := Read(data)
+	if err != nil {
+		return err
+	}
+	conflicts, err := FindConflicts(entries, dest)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%d template file(s) already exist in %s: %s", len(conflicts), dest, strings.Join(conflicts, ", "))
+	}
+	return Write(entries, dest, ConflictAbort)
+}
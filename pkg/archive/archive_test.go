@@ -0,0 +1,263 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tarEntry struct {
+	name     string
+	typeflag byte
+	body     string
+	linkname string
+}
+
+func craftTarGz(t *testing.T, entries ...tarEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Typeflag: e.typeflag, Linkname: e.linkname, Mode: 0644}
+		if e.typeflag == tar.TypeDir {
+			header.Mode = 0755
+		}
+		if e.typeflag == tar.TypeReg {
+			header.Size = int64(len(e.body))
+		}
+		require.NoError(t, tw.WriteHeader(header))
+		if e.typeflag == tar.TypeReg {
+			_, err := tw.Write([]byte(e.body))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+	return buf.Bytes()
+}
+
+func TestReadArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tarEntry
+		errMsg  string
+		want    []string
+	}{
+		{
+			name:    "parent-directory-traversal",
+			entries: []tarEntry{{name: "../evil.sh", typeflag: tar.TypeReg, body: "x"}},
+			errMsg:  `unsafe template archive: "../evil.sh" is outside of the destination`,
+		},
+		{
+			name:    "nested-traversal",
+			entries: []tarEntry{{name: "src/../../evil.sh", typeflag: tar.TypeReg, body: "x"}},
+			errMsg:  `unsafe template archive: "src/../../evil.sh" is outside of the destination`,
+		},
+		{
+			name:    "absolute-path",
+			entries: []tarEntry{{name: "/etc/cron.d/evil", typeflag: tar.TypeReg, body: "x"}},
+			errMsg:  `unsafe template archive: "/etc/cron.d/evil" is an absolute path`,
+		},
+		{
+			name:    "symlink-escaping",
+			entries: []tarEntry{{name: "src/link", typeflag: tar.TypeSymlink, linkname: "../../etc"}},
+			errMsg:  `unsafe template archive: symbolic link "src/link" points outside of the destination`,
+		},
+		{
+			name:    "absolute-symlink",
+			entries: []tarEntry{{name: "link", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"}},
+			errMsg:  `unsafe template archive: symbolic link "link" points to absolute path "/etc/passwd"`,
+		},
+		{
+			name: "entry-inside-symlink",
+			entries: []tarEntry{
+				{name: "lib", typeflag: tar.TypeSymlink, linkname: "src"},
+				{name: "lib/index.js", typeflag: tar.TypeReg, body: "x"},
+			},
+			errMsg: `unsafe template archive: "lib/index.js" is inside symbolic link "lib"`,
+		},
+		{
+			name: "chained-symlinks-escaping",
+			entries: []tarEntry{
+				{name: "a/b", typeflag: tar.TypeSymlink, linkname: ".."},
+				{name: "c", typeflag: tar.TypeSymlink, linkname: "a/b/.."},
+			},
+			errMsg: `unsafe template archive: symbolic link "c" points through symbolic link "a/b"`,
+		},
+		{
+			name: "chained-symlinks-escaping-declared-first",
+			entries: []tarEntry{
+				{name: "c", typeflag: tar.TypeSymlink, linkname: "a/b/.."},
+				{name: "a/b", typeflag: tar.TypeSymlink, linkname: ".."},
+			},
+			errMsg: `unsafe template archive: symbolic link "c" points through symbolic link "a/b"`,
+		},
+		{
+			name: "entry-inside-symlink-declared-later",
+			entries: []tarEntry{
+				{name: "lib/index.js", typeflag: tar.TypeReg, body: "x"},
+				{name: "lib", typeflag: tar.TypeSymlink, linkname: "src"},
+			},
+			errMsg: `unsafe template archive: "lib/index.js" is inside symbolic link "lib"`,
+		},
+		{
+			name:    "hardlink-missing-target",
+			entries: []tarEntry{{name: "copy", typeflag: tar.TypeLink, linkname: "missing"}},
+			errMsg:  `unsafe template archive: hard link "copy" points to "missing", which is not a file of the archive`,
+		},
+		{
+			name:    "hardlink-outside",
+			entries: []tarEntry{{name: "copy", typeflag: tar.TypeLink, linkname: "../../etc/shadow"}},
+			errMsg:  `unsafe template archive: hard link "copy": unsafe template archive: "../../etc/shadow" is outside of the destination`,
+		},
+		{
+			name: "valid",
+			entries: []tarEntry{
+				{name: "./", typeflag: tar.TypeDir},
+				{name: "src/", typeflag: tar.TypeDir},
+				{name: "src/index.js", typeflag: tar.TypeReg, body: "console.log()"},
+				{name: "index.js", typeflag: tar.TypeLink, linkname: "src/index.js"},
+				{name: "main.js", typeflag: tar.TypeSymlink, linkname: "src/index.js"},
+				{name: "start.js", typeflag: tar.TypeSymlink, linkname: "./main.js"},
+				{name: "src/lib", typeflag: tar.TypeSymlink, linkname: "../src/./"},
+				{name: "dev", typeflag: tar.TypeChar},
+			},
+			want: []string{"src", "src/index.js", "index.js", "main.js", "start.js", "src/lib"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := Read(craftTarGz(t, tt.entries...))
+			if tt.errMsg != "" {
+				require.ErrorIs(t, err, ErrUnsafe)
+				require.Equal(t, tt.errMsg, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			var names []string
+			for _, e := range entries {
+				names = append(names, filepath.ToSlash(e.Name))
+			}
+			require.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	archive := []tarEntry{
+		{name: "src/", typeflag: tar.TypeDir},
+		{name: "src/index.js", typeflag: tar.TypeReg, body: "template"},
+		{name: "README.md", typeflag: tar.TypeReg, body: "template"},
+		{name: "copy.js", typeflag: tar.TypeLink, linkname: "src/index.js"},
+		{name: "main.js", typeflag: tar.TypeSymlink, linkname: "src/index.js"},
+	}
+
+	tests := []struct {
+		name      string
+		existing  map[string]string
+		policy    ConflictPolicy
+		conflicts []string
+		errMsg    string
+		want      map[string]string
+	}{
+		{
+			name:   "no-conflicts",
+			policy: ConflictAbort,
+			want: map[string]string{
+				"src/index.js": "template",
+				"README.md":    "template",
+				"copy.js":      "template",
+				"main.js":      "template",
+			},
+		},
+		{
+			name:      "overwrite",
+			existing:  map[string]string{"src/index.js": "mine"},
+			policy:    ConflictOverwrite,
+			conflicts: []string{"src/index.js"},
+			want:      map[string]string{"src/index.js": "template", "README.md": "template"},
+		},
+		{
+			name:      "skip",
+			existing:  map[string]string{"src/index.js": "mine"},
+			policy:    ConflictSkip,
+			conflicts: []string{"src/index.js"},
+			want:      map[string]string{"src/index.js": "mine", "main.js": "mine", "README.md": "template"},
+		},
+		{
+			name:      "keep-both",
+			existing:  map[string]string{"src/index.js": "mine", "README.md": "mine", "README.template.md": "older"},
+			policy:    ConflictKeepBoth,
+			conflicts: []string{"src/index.js", "README.md"},
+			want: map[string]string{
+				"src/index.js":          "mine",
+				"src/index.template.js": "template",
+				"README.md":             "mine",
+				"README.template.md":    "older",
+				"README.template-2.md":  "template",
+			},
+		},
+		{
+			name:      "abort",
+			existing:  map[string]string{"README.md": "mine"},
+			policy:    ConflictAbort,
+			conflicts: []string{"README.md"},
+			errMsg:    "README.md already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := t.TempDir()
+			for name, body := range tt.existing {
+				path := filepath.Join(dest, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, []byte(body), 0600))
+			}
+			entries, err := Read(craftTarGz(t, archive...))
+			require.NoError(t, err)
+
+			conflicts, err := FindConflicts(entries, dest)
+			require.NoError(t, err)
+			var names []string
+			for _, c := range conflicts {
+				names = append(names, filepath.ToSlash(c))
+			}
+			require.Equal(t, tt.conflicts, names)
+
+			err = Write(entries, dest, tt.policy)
+			if tt.errMsg != "" {
+				require.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			for name, body := range tt.want {
+				data, err := os.ReadFile(filepath.Join(dest, name))
+				require.NoError(t, err)
+				require.Equal(t, body, string(data), name)
+			}
+		})
+	}
+}
+
+func TestFindConflictsExistingSymlink(t *testing.T) {
+	dest := t.TempDir()
+	outside := t.TempDir()
+	require.NoError(t, os.Symlink(outside, filepath.Join(dest, "src")))
+
+	entries, err := Read(craftTarGz(t, tarEntry{name: "src/index.js", typeflag: tar.TypeReg, body: "x"}))
+	require.NoError(t, err)
+
+	_, err = FindConflicts(entries, dest)
+	require.ErrorIs(t, err, ErrUnsafe)
+	require.Equal(t, `unsafe template archive: "src/index.js" would be written through the existing symbolic link "src"`, err.Error())
+	_, err = os.Stat(filepath.Join(outside, "index.js"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -1,12 +1,18 @@
 package cmdutil
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/briandowns/spinner"
 	"github.com/mgutz/ansi"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/config"
 )
 
 const RightArrowIcon = "➜"
@@ -111,3 +117,47 @@ func ValidateFlags(instanceID, instanceName, projectName string) error {
 	}
 	return nil
 }
+
+// GetProject returns the project with the given name of the account of the API key.
+func GetProject(ctx context.Context, f Factory, name string) (api.Project, error) {
+	spinner := DisplaySpinnerMessageWithHandle(" Retrieving project...")
+	project, err := f.Datastore().GetProject(ctx, f.APIKey(), name)
+	spinner.Stop()
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return api.Project{}, fmt.Errorf("project %q not found, see 'vcr project list' for your projects", name)
+		}
+		return api.Project{}, fmt.Errorf("failed to get project %q: %w", name, err)
+	}
+	return project, nil
+}
+
+const skipAuthCheckAnnotation = "skipAuthCheck"
+
+// DisableAuthCheck marks a command as runnable without credentials or a reachable region,
+// so the root command skips initializing the API clients for it and its subcommands.
+func DisableAuthCheck(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[skipAuthCheckAnnotation] = "true"
+}
+
+// IsAuthCheckEnabled reports whether the command or any of its parents disabled the auth check.
+func IsAuthCheckEnabled(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations != nil && c.Annotations[skipAuthCheckAnnotation] == "true" {
+			return false
+		}
+	}
+	return true
+}
+
+// JSONOutput reports whether a command with a --json flag prints JSON: the value of the flag when it is
+// set, e.g. --json=false to print a table anyway, and the output_format setting otherwise.
+func JSONOutput(cmd *cobra.Command, cfg config.CLIConfig, jsonFlag bool) bool {
+	if cmd.Flags().Changed("json") {
+		return jsonFlag
+	}
+	return cfg.OutputFormat == config.OutputFormatJSON
+}
@@ -4,7 +4,10 @@ import (
 	"os"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/config"
 )
 
 func TestStringVar(t *testing.T) {
@@ -139,3 +142,44 @@ func TestValidateFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestDisableAuthCheck(t *testing.T) {
+	parent := &cobra.Command{Use: "parent"}
+	child := &cobra.Command{Use: "child"}
+	parent.AddCommand(child)
+
+	require.True(t, IsAuthCheckEnabled(parent))
+	require.True(t, IsAuthCheckEnabled(child))
+
+	DisableAuthCheck(parent)
+
+	require.False(t, IsAuthCheckEnabled(parent))
+	require.False(t, IsAuthCheckEnabled(child))
+}
+
+func TestJSONOutput(t *testing.T) {
+	jsonConfig := config.CLIConfig{OutputFormat: config.OutputFormatJSON}
+	tests := []struct {
+		name string
+		args []string
+		cfg  config.CLIConfig
+		want bool
+	}{
+		{name: "default", cfg: config.CLIConfig{}, want: false},
+		{name: "flag", args: []string{"--json"}, cfg: config.CLIConfig{}, want: true},
+		{name: "setting", cfg: jsonConfig, want: true},
+		{name: "table-setting", cfg: config.CLIConfig{OutputFormat: config.OutputFormatTable}, want: false},
+		{name: "flag-overrides-setting", args: []string{"--json=false"}, cfg: jsonConfig, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jsonFlag bool
+			cmd := &cobra.Command{Use: "list"}
+			cmd.Flags().BoolVar(&jsonFlag, "json", false, "")
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			require.Equal(t, tt.want, JSONOutput(cmd, tt.cfg, jsonFlag))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package cmdutil
+
+import (
+	"context"
+	"errors"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+// metadataCacheDir is where the region and runtime metadata is cached, overridden in tests.
+var metadataCacheDir = config.DefaultMetadataCacheDir
+
+// cachedDatastore serves the region and runtime lists from the metadata cache, which it fills from the
+// datastore when they are missing or older than config.MetadataCacheTTL. With refresh, the cache is
+// not read but still written, so that the following commands use the fresh values.
+type cachedDatastore struct {
+	*api.Datastore
+
+	cache config.MetadataCache
+	// endpoint and apiKey are part of the cache keys, as each GraphQL endpoint and account may see
+	// different regions and runtimes.
+	endpoint string
+	apiKey   string
+	refresh  bool
+}
+
+func newCachedDatastore(ds *api.Datastore, endpoint, apiKey string, refresh bool) DatastoreInterface {
+	cache, err := config.NewMetadataCache(metadataCacheDir, config.MetadataCacheTTL)
+	if err != nil {
+		return ds
+	}
+	return &cachedDatastore{Datastore: ds, cache: cache, endpoint: endpoint, apiKey: apiKey, refresh: refresh}
+}
+
+func (d *cachedDatastore) ListRegions(ctx context.Context) ([]api.Region, error) {
+	return cachedQuery(ctx, d, "regions", d.Datastore.ListRegions)
+}
+
+// GetRegion always asks the datastore, as a region disabled since the list was cached must not be used.
+// A region that is not found anymore is dropped from the cache by refreshing the list.
+func (d *cachedDatastore) GetRegion(ctx context.Context, alias string) (api.Region, error) {
+	region, err := d.Datastore.GetRegion(ctx, alias)
+	if errors.Is(err, api.ErrNotFound) {
+		d.evict("regions")
+	}
+	return region, err
+}
+
+func (d *cachedDatastore) ListRuntimes(ctx context.Context) ([]api.Runtime, error) {
+	return cachedQuery(ctx, d, "runtimes", d.Datastore.ListRuntimes)
+}
+
+// GetRuntimeByName always asks the datastore, like GetRegion.
+func (d *cachedDatastore) GetRuntimeByName(ctx context.Context, name string) (api.Runtime, error) {
+	runtime, err := d.Datastore.GetRuntimeByName(ctx, name)
+	if errors.Is(err, api.ErrNotFound) {
+		d.evict("runtimes")
+	}
+	return runtime, err
+}
+
+func (d *cachedDatastore) key(name string) string {
+	return name + " " + d.endpoint + " " + d.apiKey
+}
+
+// evict removes the cached result of the query called name, so that the next command runs it again.
+func (d *cachedDatastore) evict(name string) {
+	// like a failed write, a failed removal only costs the TTL
+	_ = d.cache.Delete(d.key(name))
+}
+
+// cachedQuery returns the cached result of the query called name, or runs it and caches its result.
+func cachedQuery[T any](ctx context.Context, d *cachedDatastore, name string, query func(ctx context.Context) (T, error)) (T, error) {
+	key := d.key(name)
+	if !d.refresh {
+		var result T
+		// an unreadable cache is a missing one
+		if err := d.cache.Get(key, &result); err == nil {
+			return result, nil
+		}
+	}
+	result, err := query(ctx)
+	if err != nil {
+		return result, err
+	}
+	// the cache only saves requests, a command doesn't fail because it can't be written
+	_ = d.cache.Put(key, result)
+	return result, nil
+}
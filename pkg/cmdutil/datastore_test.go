@@ -0,0 +1,142 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+// newGraphQLServer answers the region and runtime queries, and counts them by the table they select.
+// The regions and runtimes named "gone" were disabled.
+func newGraphQLServer(t *testing.T) (*httptest.Server, map[string]int) {
+	t.Helper()
+	queries := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var data map[string]any
+		switch {
+		case strings.Contains(req.Query, "alias: {_eq: $alias}"):
+			queries["GetRegion"]++
+			regions := []api.Region{}
+			if req.Variables["alias"] != "aws.gone" {
+				regions = append(regions, api.Region{Alias: req.Variables["alias"].(string), DeploymentAPIURL: "https://use1.example.com"})
+			}
+			data = map[string]any{"Regions": regions}
+		case strings.Contains(req.Query, "Regions("):
+			queries["ListRegions"]++
+			data = map[string]any{"Regions": []api.Region{{Alias: "aws.euw1", DeploymentAPIURL: "https://euw1.example.com"}}}
+		case strings.Contains(req.Query, "name: {_eq: $name}"):
+			queries["GetRuntimeByName"]++
+			runtimes := []api.Runtime{}
+			if req.Variables["name"] != "gone" {
+				runtimes = append(runtimes, api.Runtime{Name: req.Variables["name"].(string), Language: "nodejs"})
+			}
+			data = map[string]any{"Runtimes": runtimes}
+		case strings.Contains(req.Query, "Runtimes("):
+			queries["ListRuntimes"]++
+			data = map[string]any{"Runtimes": []api.Runtime{{Name: "nodejs22", Language: "nodejs"}}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"data": data}))
+	}))
+	t.Cleanup(server.Close)
+	return server, queries
+}
+
+func TestCachedDatastore(t *testing.T) {
+	metadataCacheDir = t.TempDir()
+	t.Cleanup(func() { metadataCacheDir = config.DefaultMetadataCacheDir })
+	server, queries := newGraphQLServer(t)
+
+	ds := getDatastore(server.URL, "key", resty.New(), false)
+	regions, err := ds.ListRegions(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "https://euw1.example.com", regions[0].DeploymentAPIURL)
+	_, err = ds.ListRuntimes(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"ListRegions": 1, "ListRuntimes": 1}, queries)
+
+	// the next commands use the cache
+	ds = getDatastore(server.URL, "key", resty.New(), false)
+	_, err = ds.ListRegions(t.Context())
+	require.NoError(t, err)
+	_, err = ds.ListRuntimes(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"ListRegions": 1, "ListRuntimes": 1}, queries)
+
+	// another endpoint or account has its own cache
+	_, err = getDatastore(server.URL+"/v2", "key", resty.New(), false).ListRegions(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 2, queries["ListRegions"])
+	_, err = getDatastore(server.URL, "other-key", resty.New(), false).ListRegions(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 3, queries["ListRegions"])
+
+	// --refresh skips the cache
+	_, err = getDatastore(server.URL, "key", resty.New(), true).ListRegions(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 4, queries["ListRegions"])
+}
+
+func TestCachedDatastoreLookupsAreNotCached(t *testing.T) {
+	metadataCacheDir = t.TempDir()
+	t.Cleanup(func() { metadataCacheDir = config.DefaultMetadataCacheDir })
+	server, queries := newGraphQLServer(t)
+
+	ds := getDatastore(server.URL, "key", resty.New(), false)
+	_, err := ds.ListRegions(t.Context())
+	require.NoError(t, err)
+	_, err = ds.ListRuntimes(t.Context())
+	require.NoError(t, err)
+
+	// a cached region or runtime is still asked for, as it may have been disabled since
+	region, err := ds.GetRegion(t.Context(), "aws.euw1")
+	require.NoError(t, err)
+	require.Equal(t, "aws.euw1", region.Alias)
+	runtime, err := ds.GetRuntimeByName(t.Context(), "nodejs22")
+	require.NoError(t, err)
+	require.Equal(t, "nodejs", runtime.Language)
+	require.Equal(t, map[string]int{"ListRegions": 1, "ListRuntimes": 1, "GetRegion": 1, "GetRuntimeByName": 1}, queries)
+
+	// a disabled region or runtime drops the cached list
+	_, err = ds.GetRegion(t.Context(), "aws.gone")
+	require.ErrorIs(t, err, api.ErrNotFound)
+	_, err = ds.GetRuntimeByName(t.Context(), "gone")
+	require.ErrorIs(t, err, api.ErrNotFound)
+	_, err = ds.ListRegions(t.Context())
+	require.NoError(t, err)
+	_, err = ds.ListRuntimes(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 2, queries["ListRegions"])
+	require.Equal(t, 2, queries["ListRuntimes"])
+}
+
+func TestDefaultFactoryInitDeploymentClientReusesRegion(t *testing.T) {
+	metadataCacheDir = t.TempDir()
+	t.Cleanup(func() { metadataCacheDir = config.DefaultMetadataCacheDir })
+	server, queries := newGraphQLServer(t)
+
+	f := NewDefaultFactory("v0.4", "")
+	f.httpClient = resty.New()
+	f.datastore = getDatastore(server.URL, "key", f.httpClient, true)
+
+	_, err := f.getRegion(t.Context(), "aws.euw1")
+	require.NoError(t, err)
+	require.NoError(t, f.InitDeploymentClient(t.Context(), "aws.euw1"))
+	require.Equal(t, 1, queries["GetRegion"], "the region looked up by Init should be reused")
+
+	require.NoError(t, f.InitDeploymentClient(t.Context(), "aws.use1"))
+	require.Equal(t, 2, queries["GetRegion"])
+}
@@ -4,11 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/cli/cli/v2/pkg/iostreams"
 	"github.com/go-resty/resty/v2"
-	"github.com/google/uuid"
 
 	"vonage-cloud-runtime-cli/pkg/api"
 	"vonage-cloud-runtime-cli/pkg/config"
@@ -29,6 +29,8 @@ type AssetInterface interface {
 
 type ReleaseInterface interface {
 	GetLatestRelease(ctx context.Context) (api.Release, error)
+	GetReleaseByTag(ctx context.Context, tag string) (api.Release, error)
+	ListReleases(ctx context.Context) ([]api.Release, error)
 	GetAsset(ctx context.Context, url string) ([]byte, error)
 }
 
@@ -39,18 +41,21 @@ type MarketplaceInterface interface {
 type DeploymentInterface interface {
 	CreateVonageApplication(ctx context.Context, name string, enableRTC, enableVoice, enableMessages bool) (api.CreateVonageApplicationOutput, error)
 	ListVonageApplications(ctx context.Context, filter string) (api.ListVonageApplicationsOutput, error)
-	GenerateVonageApplicationKeys(ctx context.Context, appID string) error
+	GenerateVonageApplicationKeys(ctx context.Context, appID string) (api.GenerateVonageApplicationKeysOutput, error)
 	DeleteVonageApplication(ctx context.Context, appID string) error
+	GetVonageApplication(ctx context.Context, appID string) (api.VonageApplication, error)
+	UpdateVonageApplication(ctx context.Context, appID string, input api.UpdateVonageApplicationInput) (api.VonageApplication, error)
 	DeployDebugService(ctx context.Context, region, applicationID, name string, caps api.Capabilities) (api.DeployResponse, error)
 	GetServiceReadyStatus(ctx context.Context, serviceName string) (bool, error)
 	DeleteDebugService(ctx context.Context, serviceName string, preserveData bool) error
 	PruneDebugSessions(ctx context.Context) error
 	CreatePackage(ctx context.Context, createPackageArgs api.CreatePackageArgs) (api.CreatePackageResponse, error)
 	CreateProject(ctx context.Context, projectName string) (api.CreateProjectResponse, error)
+	DeleteProject(ctx context.Context, projectID string) error
 	DeployInstance(ctx context.Context, deployInstanceArgs api.DeployInstanceArgs) (api.DeployInstanceResponse, error)
 	DeleteInstance(ctx context.Context, instanceID string) error
 	UploadTgz(ctx context.Context, fileBytes []byte) (api.UploadResponse, error)
-	WatchDeployment(ctx context.Context, out *iostreams.IOStreams, packageID string) error
+	WatchDeployment(ctx context.Context, out *iostreams.IOStreams, packageID string, buildLog io.Writer) error
 	CreateSecret(ctx context.Context, s config.Secret) error
 	UpdateSecret(ctx context.Context, s config.Secret) error
 	RemoveSecret(ctx context.Context, name string) error
@@ -68,10 +73,14 @@ type DatastoreInterface interface {
 	GetInstanceByProjectAndInstanceName(ctx context.Context, projectName, instanceName string) (api.Instance, error)
 	GetInstanceByID(ctx context.Context, instanceID string) (api.Instance, error)
 	ListInstances(ctx context.Context, filter string) ([]api.InstanceListItem, error)
+	ListInstancesPages(ctx context.Context, query api.InstanceQuery, pageSize int, fn func(page []api.InstanceListItem) error) error
 	ListRuntimes(ctx context.Context) ([]api.Runtime, error)
 	GetRuntimeByName(ctx context.Context, name string) (api.Runtime, error)
 	GetProject(ctx context.Context, accountID, name string) (api.Project, error)
+	ListProjects(ctx context.Context, accountID string) ([]api.ProjectListItem, error)
+	ListInstancesByProjectID(ctx context.Context, projectID string) ([]api.InstanceListItem, error)
 	ListProducts(ctx context.Context) ([]api.Product, error)
+	ListProductTemplates(ctx context.Context) ([]api.ProductTemplate, error)
 	GetLatestProductVersionByID(ctx context.Context, id string) (api.ProductVersion, error)
 	ListLogsByInstanceID(ctx context.Context, instanceID string, limit int, timestamp time.Time) ([]api.Log, error)
 }
@@ -79,8 +88,8 @@ type DatastoreInterface interface {
 // Factory provides clients and parameters for all subcommands.
 type Factory interface {
 	Init(ctx context.Context, cfg config.CLIConfig, opts *config.GlobalOptions) error
-	InitUpgrade(opts *config.GlobalOptions)
-	InitDatastore(cfg config.CLIConfig, opts *config.GlobalOptions)
+	InitUpgrade(opts *config.GlobalOptions) error
+	InitDatastore(cfg config.CLIConfig, opts *config.GlobalOptions) error
 	InitDeploymentClient(ctx context.Context, regionAlias string) error
 	SetGlobalOptions(opts *config.GlobalOptions)
 	SetCliConfig(opts config.CLIConfig)
@@ -116,9 +125,12 @@ type DefaultFactory struct {
 	httpClient                *resty.Client
 	assetClient               *api.AssetClient
 	deploymentClient          *api.DeploymentClient
-	datastore                 *api.Datastore
+	datastore                 DatastoreInterface
 	releaseClient             *api.ReleaseClient
 	marketplaceClient         *api.MarketplaceClient
+
+	// region is the last region looked up, reused when the deployment client is set up for it.
+	region api.Region
 }
 
 func NewDefaultFactory(apiVersion string, releaseURL string) *DefaultFactory {
@@ -135,14 +147,25 @@ func (f *DefaultFactory) Init(ctx context.Context, cfg config.CLIConfig, opts *c
 
 	f.cliConfig = cfg
 	f.globalOpts = opts
-	f.websocketConnectionClient = getWebsocketConnectionClient(f.APIKey(), f.APISecret())
-	f.httpClient = GetHTTPClient(f.APIKey(), f.APISecret())
-	f.datastore = getDatastore(f.GraphQLURL(), f.httpClient)
-	region, err := f.datastore.GetRegion(ctx, f.Region())
+	p, err := cfg.Profile(opts.Profile)
 	if err != nil {
-		if errors.Is(err, api.ErrNotFound) {
-			return fmt.Errorf("region does not exist")
+		return err
+	}
+	if !opts.HasCredentials() {
+		resolved, err := p.ResolveCredentials(ctx, opts.Profile, config.NewDefaultCredentialStore())
+		if err != nil {
+			return fmt.Errorf("failed to resolve credentials: %w", err)
 		}
+		f.cliConfig.SetProfile(opts.Profile, resolved)
+	}
+	dialer, err := f.initHTTPClient(opts, f.APIKey(), f.APISecret())
+	if err != nil {
+		return err
+	}
+	f.websocketConnectionClient = api.NewWebsocketConnectionClient(f.APIKey(), f.APISecret(), dialer)
+	f.datastore = getDatastore(f.GraphQLURL(), f.APIKey(), f.httpClient, opts.Refresh)
+	region, err := f.getRegion(ctx, f.Region())
+	if err != nil {
 		return err
 	}
 	f.assetClient = api.NewAssetClient(region.AssetsAPIURL, f.httpClient)
@@ -152,31 +175,64 @@ func (f *DefaultFactory) Init(ctx context.Context, cfg config.CLIConfig, opts *c
 	return nil
 }
 
-func (f *DefaultFactory) InitUpgrade(opts *config.GlobalOptions) {
+func (f *DefaultFactory) InitUpgrade(opts *config.GlobalOptions) error {
 	f.globalOpts = opts
-	f.httpClient = GetHTTPClient("", "")
+	if _, err := f.initHTTPClient(opts, "", ""); err != nil {
+		return err
+	}
 	f.releaseClient = api.NewReleaseClient(f.releaseURL, f.httpClient)
+	return nil
 }
 
-func (f *DefaultFactory) InitDatastore(cfg config.CLIConfig, opts *config.GlobalOptions) {
+func (f *DefaultFactory) InitDatastore(cfg config.CLIConfig, opts *config.GlobalOptions) error {
 	f.globalOpts = opts
 	f.cliConfig = cfg
-	f.httpClient = GetHTTPClient(f.APIKey(), f.APISecret())
-	f.datastore = getDatastore(f.GraphQLURL(), f.httpClient)
+	if _, err := f.initHTTPClient(opts, f.APIKey(), f.APISecret()); err != nil {
+		return err
+	}
+	f.datastore = getDatastore(f.GraphQLURL(), f.APIKey(), f.httpClient, opts.Refresh)
+	return nil
+}
+
+// initHTTPClient sets the API client up with the TLS, proxy, retry and debug settings of the global
+// options, and returns the websocket dialer sharing them.
+func (f *DefaultFactory) initHTTPClient(opts *config.GlobalOptions, apiKey, apiSecret string) (*api.WebsocketDialer, error) {
+	transport, err := NewTransport(opts)
+	if err != nil {
+		return nil, err
+	}
+	debugger := NewHTTPDebugger(opts, f.ioStreams.ErrOut, apiSecret)
+	f.httpClient = GetHTTPClient(apiKey, apiSecret, opts.Retries).SetTransport(transport)
+	debugger.Attach(f.httpClient)
+	return NewWebsocketDialer(transport, debugger), nil
 }
 
 func (f *DefaultFactory) InitDeploymentClient(ctx context.Context, regionAlias string) error {
-	region, err := f.datastore.GetRegion(ctx, regionAlias)
+	region, err := f.getRegion(ctx, regionAlias)
 	if err != nil {
-		if errors.Is(err, api.ErrNotFound) {
-			return fmt.Errorf("region does not exist")
-		}
 		return err
 	}
 	f.deploymentClient = api.NewDeploymentClient(region.DeploymentAPIURL, f.apiVersion, f.httpClient, f.websocketConnectionClient)
 	return nil
 }
 
+// getRegion returns the region with the given alias, without asking the datastore again for the
+// region looked up last, which is usually the one Init already fetched.
+func (f *DefaultFactory) getRegion(ctx context.Context, alias string) (api.Region, error) {
+	if f.region.Alias != "" && f.region.Alias == alias {
+		return f.region, nil
+	}
+	region, err := f.datastore.GetRegion(ctx, alias)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return api.Region{}, fmt.Errorf("region does not exist")
+		}
+		return api.Region{}, err
+	}
+	f.region = region
+	return region, nil
+}
+
 func (f *DefaultFactory) SetGlobalOptions(opts *config.GlobalOptions) {
 	f.globalOpts = opts
 }
@@ -229,6 +285,13 @@ func (f *DefaultFactory) CliConfig() config.CLIConfig {
 	return f.cliConfig
 }
 
+// profile returns the config values of the profile selected by the global options.
+// An unknown profile resolves to empty values; Init reports it as an error.
+func (f *DefaultFactory) profile() config.Profile {
+	p, _ := f.cliConfig.Profile(f.globalOpts.Profile)
+	return p
+}
+
 func (f *DefaultFactory) GraphQLURL() string {
 	if f.globalOpts.GraphqlEndpoint != "" {
 		return f.globalOpts.GraphqlEndpoint
@@ -236,28 +299,32 @@ func (f *DefaultFactory) GraphQLURL() string {
 	if f.globalOpts.Region != "" {
 		return makeGraphqlEndpoint(f.globalOpts.Region)
 	}
-	return f.cliConfig.GraphqlEndpoint
+	p := f.profile()
+	if p.GraphqlEndpoint == "" && p.DefaultRegion != "" {
+		return makeGraphqlEndpoint(p.DefaultRegion)
+	}
+	return p.GraphqlEndpoint
 }
 
 func (f *DefaultFactory) Region() string {
 	if f.globalOpts.Region != "" {
 		return f.globalOpts.Region
 	}
-	return f.cliConfig.DefaultRegion
+	return f.profile().DefaultRegion
 }
 
 func (f *DefaultFactory) APIKey() string {
 	if f.globalOpts.APIKey != "" {
 		return f.globalOpts.APIKey
 	}
-	return f.cliConfig.APIKey
+	return f.profile().APIKey
 }
 
 func (f *DefaultFactory) APISecret() string {
 	if f.globalOpts.APISecret != "" {
 		return f.globalOpts.APISecret
 	}
-	return f.cliConfig.APISecret
+	return f.profile().APISecret
 }
 
 func (f *DefaultFactory) Timeout() time.Duration {
@@ -268,21 +335,37 @@ func (f *DefaultFactory) Deadline() time.Time {
 	return f.globalOpts.Deadline
 }
 
-func GetHTTPClient(apiKey, apiSecret string) *resty.Client {
+// GetHTTPClient returns the client used for all API calls. Idempotent requests are retried up to
+// retries times, see setRetryPolicy. Each request is sent with its own trace ID, which its retries
+// keep so that they can be followed in the logs of the platform.
+func GetHTTPClient(apiKey, apiSecret string, retries int) *resty.Client {
 	client := resty.New()
+	setRetryPolicy(client, retries)
 	client.SetBasicAuth(apiKey, apiSecret)
 	client.SetHeader("X-Neru-ApiAccountId", apiKey)
-	client.SetHeader("X-Neru-TraceId", uuid.New().String())
+	client.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		if req.Header.Get(api.TraceIDHeader) == "" {
+			req.SetHeader(api.TraceIDHeader, api.NewTraceID())
+		}
+		return nil
+	})
 	return client
 }
 
-func getDatastore(graphQLURL string, httpClient *resty.Client) *api.Datastore {
-	gqlClient := api.NewGraphQLClient(graphQLURL, httpClient)
-	return api.NewDatastore(gqlClient)
+// NewHTTPDebugger returns the debugger logging the API traffic to out when --debug-http is set, and
+// nil otherwise. The given secrets are masked in the output.
+func NewHTTPDebugger(opts *config.GlobalOptions, out io.Writer, secrets ...string) *api.HTTPDebugger {
+	if opts == nil || !opts.DebugHTTP {
+		return nil
+	}
+	return api.NewHTTPDebugger(out, secrets...)
 }
 
-func getWebsocketConnectionClient(apiKey, apiSecret string) *api.WebsocketConnectionClient {
-	return api.NewWebsocketConnectionClient(apiKey, apiSecret)
+// getDatastore returns the datastore of the GraphQL endpoint, with the region and runtime metadata of
+// the account cached on disk unless refresh is set.
+func getDatastore(graphQLURL, apiKey string, httpClient *resty.Client, refresh bool) DatastoreInterface {
+	gqlClient := api.NewGraphQLClient(graphQLURL, httpClient)
+	return newCachedDatastore(api.NewDatastore(gqlClient), graphQLURL, apiKey, refresh)
 }
 
 func makeGraphqlEndpoint(region string) string {
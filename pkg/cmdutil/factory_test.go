@@ -0,0 +1,109 @@
+package cmdutil
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+func TestDefaultFactoryProfileResolution(t *testing.T) {
+	cfg := config.CLIConfig{
+		GraphqlEndpoint: "https://graphql.euw1.runtime.vonage.cloud/v1/graphql",
+		DefaultRegion:   "aws.euw1",
+		Credentials: config.Credentials{
+			APIKey:    "sandbox-key",
+			APISecret: "sandbox-secret",
+		},
+	}
+	cfg.SetProfile("prod", config.Profile{
+		DefaultRegion: "aws.use1",
+		APIKey:        "prod-key",
+		APISecret:     "prod-secret",
+	})
+
+	f := NewDefaultFactory("v0.4", "")
+	f.SetCliConfig(cfg)
+
+	f.SetGlobalOptions(&config.GlobalOptions{})
+	require.Equal(t, "sandbox-key", f.APIKey())
+	require.Equal(t, "sandbox-secret", f.APISecret())
+	require.Equal(t, "aws.euw1", f.Region())
+	require.Equal(t, "https://graphql.euw1.runtime.vonage.cloud/v1/graphql", f.GraphQLURL())
+
+	f.SetGlobalOptions(&config.GlobalOptions{Profile: "prod"})
+	require.Equal(t, "prod-key", f.APIKey())
+	require.Equal(t, "prod-secret", f.APISecret())
+	require.Equal(t, "aws.use1", f.Region())
+	require.Equal(t, "https://graphql.use1.runtime.vonage.cloud/v1/graphql", f.GraphQLURL())
+
+	f.SetGlobalOptions(&config.GlobalOptions{Profile: "prod", APIKey: "flag-key", Region: "aws.apse1"})
+	require.Equal(t, "flag-key", f.APIKey())
+	require.Equal(t, "prod-secret", f.APISecret())
+	require.Equal(t, "aws.apse1", f.Region())
+
+	err := f.Init(t.Context(), cfg, &config.GlobalOptions{Profile: "staging"})
+	require.ErrorIs(t, err, config.ErrProfileNotFound)
+}
+
+func TestGetHTTPClientTraceID(t *testing.T) {
+	retryWaitTime, retryMaxWaitTime = time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() { retryWaitTime, retryMaxWaitTime = 500*time.Millisecond, 20*time.Second })
+
+	var traceIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceIDs = append(traceIDs, r.Header.Get(api.TraceIDHeader))
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := GetHTTPClient("key", "secret", 1)
+	_, err := client.R().Get(server.URL)
+	require.NoError(t, err)
+	_, err = client.R().Get(server.URL)
+	require.NoError(t, err)
+	require.Len(t, traceIDs, 2)
+	require.NotEmpty(t, traceIDs[0])
+	require.NotEqual(t, traceIDs[0], traceIDs[1], "each request should get its own trace ID")
+
+	// the retries of a request keep its trace ID, which is the one shown in the error
+	traceIDs = nil
+	resp, err := client.R().Get(server.URL + "/fail")
+	require.NoError(t, err)
+	require.Len(t, traceIDs, 2)
+	require.Equal(t, traceIDs[0], traceIDs[1])
+	require.Equal(t, traceIDs[0], api.NewErrorFromHTTPResponse(resp).TraceID)
+}
+
+func TestNewHTTPDebugger(t *testing.T) {
+	var out bytes.Buffer
+	require.Nil(t, NewHTTPDebugger(&config.GlobalOptions{}, &out))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"region":"aws.euw1"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := GetHTTPClient("key", "my-api-secret", 0)
+	NewHTTPDebugger(&config.GlobalOptions{DebugHTTP: true}, &out, "my-api-secret").Attach(client)
+	gql := api.NewGraphQLClient(server.URL, client)
+	var resp struct{}
+	require.NoError(t, gql.Do(t.Context(), api.GQLRequest{Query: "query { Regions { name } }"}, &resp))
+
+	require.Contains(t, out.String(), "> POST "+server.URL)
+	require.Contains(t, out.String(), "> Authorization: [REDACTED]")
+	require.Contains(t, out.String(), "> "+api.TraceIDHeader+": ")
+	require.Contains(t, out.String(), `"query":"query { Regions { name } }"`)
+	require.Contains(t, out.String(), "< 200 OK (")
+	require.Contains(t, out.String(), `< {"data":{"region":"aws.euw1"}}`)
+	require.NotContains(t, out.String(), "my-api-secret")
+}
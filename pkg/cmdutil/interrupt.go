@@ -0,0 +1,46 @@
+package cmdutil
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// InterruptedExitCode is the exit code of the CLI when it is stopped with Ctrl+C or SIGTERM.
+const InterruptedExitCode = 130
+
+// ErrInterrupted is the cause of the cancellation of the root context when the user stops the CLI.
+var ErrInterrupted = errors.New("interrupted")
+
+// exit is os.Exit, overridden in tests.
+var exit = os.Exit
+
+// NotifyInterrupt returns a copy of ctx that is canceled with ErrInterrupted on the first SIGINT or
+// SIGTERM, so that commands stop what they are doing and report what they left behind. A second
+// signal exits right away. Call stop to release the signal handler.
+func NotifyInterrupt(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-signals:
+			cancel(ErrInterrupted)
+		case <-ctx.Done():
+			return
+		}
+		<-signals
+		exit(InterruptedExitCode)
+	}()
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel(nil)
+	}
+}
+
+// Interrupted reports whether ctx was canceled because the user stopped the CLI.
+func Interrupted(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrInterrupted)
+}
@@ -0,0 +1,49 @@
+package cmdutil
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyInterrupt(t *testing.T) {
+	exited := make(chan int, 1)
+	exit = func(code int) { exited <- code }
+	t.Cleanup(func() { exit = os.Exit })
+
+	ctx, stop := NotifyInterrupt(t.Context())
+	defer stop()
+	require.False(t, Interrupted(ctx))
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(os.Interrupt))
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the context should be canceled by the signal")
+	}
+	require.True(t, Interrupted(ctx))
+	derived, cancel := context.WithDeadline(ctx, time.Now().Add(time.Hour))
+	defer cancel()
+	require.True(t, Interrupted(derived), "the contexts of the commands derive from the root one")
+
+	require.NoError(t, process.Signal(os.Interrupt))
+	select {
+	case code := <-exited:
+		require.Equal(t, InterruptedExitCode, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("a second signal should exit")
+	}
+}
+
+func TestNotifyInterruptStopped(t *testing.T) {
+	ctx, stop := NotifyInterrupt(t.Context())
+	stop()
+	<-ctx.Done()
+	require.False(t, Interrupted(ctx))
+}
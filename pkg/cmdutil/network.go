@@ -0,0 +1,82 @@
+package cmdutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+// websocketHandshakeTimeout is how long the handshake of a websocket connection may take, as with the
+// default dialer.
+const websocketHandshakeTimeout = 45 * time.Second
+
+// NewTransport returns the transport of the clients talking to the platform. It trusts the
+// certificates of the --ca-file bundle on top of the system ones, and goes through the --proxy
+// server, or the one set with HTTPS_PROXY, HTTP_PROXY and NO_PROXY.
+func NewTransport(opts *config.GlobalOptions) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if opts == nil {
+		return transport, nil
+	}
+
+	if opts.Proxy != "" {
+		proxyURL, err := url.Parse(opts.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q, must be like http://host:port", opts.Proxy)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("invalid proxy URL %q, the scheme must be http, https or socks5", opts.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if opts.CAFile != "" {
+		pool, err := loadCAFile(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return transport, nil
+}
+
+// loadCAFile returns the system certificate pool with the PEM certificates of path added to it.
+func loadCAFile(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificate found in CA file %q", path)
+	}
+	return pool, nil
+}
+
+// NewWebsocketDialer returns the dialer of the websocket connections to the platform, which uses the
+// TLS and proxy settings of transport.
+func NewWebsocketDialer(transport *http.Transport, debugger *api.HTTPDebugger) *api.WebsocketDialer {
+	return &api.WebsocketDialer{
+		Dialer: &websocket.Dialer{
+			Proxy:            transport.Proxy,
+			TLSClientConfig:  transport.TLSClientConfig,
+			HandshakeTimeout: websocketHandshakeTimeout,
+		},
+		Debugger: debugger,
+	}
+}
@@ -0,0 +1,163 @@
+package cmdutil
+
+import (
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+// connectProxy is a proxy server that only tunnels CONNECT requests, counting them.
+func connectProxy(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var tunnels atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		tunnels.Add(1)
+		w.WriteHeader(http.StatusOK)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			upstream.Close()
+			return
+		}
+		go func() {
+			defer upstream.Close()
+			defer conn.Close()
+			go func() { _, _ = io.Copy(upstream, conn) }()
+			_, _ = io.Copy(conn, upstream)
+		}()
+	}))
+	t.Cleanup(proxy.Close)
+	return proxy, &tunnels
+}
+
+// tlsServer is a server with a self-signed certificate, which is written to the returned CA file. It
+// answers websocket upgrades with an echo and any other request with an empty GraphQL response.
+func tlsServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	var upgrader websocket.Upgrader
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !websocket.IsWebSocketUpgrade(r) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":{}}`))
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		msgType, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = conn.WriteMessage(msgType, msg)
+	}))
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, data, 0600))
+	return server, caFile
+}
+
+func TestNewTransport(t *testing.T) {
+	server, caFile := tlsServer(t)
+	proxy, tunnels := connectProxy(t)
+	wsURL := "wss" + strings.TrimPrefix(server.URL, "https")
+
+	// without the CA the certificate of the server is not trusted
+	transport, err := NewTransport(&config.GlobalOptions{Proxy: proxy.URL})
+	require.NoError(t, err)
+	_, err = GetHTTPClient("", "", 0).SetTransport(transport).R().Get(server.URL)
+	require.ErrorContains(t, err, "certificate")
+
+	transport, err = NewTransport(&config.GlobalOptions{Proxy: proxy.URL, CAFile: caFile})
+	require.NoError(t, err)
+
+	tunnels.Store(0)
+	resp, err := GetHTTPClient("", "", 0).SetTransport(transport).R().Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, `{"data":{}}`, resp.String())
+	require.Equal(t, int32(1), tunnels.Load(), "the request should go through the proxy")
+
+	transport.CloseIdleConnections()
+	tunnels.Store(0)
+	gql := api.NewGraphQLClient(server.URL, GetHTTPClient("", "", 0).SetTransport(transport))
+	var result struct{}
+	require.NoError(t, gql.Do(t.Context(), api.GQLRequest{Query: "query { Regions { name } }"}, &result))
+	require.Equal(t, int32(1), tunnels.Load(), "the GraphQL request should go through the proxy")
+
+	tunnels.Store(0)
+	conn, _, err := NewWebsocketDialer(transport, nil).Dial(wsURL, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("hello")))
+	_, msg, err := conn.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(msg))
+	require.Equal(t, int32(1), tunnels.Load(), "the websocket connection should go through the proxy")
+
+	client := api.NewWebsocketConnectionClient("key", "secret", NewWebsocketDialer(transport, nil))
+	tunnels.Store(0)
+	require.NoError(t, client.Connect(t.Context(), wsURL))
+	require.Equal(t, int32(1), tunnels.Load(), "the websocket client should go through the proxy")
+}
+
+func TestNewTransportErrors(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0600))
+
+	tests := []struct {
+		name   string
+		opts   config.GlobalOptions
+		errMsg string
+	}{
+		{
+			name:   "missing-ca-file",
+			opts:   config.GlobalOptions{CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+			errMsg: "failed to read CA file",
+		},
+		{
+			name:   "invalid-ca-file",
+			opts:   config.GlobalOptions{CAFile: notPEM},
+			errMsg: "no PEM certificate found in CA file",
+		},
+		{
+			name:   "proxy-without-host",
+			opts:   config.GlobalOptions{Proxy: "proxy.corp:3128"},
+			errMsg: `invalid proxy URL "proxy.corp:3128"`,
+		},
+		{
+			name:   "proxy-scheme",
+			opts:   config.GlobalOptions{Proxy: "ftp://proxy.corp:3128"},
+			errMsg: `invalid proxy URL "ftp://proxy.corp:3128", the scheme must be http, https or socks5`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTransport(&tt.opts)
+			require.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package cmdutil
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+)
+
+const (
+	// DefaultRetries is how many times a failed idempotent request is retried by default.
+	DefaultRetries = 3
+
+	// IdempotencyKeyHeader marks a request that is safe to retry even though its method is not idempotent.
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
+// retryWaitTime and retryMaxWaitTime bound the exponential backoff between two attempts, including
+// the wait asked for with Retry-After.
+var (
+	retryWaitTime    = 500 * time.Millisecond
+	retryMaxWaitTime = 20 * time.Second
+)
+
+// setRetryPolicy makes the client retry idempotent requests that failed with a connection error or a
+// transient status, waiting with exponential backoff and jitter, or as long as Retry-After asks.
+func setRetryPolicy(client *resty.Client, retries int) {
+	client.SetRetryCount(retries).
+		SetRetryWaitTime(retryWaitTime).
+		SetRetryMaxWaitTime(retryMaxWaitTime).
+		SetRetryAfter(retryAfter).
+		AddRetryCondition(shouldRetry)
+}
+
+// shouldRetry reports whether a request should be sent again. It replaces the default condition of
+// resty, which retries any request that failed with an error, whatever its method.
+func shouldRetry(resp *resty.Response, err error) bool {
+	if resp == nil || resp.Request == nil || !isIdempotent(resp.Request) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode() {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent reports whether sending a request twice has the same effect as sending it once:
+// its method is idempotent, it carries an idempotency key, or it is a GraphQL query.
+func isIdempotent(req *resty.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	if req.Header.Get(IdempotencyKeyHeader) != "" {
+		return true
+	}
+	if gql, ok := req.Body.(api.GQLRequest); ok {
+		query := strings.TrimSpace(gql.Query)
+		return strings.HasPrefix(query, "query") || strings.HasPrefix(query, "{")
+	}
+	return false
+}
+
+// retryAfter returns the wait asked for by the Retry-After header, in seconds or as an HTTP date, and
+// 0 to use the exponential backoff when there is none.
+func retryAfter(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	value := resp.Header().Get("Retry-After")
+	if value == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if date, err := http.ParseTime(value); err == nil && time.Until(date) > 0 {
+		return time.Until(date), nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,171 @@
+package cmdutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+)
+
+// failingServer fails the first failures requests with status, or by closing the connection when status is 0.
+func failingServer(t *testing.T, failures int, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if int(attempts.Add(1)) > failures {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":{}}`))
+			return
+		}
+		if status == 0 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &attempts
+}
+
+func TestRetryPolicy(t *testing.T) {
+	retryWaitTime, retryMaxWaitTime = time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() { retryWaitTime, retryMaxWaitTime = 500*time.Millisecond, 20*time.Second })
+
+	tests := []struct {
+		name         string
+		retries      int
+		failures     int
+		status       int
+		request      func(client *resty.Client, url string) (*resty.Response, error)
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{
+			name:         "get-recovers",
+			retries:      3,
+			failures:     2,
+			status:       http.StatusServiceUnavailable,
+			request:      func(c *resty.Client, url string) (*resty.Response, error) { return c.R().Get(url) },
+			wantAttempts: 3,
+		},
+		{
+			name:         "get-connection-reset",
+			retries:      3,
+			failures:     1,
+			request:      func(c *resty.Client, url string) (*resty.Response, error) { return c.R().Get(url) },
+			wantAttempts: 2,
+		},
+		{
+			name:         "delete-gives-up",
+			retries:      2,
+			failures:     5,
+			status:       http.StatusBadGateway,
+			request:      func(c *resty.Client, url string) (*resty.Response, error) { return c.R().Delete(url) },
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "retries-disabled",
+			retries:      0,
+			failures:     1,
+			status:       http.StatusServiceUnavailable,
+			request:      func(c *resty.Client, url string) (*resty.Response, error) { return c.R().Get(url) },
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "client-error-not-retried",
+			retries:      3,
+			failures:     1,
+			status:       http.StatusBadRequest,
+			request:      func(c *resty.Client, url string) (*resty.Response, error) { return c.R().Get(url) },
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "post-not-retried",
+			retries:      3,
+			failures:     1,
+			status:       http.StatusServiceUnavailable,
+			request:      func(c *resty.Client, url string) (*resty.Response, error) { return c.R().SetBody("{}").Post(url) },
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "post-connection-reset-not-retried",
+			retries:      3,
+			failures:     1,
+			request:      func(c *resty.Client, url string) (*resty.Response, error) { return c.R().SetBody("{}").Post(url) },
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:     "post-with-idempotency-key",
+			retries:  3,
+			failures: 2,
+			status:   http.StatusServiceUnavailable,
+			request: func(c *resty.Client, url string) (*resty.Response, error) {
+				return c.R().SetHeader(IdempotencyKeyHeader, "key").SetBody("{}").Post(url)
+			},
+			wantAttempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, attempts := failingServer(t, tt.failures, tt.status, nil)
+			client := GetHTTPClient("key", "secret", tt.retries)
+
+			resp, err := tt.request(client, server.URL)
+			require.Equal(t, tt.wantAttempts, attempts.Load())
+			if tt.wantErr {
+				require.True(t, err != nil || resp.IsError(), "should fail")
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, resp.IsError())
+		})
+	}
+}
+
+func TestRetryPolicyGraphQL(t *testing.T) {
+	retryWaitTime, retryMaxWaitTime = time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() { retryWaitTime, retryMaxWaitTime = 500*time.Millisecond, 20*time.Second })
+
+	server, attempts := failingServer(t, 2, http.StatusServiceUnavailable, nil)
+	gql := api.NewGraphQLClient(server.URL, GetHTTPClient("key", "secret", 3))
+	var resp struct{}
+	require.NoError(t, gql.Do(t.Context(), api.GQLRequest{Query: "query MyQuery { Regions { name } }"}, &resp))
+	require.Equal(t, int32(3), attempts.Load())
+
+	server, attempts = failingServer(t, 2, http.StatusServiceUnavailable, nil)
+	gql = api.NewGraphQLClient(server.URL, GetHTTPClient("key", "secret", 3))
+	require.Error(t, gql.Do(t.Context(), api.GQLRequest{Query: "mutation { deleteRegion }"}, &resp))
+	require.Equal(t, int32(1), attempts.Load())
+}
+
+func TestRetryPolicyRetryAfter(t *testing.T) {
+	retryWaitTime, retryMaxWaitTime = time.Millisecond, 5*time.Second
+	t.Cleanup(func() { retryWaitTime, retryMaxWaitTime = 500*time.Millisecond, 20*time.Second })
+
+	server, attempts := failingServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"1"}})
+	client := GetHTTPClient("key", "secret", 3)
+
+	start := time.Now()
+	resp, err := client.R().Get(server.URL)
+	require.NoError(t, err)
+	require.False(t, resp.IsError())
+	require.Equal(t, int32(2), attempts.Load())
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+}
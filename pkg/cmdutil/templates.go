@@ -0,0 +1,123 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+const (
+	TemplateSourceMarketplace = "marketplace"
+	TemplateSourceAsset       = "asset"
+
+	// AssetTemplatePrefix is where templates are stored in the asset API, as <prefix><language>/<name>.tar.gz.
+	AssetTemplatePrefix = "templates/"
+)
+
+// TemplateInfo describes a template of the marketplace or of the asset API.
+type TemplateInfo struct {
+	ID       string
+	Name     string
+	Source   string
+	Language string
+	// Version is the latest product version for marketplace templates, and the last modification
+	// date for asset templates.
+	Version string
+	// Size is the size of the archive in bytes, or 0 if it is not known before downloading it.
+	Size int64
+}
+
+// ListTemplates returns the marketplace product templates followed by the templates of the asset API.
+func ListTemplates(ctx context.Context, f Factory) ([]TemplateInfo, error) {
+	products, err := f.Datastore().ListProductTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product templates: %w", err)
+	}
+	templates := make([]TemplateInfo, 0, len(products))
+	for _, product := range products {
+		templates = append(templates, TemplateInfo{
+			ID:       product.ID,
+			Name:     product.Name,
+			Source:   TemplateSourceMarketplace,
+			Language: strings.ToLower(product.ProgrammingLanguage),
+			Version:  product.LatestVersion(),
+		})
+	}
+
+	assets, err := f.AssetClient().GetTemplateNameList(ctx, AssetTemplatePrefix, true, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list asset templates: %w", err)
+	}
+	for _, asset := range assets {
+		templates = append(templates, assetTemplateInfo(asset))
+	}
+	return templates, nil
+}
+
+func assetTemplateInfo(asset api.Metadata) TemplateInfo {
+	rel := strings.TrimPrefix(asset.Name, AssetTemplatePrefix)
+	name := strings.TrimSuffix(strings.TrimSuffix(path.Base(rel), ".tgz"), ".tar.gz")
+	var language string
+	if dir := path.Dir(rel); dir != "." {
+		language = strings.ToLower(strings.Split(dir, "/")[0])
+	}
+	var version string
+	if !asset.LastModified.IsZero() {
+		version = asset.LastModified.UTC().Format("2006-01-02")
+	}
+	return TemplateInfo{
+		ID:       asset.Name,
+		Name:     name,
+		Source:   TemplateSourceAsset,
+		Language: language,
+		Version:  version,
+		Size:     asset.Size,
+	}
+}
+
+// FindTemplateInfo returns the template with the given ID or, ignoring case, name.
+func FindTemplateInfo(templates []TemplateInfo, idOrName string) (TemplateInfo, error) {
+	for _, t := range templates {
+		if t.ID == idOrName {
+			return t, nil
+		}
+	}
+	for _, t := range templates {
+		if strings.EqualFold(t.Name, idOrName) {
+			return t, nil
+		}
+	}
+	return TemplateInfo{}, fmt.Errorf("template %q not found, run 'vcr template list' to see the available templates", idOrName)
+}
+
+// FetchTemplate returns the archive of a template. The same version is taken from the cache if it was
+// downloaded before, and a downloaded template is added to the cache. Failing to write the cache is
+// not an error, it only means that the template is downloaded again next time.
+func FetchTemplate(ctx context.Context, f Factory, cache config.TemplateCache, t TemplateInfo) ([]byte, error) {
+	if cached, archive, err := cache.Get(t.ID); err == nil && cached.Version == t.Version && t.Version != "" {
+		return archive, nil
+	}
+
+	var archive []byte
+	switch t.Source {
+	case TemplateSourceAsset:
+		asset, err := f.AssetClient().GetTemplate(ctx, t.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download template files: %w", err)
+		}
+		archive = asset.Content
+	default:
+		var err error
+		archive, err = f.MarketplaceClient().GetTemplate(ctx, t.ID, t.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download template files: %w", err)
+		}
+	}
+
+	_ = cache.Put(config.CachedTemplate{ID: t.ID, Name: t.Name, Source: t.Source, Language: t.Language, Version: t.Version}, archive)
+	return archive, nil
+}
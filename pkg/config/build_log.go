@@ -0,0 +1,30 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// DefaultBuildLogDir is where the build logs of the deployed packages are saved.
+var DefaultBuildLogDir = DefaultCLIDataDir + "/builds"
+
+// CreateBuildLog creates the file the build log of a package is saved to in dir, and returns it with
+// its path.
+func CreateBuildLog(dir, packageID string) (*os.File, string, error) {
+	dir, err := homedir.Expand(dir)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to expand path %q: %w", dir, err)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, "", err
+	}
+	path := filepath.Join(dir, filepath.Base(packageID)+".log")
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, "", err
+	}
+	return file, path, nil
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBuildLog(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "builds")
+
+	file, path, err := CreateBuildLog(dir, "package-id")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "package-id.log"), path)
+	_, err = file.WriteString("first build\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	// a new build of the same package replaces its log
+	file, _, err = CreateBuildLog(dir, "package-id")
+	require.NoError(t, err)
+	_, err = file.WriteString("second build\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "second build\n", string(data))
+}
@@ -1,19 +1,31 @@
 package config
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/mitchellh/go-homedir"
 	"gopkg.in/ini.v1"
 )
 
 var ErrNoConfig = errors.New("config file not found, please use 'vcr configure' to create one")
+var ErrProfileNotFound = errors.New("profile not found, please use 'vcr configure --profile <name>' to create it")
 
 const (
 	DefaultGraphqlURL = "https://graphql.euw1.runtime.vonage.cloud/v1/graphql"
 	DefaultRegion     = "aws.euw1"
+	// DefaultProfile names the top-level settings of the config file.
+	DefaultProfile = "default"
+	// ProfileEnv selects a named profile when the --profile flag is not set.
+	ProfileEnv = "VCR_PROFILE"
+
+	profileSectionPrefix = "profile "
 )
 
 var (
@@ -21,18 +33,87 @@ var (
 		"~/.vcr-cli",
 		"~/.neru-cli",
 	}
+	// DefaultCLIDataDir holds local CLI state that does not belong in the config file.
+	DefaultCLIDataDir = "~/.vcr-cli.d"
 )
 
 type Credentials struct {
 	APIKey    string `ini:"api_key"`
 	APISecret string `ini:"api_secret"`
+	// CredentialStore selects where the API secret is kept, see CredentialStoreEncrypted.
+	CredentialStore string `ini:"credential_store,omitempty"`
+	// CredentialProcess is a command that prints the API key and secret as JSON.
+	CredentialProcess string `ini:"credential_process,omitempty"`
 }
 
 type CLIConfig struct {
 	GraphqlEndpoint string `ini:"graphql_endpoint"`
 	DefaultRegion   string `ini:"default_region"`
+	// UpdateCheck is "false" when the update check is disabled, see SettingUpdateCheck.
+	UpdateCheck string `ini:"update_check,omitempty"`
+	// OutputFormat is the format of the commands with a --json flag when it is not set, see SettingOutputFormat.
+	OutputFormat string `ini:"output_format,omitempty"`
 
 	Credentials `ini:"credentials"`
+
+	// Profiles holds the named "[profile <name>]" sections of the config file.
+	Profiles map[string]Profile `ini:"-"`
+}
+
+// Profile is a named set of credentials and region settings, stored as a "[profile <name>]" section.
+type Profile struct {
+	GraphqlEndpoint   string `ini:"graphql_endpoint"`
+	DefaultRegion     string `ini:"default_region"`
+	APIKey            string `ini:"api_key"`
+	APISecret         string `ini:"api_secret"`
+	CredentialStore   string `ini:"credential_store,omitempty"`
+	CredentialProcess string `ini:"credential_process,omitempty"`
+}
+
+// Profile returns the named profile, or the top-level settings for an empty name or DefaultProfile.
+func (c CLIConfig) Profile(name string) (Profile, error) {
+	if name == "" || name == DefaultProfile {
+		return Profile{
+			GraphqlEndpoint:   c.GraphqlEndpoint,
+			DefaultRegion:     c.DefaultRegion,
+			APIKey:            c.APIKey,
+			APISecret:         c.APISecret,
+			CredentialStore:   c.CredentialStore,
+			CredentialProcess: c.CredentialProcess,
+		}, nil
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%q: %w", name, ErrProfileNotFound)
+	}
+	return p, nil
+}
+
+// SetProfile stores the profile under the given name, or as the top-level settings for an empty name or DefaultProfile.
+func (c *CLIConfig) SetProfile(name string, p Profile) {
+	if name == "" || name == DefaultProfile {
+		c.GraphqlEndpoint = p.GraphqlEndpoint
+		c.DefaultRegion = p.DefaultRegion
+		c.APIKey = p.APIKey
+		c.APISecret = p.APISecret
+		c.CredentialStore = p.CredentialStore
+		c.CredentialProcess = p.CredentialProcess
+		return
+	}
+	if c.Profiles == nil {
+		c.Profiles = map[string]Profile{}
+	}
+	c.Profiles[name] = p
+}
+
+// ProfileNames returns DefaultProfile followed by the sorted names of all named profiles.
+func (c CLIConfig) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...)
 }
 
 func ReadDefaultCLIConfig() (CLIConfig, string, error) {
@@ -66,10 +147,21 @@ func ReadCLIConfig(path string) (CLIConfig, error) {
 	if err := f.MapTo(&c); err != nil {
 		return CLIConfig{}, err
 	}
+	for _, section := range f.Sections() {
+		name, ok := strings.CutPrefix(section.Name(), profileSectionPrefix)
+		if !ok {
+			continue
+		}
+		var p Profile
+		if err := section.MapTo(&p); err != nil {
+			return CLIConfig{}, fmt.Errorf("failed to read profile %q: %w", name, err)
+		}
+		c.SetProfile(strings.TrimSpace(name), p)
+	}
 	return c, nil
 }
 
-// WriteCLIConfig writes the CLIConfig to the given path.
+// WriteCLIConfig writes the CLIConfig to the given path, readable by the current user only.
 func WriteCLIConfig(c CLIConfig, path string) error {
 
 	path, err := homedir.Expand(path)
@@ -80,8 +172,65 @@ func WriteCLIConfig(c CLIConfig, path string) error {
 	if err := f.ReflectFrom(&c); err != nil {
 		return err
 	}
-	if err := f.SaveTo(path); err != nil {
+	for _, name := range c.ProfileNames()[1:] {
+		p := c.Profiles[name]
+		section, err := f.NewSection(profileSectionPrefix + name)
+		if err != nil {
+			return err
+		}
+		if err := section.ReflectFrom(&p); err != nil {
+			return err
+		}
+	}
+	var buf bytes.Buffer
+	if _, err := f.WriteTo(&buf); err != nil {
+		return err
+	}
+	return writePrivateFile(path, buf.Bytes())
+}
+
+// writePrivateFile replaces the file at path with data, readable by the current user only. The data is
+// written to a temporary file of the same directory first, so that a failed write leaves the previous
+// file intact. A symbolic link at path is followed, so that its target is replaced instead of the link.
+func writePrivateFile(path string, data []byte) (err error) {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}()
+	if err := file.Chmod(privateFilePermission); err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
 		return err
 	}
-	return nil
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// IsWorldReadable reports whether the file at path can be read by users other than its owner.
+// It always returns false on Windows, where file modes do not reflect access control.
+func IsWorldReadable(path string) (bool, error) {
+	if runtime.GOOS == "windows" {
+		return false, nil
+	}
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return false, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	return info.Mode().Perm()&0004 != 0, nil
 }
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -26,6 +28,26 @@ func TestReadAndWriteCLIConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedConfig, actualConfig)
 
+	info, err := os.Stat(configFile)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(privateFilePermission), info.Mode().Perm())
+	worldReadable, err := IsWorldReadable(configFile)
+	require.NoError(t, err)
+	require.False(t, worldReadable)
+
+	require.NoError(t, os.Chmod(configFile, 0644))
+	worldReadable, err = IsWorldReadable(configFile)
+	require.NoError(t, err)
+	require.Equal(t, runtime.GOOS != "windows", worldReadable)
+	err = WriteCLIConfig(expectedConfig, configFile)
+	require.NoError(t, err)
+	info, err = os.Stat(configFile)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(privateFilePermission), info.Mode().Perm())
+	entries, err := os.ReadDir(tempDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "the temporary file should be renamed over the config file")
+
 	nonExistentFile := "non-existent-file.yaml"
 
 	_, err = ReadCLIConfig(nonExistentFile)
@@ -36,3 +58,67 @@ func TestReadAndWriteCLIConfig(t *testing.T) {
 	err = WriteCLIConfig(expectedConfig, readOnlyDir)
 	require.Error(t, err)
 }
+
+func TestReadAndWriteCLIConfigProfiles(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config")
+
+	expectedConfig := CLIConfig{
+		GraphqlEndpoint: DefaultGraphqlURL,
+		DefaultRegion:   DefaultRegion,
+		Credentials: Credentials{
+			APIKey:    "sandbox-key",
+			APISecret: "sandbox-secret",
+		},
+	}
+	expectedConfig.SetProfile("prod", Profile{
+		DefaultRegion: "aws.use1",
+		APIKey:        "prod-key",
+		APISecret:     "prod-secret",
+	})
+
+	err := WriteCLIConfig(expectedConfig, configFile)
+	require.NoError(t, err)
+
+	fileData, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+	require.Contains(t, string(fileData), "[profile prod]")
+
+	actualConfig, err := ReadCLIConfig(configFile)
+	require.NoError(t, err)
+	require.Equal(t, expectedConfig, actualConfig)
+	require.Equal(t, []string{DefaultProfile, "prod"}, actualConfig.ProfileNames())
+
+	p, err := actualConfig.Profile("prod")
+	require.NoError(t, err)
+	require.Equal(t, "prod-key", p.APIKey)
+
+	p, err = actualConfig.Profile(DefaultProfile)
+	require.NoError(t, err)
+	require.Equal(t, "sandbox-key", p.APIKey)
+
+	_, err = actualConfig.Profile("staging")
+	require.ErrorIs(t, err, ErrProfileNotFound)
+}
+
+func TestWriteCLIConfigThroughSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symbolic links needs privileges on Windows")
+	}
+	tempDir := t.TempDir()
+	target := filepath.Join(tempDir, "dotfiles", "vcr-cli")
+	require.NoError(t, os.MkdirAll(filepath.Dir(target), 0700))
+	require.NoError(t, os.WriteFile(target, nil, 0600))
+	link := filepath.Join(tempDir, ".vcr-cli")
+	require.NoError(t, os.Symlink(target, link))
+
+	expectedConfig := CLIConfig{Credentials: Credentials{APIKey: "my-api-key"}}
+	require.NoError(t, WriteCLIConfig(expectedConfig, link))
+
+	info, err := os.Lstat(link)
+	require.NoError(t, err)
+	require.NotZero(t, info.Mode()&os.ModeSymlink, "the link should be kept")
+	actualConfig, err := ReadCLIConfig(target)
+	require.NoError(t, err)
+	require.Equal(t, "my-api-key", actualConfig.Credentials.APIKey)
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/google/shlex"
+)
+
+// Credential stores supported by the credential_store setting.
+const (
+	// CredentialStorePlaintext keeps the API secret in the config file. It is the default.
+	CredentialStorePlaintext = "plaintext"
+	// CredentialStoreEncrypted keeps the API secret in an AES-GCM encrypted file, keyed like the local secret store.
+	CredentialStoreEncrypted = "encrypted"
+)
+
+var DefaultCredentialStorePath = DefaultCLIDataDir + "/credentials.enc"
+
+var ErrNoStoredCredentials = errors.New("no API secret found in the encrypted credential store, please run 'vcr configure'")
+
+// NewDefaultCredentialStore returns the encrypted credential store. It shares the key file and
+// passphrase of the local secret store.
+func NewDefaultCredentialStore() *LocalSecretStore {
+	return NewLocalSecretStore(DefaultCredentialStorePath, DefaultLocalSecretKeyPath, os.Getenv(LocalSecretPassphraseEnv))
+}
+
+// ValidateCredentialStore checks that name is a supported credential store.
+func ValidateCredentialStore(name string) error {
+	switch name {
+	case "", CredentialStorePlaintext, CredentialStoreEncrypted:
+		return nil
+	default:
+		return fmt.Errorf("unsupported credential store %q, must be one of %q or %q", name, CredentialStorePlaintext, CredentialStoreEncrypted)
+	}
+}
+
+// ResolveCredentials returns the profile with the API key and secret filled in from its credential
+// process or credential store. Profiles that keep the secret in the config file are returned as is.
+func (p Profile) ResolveCredentials(ctx context.Context, name string, store *LocalSecretStore) (Profile, error) {
+	if p.CredentialProcess != "" {
+		creds, err := runCredentialProcess(ctx, p.CredentialProcess)
+		if err != nil {
+			return Profile{}, err
+		}
+		if creds.APIKey != "" {
+			p.APIKey = creds.APIKey
+		}
+		p.APISecret = creds.APISecret
+		return p, nil
+	}
+
+	if err := ValidateCredentialStore(p.CredentialStore); err != nil {
+		return Profile{}, err
+	}
+	if p.CredentialStore != CredentialStoreEncrypted {
+		return p, nil
+	}
+	secrets, err := store.Load()
+	if err != nil {
+		return Profile{}, fmt.Errorf("failed to read credential store: %w", err)
+	}
+	secret, ok := secrets[credentialStoreKey(name)]
+	if !ok {
+		return Profile{}, fmt.Errorf("profile %q: %w", credentialStoreKey(name), ErrNoStoredCredentials)
+	}
+	p.APISecret = secret
+	return p, nil
+}
+
+// SaveCredentials stores the API secret of the named profile in the encrypted credential store.
+func SaveCredentials(store *LocalSecretStore, name, apiSecret string) error {
+	return store.Set(credentialStoreKey(name), apiSecret)
+}
+
+// RemoveCredentials deletes the API secret of the named profile from the encrypted credential store.
+func RemoveCredentials(store *LocalSecretStore, name string) error {
+	return store.Remove(credentialStoreKey(name))
+}
+
+func credentialStoreKey(profileName string) string {
+	if profileName == "" {
+		return DefaultProfile
+	}
+	return profileName
+}
+
+type credentialProcessOutput struct {
+	APIKey    string `json:"api_key"`
+	APISecret string `json:"api_secret"`
+}
+
+// runCredentialProcess executes the command and parses the credentials it prints on stdout.
+func runCredentialProcess(ctx context.Context, command string) (credentialProcessOutput, error) {
+	args, err := shlex.Split(command)
+	if err != nil {
+		return credentialProcessOutput{}, fmt.Errorf("invalid credential_process %q: %w", command, err)
+	}
+	if len(args) == 0 {
+		return credentialProcessOutput{}, fmt.Errorf("credential_process is empty")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return credentialProcessOutput{}, fmt.Errorf("credential_process failed: %w: %s", err, msg)
+		}
+		return credentialProcessOutput{}, fmt.Errorf("credential_process failed: %w", err)
+	}
+
+	var output credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return credentialProcessOutput{}, fmt.Errorf("failed to parse credential_process output: %w", err)
+	}
+	if output.APISecret == "" {
+		return credentialProcessOutput{}, fmt.Errorf("credential_process output is missing \"api_secret\"")
+	}
+	return output, nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveCredentials(t *testing.T) {
+	tempDir := t.TempDir()
+	store := NewLocalSecretStore(filepath.Join(tempDir, "credentials.enc"), filepath.Join(tempDir, "secrets.key"), "")
+
+	plaintext := Profile{APIKey: "key", APISecret: "secret"}
+	resolved, err := plaintext.ResolveCredentials(t.Context(), "", store)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, resolved)
+
+	encrypted := Profile{APIKey: "key", CredentialStore: CredentialStoreEncrypted}
+	_, err = encrypted.ResolveCredentials(t.Context(), "prod", store)
+	require.ErrorIs(t, err, ErrNoStoredCredentials)
+
+	require.NoError(t, SaveCredentials(store, "prod", "stored-secret"))
+	resolved, err = encrypted.ResolveCredentials(t.Context(), "prod", store)
+	require.NoError(t, err)
+	require.Equal(t, "key", resolved.APIKey)
+	require.Equal(t, "stored-secret", resolved.APISecret)
+
+	_, err = Profile{CredentialStore: "keychain"}.ResolveCredentials(t.Context(), "", store)
+	require.EqualError(t, err, `unsupported credential store "keychain", must be one of "plaintext" or "encrypted"`)
+}
+
+func TestResolveCredentialsWithProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential process tests use sh")
+	}
+
+	p := Profile{
+		APIKey:            "config-key",
+		CredentialProcess: `sh -c 'echo "{\"api_key\": \"process-key\", \"api_secret\": \"process-secret\"}"'`,
+	}
+	resolved, err := p.ResolveCredentials(t.Context(), "", nil)
+	require.NoError(t, err)
+	require.Equal(t, "process-key", resolved.APIKey)
+	require.Equal(t, "process-secret", resolved.APISecret)
+
+	p.CredentialProcess = `sh -c 'echo "{\"api_secret\": \"process-secret\"}"'`
+	resolved, err = p.ResolveCredentials(t.Context(), "", nil)
+	require.NoError(t, err)
+	require.Equal(t, "config-key", resolved.APIKey)
+
+	p.CredentialProcess = `sh -c 'echo "vault is sealed" >&2; exit 3'`
+	_, err = p.ResolveCredentials(t.Context(), "", nil)
+	require.EqualError(t, err, "credential_process failed: exit status 3: vault is sealed")
+
+	p.CredentialProcess = `sh -c 'echo not-json'`
+	_, err = p.ResolveCredentials(t.Context(), "", nil)
+	require.ErrorContains(t, err, "failed to parse credential_process output")
+}
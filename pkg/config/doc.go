@@ -7,11 +7,12 @@ package config
 //   * app deployment manifest: vcr.yaml
 //
 // global options should contain all the values found in the home config file so that
-// the values can be overridden by flags.
+// the values can be overridden by flags or VCR_* environment variables.
 // deployment manifest should only be read on `deploy` and `debug` commands.
 //
 // order of precedence if the same configuration value is found in multiple places:
 //
 //   1. flags
-//   2. deployment manifest
-//   3. home config file
+//   2. environment variables (VCR_API_KEY, VCR_REGION, ...)
+//   3. deployment manifest
+//   4. home config file
@@ -1,15 +1,106 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables that provide global options when the matching flag is not set.
+const (
+	APIKeyEnv          = "VCR_API_KEY"
+	APISecretEnv       = "VCR_API_SECRET"
+	RegionEnv          = "VCR_REGION"
+	GraphqlEndpointEnv = "VCR_GRAPHQL_ENDPOINT"
+	TimeoutEnv         = "VCR_TIMEOUT"
+	RetriesEnv         = "VCR_RETRIES"
+	CABundleEnv        = "VCR_CA_BUNDLE"
+	ProxyEnv           = "VCR_PROXY"
+	// DebugEnv is a comma separated list of the debug outputs to enable, e.g. "api" for --debug-http.
+	DebugEnv = "VCR_DEBUG"
+)
 
 // GlobalOptions is a struct that holds the global options for the CLI.
 // Should be accessible by all subcommands.
 type GlobalOptions struct {
 	ConfigFilePath  string
+	Profile         string
 	GraphqlEndpoint string
 	Region          string
 	APIKey          string
 	APISecret       string
 	Timeout         time.Duration
 	Deadline        time.Time
+	// Retries is how many times a failed idempotent API request is retried.
+	Retries int
+	// DebugHTTP logs the API requests and responses to stderr.
+	DebugHTTP bool
+	// CAFile is a PEM bundle of certificates trusted on top of the system ones, e.g. the CA of a
+	// TLS-intercepting proxy.
+	CAFile string
+	// Proxy is the URL of the proxy server used instead of the one set with HTTPS_PROXY.
+	Proxy string
+	// Refresh fetches the region and runtime metadata again instead of using the cached one.
+	Refresh bool
+}
+
+// ApplyEnv fills the options whose flag was not changed from their VCR_* environment variable,
+// so that flags take precedence over the environment, which takes precedence over the config file.
+func (o *GlobalOptions) ApplyEnv(flagChanged func(name string) bool) error {
+	stringOpts := []struct {
+		flag  string
+		env   string
+		value *string
+	}{
+		{"profile", ProfileEnv, &o.Profile},
+		{"api-key", APIKeyEnv, &o.APIKey},
+		{"api-secret", APISecretEnv, &o.APISecret},
+		{"region", RegionEnv, &o.Region},
+		{"graphql-endpoint", GraphqlEndpointEnv, &o.GraphqlEndpoint},
+		{"ca-file", CABundleEnv, &o.CAFile},
+		{"proxy", ProxyEnv, &o.Proxy},
+	}
+	for _, opt := range stringOpts {
+		if flagChanged(opt.flag) {
+			continue
+		}
+		if v := os.Getenv(opt.env); v != "" {
+			*opt.value = v
+		}
+	}
+
+	if v := os.Getenv(TimeoutEnv); v != "" && !flagChanged("timeout") {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s value %q: %w", TimeoutEnv, v, err)
+		}
+		o.Timeout = timeout
+	}
+
+	if v := os.Getenv(RetriesEnv); v != "" && !flagChanged("retries") {
+		retries, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s value %q: %w", RetriesEnv, v, err)
+		}
+		o.Retries = retries
+	}
+	if !flagChanged("debug-http") {
+		for _, d := range strings.Split(os.Getenv(DebugEnv), ",") {
+			if strings.TrimSpace(d) == "api" {
+				o.DebugHTTP = true
+			}
+		}
+	}
+
+	if o.Retries < 0 {
+		return fmt.Errorf("invalid number of retries %d, must be 0 or more", o.Retries)
+	}
+	return nil
+}
+
+// HasCredentials reports whether both the API key and secret are set without the config file.
+func (o *GlobalOptions) HasCredentials() bool {
+	return o.APIKey != "" && o.APISecret != ""
 }
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalOptionsApplyEnv(t *testing.T) {
+	t.Setenv(APIKeyEnv, "env-key")
+	t.Setenv(APISecretEnv, "env-secret")
+	t.Setenv(RegionEnv, "aws.use1")
+	t.Setenv(GraphqlEndpointEnv, "https://graphql.example.com")
+	t.Setenv(TimeoutEnv, "15m")
+	t.Setenv(RetriesEnv, "5")
+	t.Setenv(DebugEnv, "deploy, api")
+	t.Setenv(CABundleEnv, "/etc/ssl/corp.pem")
+	t.Setenv(ProxyEnv, "http://proxy.corp:3128")
+	t.Setenv(ProfileEnv, "")
+
+	opts := GlobalOptions{
+		APIKey:  "flag-key",
+		Timeout: 10 * time.Minute,
+	}
+	changed := map[string]bool{"api-key": true}
+
+	err := opts.ApplyEnv(func(name string) bool { return changed[name] })
+	require.NoError(t, err)
+	require.Equal(t, GlobalOptions{
+		APIKey:          "flag-key",
+		APISecret:       "env-secret",
+		Region:          "aws.use1",
+		GraphqlEndpoint: "https://graphql.example.com",
+		Timeout:         15 * time.Minute,
+		Retries:         5,
+		DebugHTTP:       true,
+		CAFile:          "/etc/ssl/corp.pem",
+		Proxy:           "http://proxy.corp:3128",
+	}, opts)
+	require.True(t, opts.HasCredentials())
+
+	t.Setenv(RetriesEnv, "many")
+	err = opts.ApplyEnv(func(string) bool { return false })
+	require.EqualError(t, err, `invalid VCR_RETRIES value "many": strconv.Atoi: parsing "many": invalid syntax`)
+
+	t.Setenv(RetriesEnv, "-1")
+	err = opts.ApplyEnv(func(string) bool { return false })
+	require.EqualError(t, err, "invalid number of retries -1, must be 0 or more")
+	t.Setenv(RetriesEnv, "")
+
+	t.Setenv(TimeoutEnv, "soon")
+	err = opts.ApplyEnv(func(string) bool { return false })
+	require.EqualError(t, err, `invalid VCR_TIMEOUT value "soon": time: invalid duration "soon"`)
+
+	t.Setenv(TimeoutEnv, "")
+	t.Setenv(DebugEnv, "deploy")
+	opts = GlobalOptions{}
+	err = opts.ApplyEnv(func(string) bool { return false })
+	require.NoError(t, err)
+	require.False(t, opts.DebugHTTP)
+
+	opts = GlobalOptions{}
+	err = opts.ApplyEnv(func(string) bool { return true })
+	require.NoError(t, err)
+	require.False(t, opts.HasCredentials())
+}
@@ -0,0 +1,261 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+var ErrLocalSecretNotFound = errors.New("local secret not found")
+var ErrPassphraseRequired = fmt.Errorf("local secret store is passphrase protected, please set %s", LocalSecretPassphraseEnv)
+var ErrInvalidSecretStore = errors.New("local secret store could not be decrypted, check the key file or passphrase")
+
+// LocalSecretPassphraseEnv is the environment variable holding the passphrase for the local secret store.
+// When it is not set, a random key file is used instead.
+const LocalSecretPassphraseEnv = "VCR_SECRETS_PASSPHRASE"
+
+const (
+	localSecretFileVersion   = 1
+	localSecretKeySize       = 32
+	localSecretSaltSize      = 16
+	localSecretKDFIterations = 600000
+
+	kdfKeyFile = "keyfile"
+	kdfPBKDF2  = "pbkdf2-sha256"
+
+	// privateFilePermission is used for files that must only be readable by the current user.
+	privateFilePermission = 0600
+	privateDirPermission  = 0700
+)
+
+var (
+	DefaultLocalSecretStorePath = DefaultCLIDataDir + "/secrets.enc"
+	DefaultLocalSecretKeyPath   = DefaultCLIDataDir + "/secrets.key"
+)
+
+// LocalSecretStore keeps secret values for local development in an AES-GCM encrypted file.
+// The encryption key is either derived from a passphrase or read from a key file.
+type LocalSecretStore struct {
+	path       string
+	keyPath    string
+	passphrase string
+}
+
+type localSecretFile struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt,omitempty"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+func NewLocalSecretStore(path, keyPath, passphrase string) *LocalSecretStore {
+	return &LocalSecretStore{
+		path:       path,
+		keyPath:    keyPath,
+		passphrase: passphrase,
+	}
+}
+
+// NewDefaultLocalSecretStore returns the store under DefaultCLIDataDir, using the passphrase from
+// LocalSecretPassphraseEnv if it is set.
+func NewDefaultLocalSecretStore() *LocalSecretStore {
+	return NewLocalSecretStore(DefaultLocalSecretStorePath, DefaultLocalSecretKeyPath, os.Getenv(LocalSecretPassphraseEnv))
+}
+
+// Load decrypts and returns all secrets in the store. A missing store is treated as empty.
+func (s *LocalSecretStore) Load() (map[string]string, error) {
+	path, err := homedir.Expand(s.path)
+	if err != nil {
+		return nil, err
+	}
+	fileData, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	var file localSecretFile
+	if err := json.Unmarshal(fileData, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse local secret store %q: %w", s.path, err)
+	}
+	if file.Version != localSecretFileVersion {
+		return nil, fmt.Errorf("unsupported local secret store version %d", file.Version)
+	}
+
+	var key []byte
+	switch file.KDF {
+	case kdfPBKDF2:
+		if s.passphrase == "" {
+			return nil, ErrPassphraseRequired
+		}
+		key, err = derivePassphraseKey(s.passphrase, file.Salt)
+	case kdfKeyFile:
+		key, err = s.readKeyFile()
+	default:
+		return nil, fmt.Errorf("unsupported local secret store kdf %q", file.KDF)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, ErrInvalidSecretStore
+	}
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse local secrets: %w", err)
+	}
+	return secrets, nil
+}
+
+// Save encrypts and writes all secrets to the store, replacing its previous content.
+func (s *LocalSecretStore) Save(secrets map[string]string) error {
+	path, err := homedir.Expand(s.path)
+	if err != nil {
+		return err
+	}
+
+	file := localSecretFile{Version: localSecretFileVersion}
+	var key []byte
+	if s.passphrase != "" {
+		file.KDF = kdfPBKDF2
+		file.Salt = make([]byte, localSecretSaltSize)
+		if _, err := rand.Read(file.Salt); err != nil {
+			return err
+		}
+		key, err = derivePassphraseKey(s.passphrase, file.Salt)
+	} else {
+		file.KDF = kdfKeyFile
+		key, err = s.readOrCreateKeyFile()
+	}
+	if err != nil {
+		return err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return err
+	}
+	file.Data = gcm.Seal(nil, file.Nonce, plaintext, nil)
+
+	fileData, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), privateDirPermission); err != nil {
+		return err
+	}
+	return writePrivateFile(path, fileData)
+}
+
+// Set adds or replaces a secret in the store.
+func (s *LocalSecretStore) Set(name, value string) error {
+	secrets, err := s.Load()
+	if err != nil {
+		return err
+	}
+	secrets[name] = value
+	return s.Save(secrets)
+}
+
+// Remove deletes a secret from the store, returning ErrLocalSecretNotFound if it does not exist.
+func (s *LocalSecretStore) Remove(name string) error {
+	secrets, err := s.Load()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[name]; !ok {
+		return ErrLocalSecretNotFound
+	}
+	delete(secrets, name)
+	return s.Save(secrets)
+}
+
+// Names returns the sorted names of all secrets in the store.
+func (s *LocalSecretStore) Names() ([]string, error) {
+	secrets, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *LocalSecretStore) readKeyFile() ([]byte, error) {
+	keyPath, err := homedir.Expand(s.keyPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local secret key file: %w", err)
+	}
+	if len(key) != localSecretKeySize {
+		return nil, fmt.Errorf("local secret key file %q is corrupted", s.keyPath)
+	}
+	return key, nil
+}
+
+func (s *LocalSecretStore) readOrCreateKeyFile() ([]byte, error) {
+	keyPath, err := homedir.Expand(s.keyPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(keyPath); err == nil {
+		return s.readKeyFile()
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	key := make([]byte, localSecretKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(keyPath), privateDirPermission); err != nil {
+		return nil, err
+	}
+	if err := writePrivateFile(keyPath, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func derivePassphraseKey(passphrase string, salt []byte) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, passphrase, salt, localSecretKDFIterations, localSecretKeySize)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalSecretStoreWithKeyFile(t *testing.T) {
+	tempDir := t.TempDir()
+	storePath := filepath.Join(tempDir, "data", "secrets.enc")
+	keyPath := filepath.Join(tempDir, "data", "secrets.key")
+	store := NewLocalSecretStore(storePath, keyPath, "")
+
+	secrets, err := store.Load()
+	require.NoError(t, err)
+	require.Empty(t, secrets)
+
+	require.NoError(t, store.Set("B_SECRET", "b"))
+	require.NoError(t, store.Set("A_SECRET", "a"))
+
+	info, err := os.Stat(storePath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(privateFilePermission), info.Mode().Perm())
+	info, err = os.Stat(keyPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(privateFilePermission), info.Mode().Perm())
+
+	fileData, err := os.ReadFile(storePath)
+	require.NoError(t, err)
+	require.NotContains(t, string(fileData), "A_SECRET")
+
+	names, err := NewLocalSecretStore(storePath, keyPath, "").Names()
+	require.NoError(t, err)
+	require.Equal(t, []string{"A_SECRET", "B_SECRET"}, names)
+
+	require.NoError(t, store.Remove("A_SECRET"))
+	require.ErrorIs(t, store.Remove("A_SECRET"), ErrLocalSecretNotFound)
+
+	// saving again restores the permission of a store that was made readable by others
+	require.NoError(t, os.Chmod(storePath, 0644))
+	require.NoError(t, store.Set("C_SECRET", "c"))
+	info, err = os.Stat(storePath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(privateFilePermission), info.Mode().Perm())
+	require.NoError(t, store.Remove("C_SECRET"))
+
+	secrets, err = store.Load()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"B_SECRET": "b"}, secrets)
+
+	otherKeyPath := filepath.Join(tempDir, "other.key")
+	require.NoError(t, os.WriteFile(otherKeyPath, make([]byte, localSecretKeySize), privateFilePermission))
+	_, err = NewLocalSecretStore(storePath, otherKeyPath, "").Load()
+	require.ErrorIs(t, err, ErrInvalidSecretStore)
+}
+
+func TestLocalSecretStoreWithPassphrase(t *testing.T) {
+	tempDir := t.TempDir()
+	storePath := filepath.Join(tempDir, "secrets.enc")
+	keyPath := filepath.Join(tempDir, "secrets.key")
+
+	require.NoError(t, NewLocalSecretStore(storePath, keyPath, "correct horse").Set("TOKEN", "value"))
+	_, err := os.Stat(keyPath)
+	require.True(t, os.IsNotExist(err), "key file should not be created when a passphrase is used")
+
+	secrets, err := NewLocalSecretStore(storePath, keyPath, "correct horse").Load()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"TOKEN": "value"}, secrets)
+
+	_, err = NewLocalSecretStore(storePath, keyPath, "").Load()
+	require.ErrorIs(t, err, ErrPassphraseRequired)
+
+	_, err = NewLocalSecretStore(storePath, keyPath, "wrong").Load()
+	require.ErrorIs(t, err, ErrInvalidSecretStore)
+}
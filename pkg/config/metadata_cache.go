@@ -0,0 +1,95 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// MetadataCacheTTL is how long the cached platform metadata, e.g. the regions and runtimes, is reused.
+const MetadataCacheTTL = 24 * time.Hour
+
+var DefaultMetadataCacheDir = DefaultCLIDataDir + "/metadata"
+
+// ErrMetadataNotCached is returned for metadata that was never cached or whose TTL has passed.
+var ErrMetadataNotCached = errors.New("metadata not cached")
+
+type metadataCacheEntry struct {
+	CachedAt time.Time       `json:"cached_at"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// MetadataCache stores the results of metadata queries that rarely change, so that commands don't
+// fetch them again each time. Each result is kept in a JSON file named after the hash of its key.
+type MetadataCache struct {
+	dir string
+	ttl time.Duration
+}
+
+func NewMetadataCache(dir string, ttl time.Duration) (MetadataCache, error) {
+	dir, err := homedir.Expand(dir)
+	if err != nil {
+		return MetadataCache{}, err
+	}
+	return MetadataCache{dir: dir, ttl: ttl}, nil
+}
+
+// Get decodes the value cached for key into v, or returns ErrMetadataNotCached when it is missing,
+// expired or corrupted.
+func (c MetadataCache) Get(key string, v any) error {
+	data, err := os.ReadFile(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrMetadataNotCached
+	}
+	if err != nil {
+		return err
+	}
+	var entry metadataCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return ErrMetadataNotCached
+	}
+	if time.Since(entry.CachedAt) >= c.ttl {
+		return ErrMetadataNotCached
+	}
+	if err := json.Unmarshal(entry.Data, v); err != nil {
+		return ErrMetadataNotCached
+	}
+	return nil
+}
+
+// Put replaces the value cached for key.
+func (c MetadataCache) Put(key string, v any) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(metadataCacheEntry{CachedAt: time.Now(), Data: value})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, privateDirPermission); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path(key), data, privateFilePermission)
+}
+
+// Delete removes the value cached for key, if any.
+func (c MetadataCache) Delete(key string) error {
+	err := os.Remove(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path returns the file of a key. Keys contain the GraphQL endpoint and API key, so they are hashed.
+func (c MetadataCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16])+".json")
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "metadata")
+	cache, err := NewMetadataCache(dir, time.Hour)
+	require.NoError(t, err)
+
+	var regions []string
+	require.ErrorIs(t, cache.Get("regions", &regions), ErrMetadataNotCached)
+
+	require.NoError(t, cache.Put("regions", []string{"aws.euw1", "aws.use1"}))
+	require.NoError(t, cache.Get("regions", &regions))
+	require.Equal(t, []string{"aws.euw1", "aws.use1"}, regions)
+	require.ErrorIs(t, cache.Get("runtimes", &regions), ErrMetadataNotCached)
+
+	require.NoError(t, cache.Delete("regions"))
+	require.ErrorIs(t, cache.Get("regions", &regions), ErrMetadataNotCached)
+	require.NoError(t, cache.Delete("regions"), "deleting a missing entry is not an error")
+	require.NoError(t, cache.Put("regions", []string{"aws.euw1", "aws.use1"}))
+
+	// an expired entry is not returned
+	data, err := json.Marshal(metadataCacheEntry{CachedAt: time.Now().Add(-2 * time.Hour), Data: json.RawMessage(`["old"]`)})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cache.path("regions"), data, privateFilePermission))
+	require.ErrorIs(t, cache.Get("regions", &regions), ErrMetadataNotCached)
+
+	// a corrupted entry is only a missing one
+	require.NoError(t, os.WriteFile(cache.path("regions"), []byte("{"), privateFilePermission))
+	require.ErrorIs(t, cache.Get("regions", &regions), ErrMetadataNotCached)
+}
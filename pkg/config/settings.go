@@ -0,0 +1,195 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+var ErrUnknownSetting = errors.New("unknown setting, run 'vcr config list' to see all settings")
+
+// Setting keys that can be read and written with 'vcr config'.
+const (
+	SettingDefaultRegion     = "default_region"
+	SettingGraphqlEndpoint   = "graphql_endpoint"
+	SettingAPIKey            = "api_key"
+	SettingAPISecret         = "api_secret"
+	SettingCredentialStore   = "credential_store"
+	SettingCredentialProcess = "credential_process"
+	SettingUpdateCheck       = "update_check"
+	SettingOutputFormat      = "output_format"
+)
+
+// Values of SettingOutputFormat.
+const (
+	OutputFormatTable = "table"
+	OutputFormatJSON  = "json"
+)
+
+// Setting describes a single key of the config file.
+type Setting struct {
+	Key         string
+	Description string
+	// Global settings are stored at the top level of the file and shared by all profiles.
+	Global bool
+	// Secret values are masked when listed.
+	Secret bool
+
+	validate func(string) (string, error)
+}
+
+// Settings lists every key supported by 'vcr config', in display order.
+var Settings = []Setting{
+	{Key: SettingDefaultRegion, Description: "Region used when --region is not set"},
+	{Key: SettingGraphqlEndpoint, Description: "GraphQL endpoint used to fetch metadata", validate: validateEndpoint},
+	{Key: SettingAPIKey, Description: "Vonage API key"},
+	{Key: SettingAPISecret, Description: "Vonage API secret", Secret: true},
+	{Key: SettingCredentialStore, Description: "Where the API secret is kept: plaintext or encrypted", validate: validateCredentialStoreSetting},
+	{Key: SettingCredentialProcess, Description: "Command that prints the API key and secret as JSON"},
+	{Key: SettingUpdateCheck, Description: "Check for new CLI versions once a day: true or false", Global: true, validate: validateBool},
+	{Key: SettingOutputFormat, Description: "Output of the list and show commands when --json is not set: table or json", Global: true, validate: validateOutputFormat},
+}
+
+// LookupSetting returns the setting with the given key.
+func LookupSetting(key string) (Setting, error) {
+	for _, s := range Settings {
+		if s.Key == key {
+			return s, nil
+		}
+	}
+	return Setting{}, fmt.Errorf("%q: %w", key, ErrUnknownSetting)
+}
+
+// Normalize validates the value and returns it in the form stored in the config file.
+func (s Setting) Normalize(value string) (string, error) {
+	if s.validate == nil {
+		return value, nil
+	}
+	normalized, err := s.validate(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid value for %s: %w", s.Key, err)
+	}
+	return normalized, nil
+}
+
+// Get returns the value of the key in the named profile. Global settings ignore the profile.
+func (c CLIConfig) Get(profileName, key string) (string, error) {
+	s, err := LookupSetting(key)
+	if err != nil {
+		return "", err
+	}
+	if s.Global {
+		return *c.globalField(key), nil
+	}
+	p, err := c.Profile(profileName)
+	if err != nil {
+		return "", err
+	}
+	if field := p.field(key); field != nil {
+		return *field, nil
+	}
+	return "", nil
+}
+
+// Set validates the value and stores it under the key of the named profile, creating the profile if needed.
+func (c *CLIConfig) Set(profileName, key, value string) error {
+	s, err := LookupSetting(key)
+	if err != nil {
+		return err
+	}
+	value, err = s.Normalize(value)
+	if err != nil {
+		return err
+	}
+	return c.set(s, profileName, value)
+}
+
+// Unset clears the key of the named profile, so that its default applies again.
+func (c *CLIConfig) Unset(profileName, key string) error {
+	s, err := LookupSetting(key)
+	if err != nil {
+		return err
+	}
+	if _, err := c.Profile(profileName); err != nil && !s.Global {
+		return err
+	}
+	return c.set(s, profileName, "")
+}
+
+func (c *CLIConfig) set(s Setting, profileName, value string) error {
+	if s.Global {
+		*c.globalField(s.Key) = value
+		return nil
+	}
+	p, err := c.Profile(profileName)
+	if err != nil && !errors.Is(err, ErrProfileNotFound) {
+		return err
+	}
+	if field := p.field(s.Key); field != nil {
+		*field = value
+	}
+	c.SetProfile(profileName, p)
+	return nil
+}
+
+// globalField returns a pointer to the top-level value stored under the key of a global setting.
+func (c *CLIConfig) globalField(key string) *string {
+	if key == SettingOutputFormat {
+		return &c.OutputFormat
+	}
+	return &c.UpdateCheck
+}
+
+// field returns a pointer to the profile value stored under the key, or nil for global settings.
+func (p *Profile) field(key string) *string {
+	switch key {
+	case SettingDefaultRegion:
+		return &p.DefaultRegion
+	case SettingGraphqlEndpoint:
+		return &p.GraphqlEndpoint
+	case SettingAPIKey:
+		return &p.APIKey
+	case SettingAPISecret:
+		return &p.APISecret
+	case SettingCredentialStore:
+		return &p.CredentialStore
+	case SettingCredentialProcess:
+		return &p.CredentialProcess
+	default:
+		return nil
+	}
+}
+
+func validateEndpoint(value string) (string, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return "", err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%q is not an http(s) URL", value)
+	}
+	return value, nil
+}
+
+func validateCredentialStoreSetting(value string) (string, error) {
+	if err := ValidateCredentialStore(value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+func validateOutputFormat(value string) (string, error) {
+	if value != OutputFormatTable && value != OutputFormatJSON {
+		return "", fmt.Errorf("%q is not %s or %s", value, OutputFormatTable, OutputFormatJSON)
+	}
+	return value, nil
+}
+
+func validateBool(value string) (string, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", fmt.Errorf("%q is not true or false", value)
+	}
+	return strconv.FormatBool(b), nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCLIConfigSettings(t *testing.T) {
+	var cfg CLIConfig
+
+	require.NoError(t, cfg.Set("", SettingDefaultRegion, "aws.euw1"))
+	require.NoError(t, cfg.Set("prod", SettingAPIKey, "prod-key"))
+	require.NoError(t, cfg.Set("prod", SettingUpdateCheck, "0"))
+	require.NoError(t, cfg.Set("prod", SettingOutputFormat, "json"))
+
+	require.Equal(t, "aws.euw1", cfg.DefaultRegion)
+	require.Equal(t, "prod-key", cfg.Profiles["prod"].APIKey)
+	require.Equal(t, "false", cfg.UpdateCheck, "global settings should be normalized and stored at the top level")
+	require.Equal(t, "json", cfg.OutputFormat)
+
+	value, err := cfg.Get("prod", SettingUpdateCheck)
+	require.NoError(t, err)
+	require.Equal(t, "false", value)
+	value, err = cfg.Get("", SettingOutputFormat)
+	require.NoError(t, err)
+	require.Equal(t, "json", value)
+	value, err = cfg.Get(DefaultProfile, SettingDefaultRegion)
+	require.NoError(t, err)
+	require.Equal(t, "aws.euw1", value)
+
+	require.NoError(t, cfg.Unset("prod", SettingAPIKey))
+	value, err = cfg.Get("prod", SettingAPIKey)
+	require.NoError(t, err)
+	require.Empty(t, value)
+
+	_, err = cfg.Get("missing", SettingAPIKey)
+	require.ErrorIs(t, err, ErrProfileNotFound)
+	_, err = cfg.Get("", "colour")
+	require.ErrorIs(t, err, ErrUnknownSetting)
+
+	require.EqualError(t, cfg.Set("", SettingGraphqlEndpoint, "graphql.example.com"), `invalid value for graphql_endpoint: "graphql.example.com" is not an http(s) URL`)
+	require.EqualError(t, cfg.Set("", SettingUpdateCheck, "maybe"), `invalid value for update_check: "maybe" is not true or false`)
+	require.EqualError(t, cfg.Set("", SettingOutputFormat, "yaml"), `invalid value for output_format: "yaml" is not table or json`)
+	require.Error(t, cfg.Set("", SettingCredentialStore, "keychain"))
+}
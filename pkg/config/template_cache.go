@@ -0,0 +1,133 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+var DefaultTemplateCacheDir = DefaultCLIDataDir + "/templates"
+
+var ErrTemplateNotCached = errors.New("template not cached")
+
+// CachedTemplate describes a template archive downloaded earlier.
+type CachedTemplate struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Source   string    `json:"source"`
+	Language string    `json:"language"`
+	Version  string    `json:"version"`
+	Size     int64     `json:"size"`
+	CachedAt time.Time `json:"cached_at"`
+}
+
+// TemplateCache stores template archives so that they can be used without downloading them again.
+// Each template is kept as <key>.tar.gz next to a <key>.json that describes it.
+type TemplateCache struct {
+	dir string
+}
+
+func NewTemplateCache(dir string) (TemplateCache, error) {
+	dir, err := homedir.Expand(dir)
+	if err != nil {
+		return TemplateCache{}, err
+	}
+	return TemplateCache{dir: dir}, nil
+}
+
+// Put replaces the cached archive of a template.
+func (c TemplateCache) Put(t CachedTemplate, archive []byte) error {
+	if err := os.MkdirAll(c.dir, privateDirPermission); err != nil {
+		return err
+	}
+	t.Size = int64(len(archive))
+	t.CachedAt = time.Now()
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	key := c.key(t.ID)
+	if err := os.WriteFile(key+".tar.gz", archive, privateFilePermission); err != nil {
+		return err
+	}
+	return os.WriteFile(key+".json", data, privateFilePermission)
+}
+
+// Get returns a cached template by ID, or ErrTemplateNotCached.
+func (c TemplateCache) Get(id string) (CachedTemplate, []byte, error) {
+	key := c.key(id)
+	data, err := os.ReadFile(key + ".json")
+	if errors.Is(err, os.ErrNotExist) {
+		return CachedTemplate{}, nil, ErrTemplateNotCached
+	}
+	if err != nil {
+		return CachedTemplate{}, nil, err
+	}
+	var t CachedTemplate
+	if err := json.Unmarshal(data, &t); err != nil {
+		return CachedTemplate{}, nil, err
+	}
+	archive, err := os.ReadFile(key + ".tar.gz")
+	if errors.Is(err, os.ErrNotExist) {
+		return CachedTemplate{}, nil, ErrTemplateNotCached
+	}
+	if err != nil {
+		return CachedTemplate{}, nil, err
+	}
+	return t, archive, nil
+}
+
+// Find returns a cached template by ID or by name, ignoring case, or ErrTemplateNotCached.
+func (c TemplateCache) Find(idOrName string) (CachedTemplate, []byte, error) {
+	t, archive, err := c.Get(idOrName)
+	if !errors.Is(err, ErrTemplateNotCached) {
+		return t, archive, err
+	}
+	templates, err := c.List()
+	if err != nil {
+		return CachedTemplate{}, nil, err
+	}
+	for _, t := range templates {
+		if strings.EqualFold(t.Name, idOrName) {
+			return c.Get(t.ID)
+		}
+	}
+	return CachedTemplate{}, nil, ErrTemplateNotCached
+}
+
+// List returns the cached templates sorted by name.
+func (c TemplateCache) List() ([]CachedTemplate, error) {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	templates := make([]CachedTemplate, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var t CachedTemplate
+		if err := json.Unmarshal(data, &t); err != nil {
+			// a corrupted entry is only a missing cache entry
+			continue
+		}
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// key returns the path of a template without extension. IDs can contain slashes, so they are hashed.
+func (c TemplateCache) key(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16]))
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateCache(t *testing.T) {
+	cache, err := NewTemplateCache(filepath.Join(t.TempDir(), "templates"))
+	require.NoError(t, err)
+
+	_, _, err = cache.Get("product-id")
+	require.ErrorIs(t, err, ErrTemplateNotCached)
+	templates, err := cache.List()
+	require.NoError(t, err)
+	require.Empty(t, templates)
+
+	require.NoError(t, cache.Put(CachedTemplate{ID: "product-id", Name: "Starter Project", Source: "marketplace", Version: "v1"}, []byte("archive")))
+	require.NoError(t, cache.Put(CachedTemplate{ID: "templates/nodejs/voice.tar.gz", Name: "voice", Source: "asset"}, []byte("voice")))
+
+	cached, archive, err := cache.Get("product-id")
+	require.NoError(t, err)
+	require.Equal(t, "v1", cached.Version)
+	require.Equal(t, int64(len("archive")), cached.Size)
+	require.Equal(t, "archive", string(archive))
+
+	cached, archive, err = cache.Find("starter project")
+	require.NoError(t, err)
+	require.Equal(t, "product-id", cached.ID)
+	require.Equal(t, "archive", string(archive))
+
+	_, archive, err = cache.Find("templates/nodejs/voice.tar.gz")
+	require.NoError(t, err)
+	require.Equal(t, "voice", string(archive))
+
+	_, _, err = cache.Find("missing")
+	require.ErrorIs(t, err, ErrTemplateNotCached)
+
+	require.NoError(t, cache.Put(CachedTemplate{ID: "product-id", Name: "Starter Project", Version: "v2"}, []byte("archive v2")))
+	templates, err = cache.List()
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	require.Equal(t, "Starter Project", templates[0].Name)
+	require.Equal(t, "v2", templates[0].Version)
+}
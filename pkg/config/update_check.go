@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+const (
+	// NoUpdateNotifierEnv disables the update check when set to any value.
+	NoUpdateNotifierEnv = "VCR_NO_UPDATE_NOTIFIER"
+	// CIEnv is set by most CI providers, where the update check is disabled.
+	CIEnv = "CI"
+
+	// UpdateCheckInterval is how long the result of an update check is reused.
+	UpdateCheckInterval = 24 * time.Hour
+)
+
+var DefaultUpdateCheckCachePath = DefaultCLIDataDir + "/update-check.json"
+
+// UpdateCheckCache stores the result of the last update check.
+type UpdateCheckCache struct {
+	CheckedAt time.Time `json:"checked_at"`
+	// LatestVersion is the latest version found by a successful check, which is kept when a later check
+	// fails. It is empty if no check succeeded yet.
+	LatestVersion string `json:"latest_version"`
+}
+
+// IsFresh reports whether the last check, successful or not, is recent enough to skip checking again.
+func (c UpdateCheckCache) IsFresh(now time.Time) bool {
+	return now.Sub(c.CheckedAt) < UpdateCheckInterval
+}
+
+// UpdateCheckEnabled reports whether the background update check should run. It is disabled by
+// NoUpdateNotifierEnv, by CIEnv, or by setting update_check to false in the config file.
+func UpdateCheckEnabled(c CLIConfig) bool {
+	if os.Getenv(NoUpdateNotifierEnv) != "" || os.Getenv(CIEnv) != "" {
+		return false
+	}
+	return c.UpdateCheck != "false"
+}
+
+func ReadUpdateCheckCache(path string) (UpdateCheckCache, error) {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return UpdateCheckCache{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return UpdateCheckCache{}, err
+	}
+	var cache UpdateCheckCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return UpdateCheckCache{}, err
+	}
+	return cache, nil
+}
+
+func WriteUpdateCheckCache(path string, cache UpdateCheckCache) error {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), privateDirPermission); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, privateFilePermission)
+}
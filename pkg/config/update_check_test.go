@@ -0,0 +1,43 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateCheckCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "update-check.json")
+
+	_, err := ReadUpdateCheckCache(path)
+	require.Error(t, err)
+
+	now := time.Now()
+	require.NoError(t, WriteUpdateCheckCache(path, UpdateCheckCache{CheckedAt: now, LatestVersion: "1.2.3"}))
+	cache, err := ReadUpdateCheckCache(path)
+	require.NoError(t, err)
+	require.Equal(t, "1.2.3", cache.LatestVersion)
+
+	require.True(t, cache.IsFresh(now.Add(time.Hour)))
+	require.False(t, cache.IsFresh(now.Add(UpdateCheckInterval)))
+	require.True(t, UpdateCheckCache{CheckedAt: now}.IsFresh(now), "a failed check is not repeated either")
+	require.False(t, UpdateCheckCache{}.IsFresh(now))
+}
+
+func TestUpdateCheckEnabled(t *testing.T) {
+	t.Setenv(NoUpdateNotifierEnv, "")
+	t.Setenv(CIEnv, "")
+
+	require.True(t, UpdateCheckEnabled(CLIConfig{}))
+	require.True(t, UpdateCheckEnabled(CLIConfig{UpdateCheck: "true"}))
+	require.False(t, UpdateCheckEnabled(CLIConfig{UpdateCheck: "false"}))
+
+	t.Setenv(CIEnv, "true")
+	require.False(t, UpdateCheckEnabled(CLIConfig{}))
+
+	t.Setenv(CIEnv, "")
+	t.Setenv(NoUpdateNotifierEnv, "1")
+	require.False(t, UpdateCheckEnabled(CLIConfig{}))
+}
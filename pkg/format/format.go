@@ -1,14 +1,17 @@
 package format
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/olekukonko/tablewriter"
 
 	"vonage-cloud-runtime-cli/pkg/cmdutil"
 
@@ -79,7 +82,7 @@ func GetRuntimeOptions(runtimes []api.Runtime) RuntimeOptions {
 	}
 
 	for _, r := range runtimes {
-		if r.Language != "debug" && r.Language != "" && r.Comments != "deprecated" {
+		if r.Language != "debug" && r.Language != "" && !r.Deprecated() {
 			if r.Comments != "" {
 				label := fmt.Sprintf("%s - (%s)", r.Name, r.Comments)
 				options.Labels = append(options.Labels, label)
@@ -188,35 +191,120 @@ func parseCapVersion(capability string) (string, error) {
 
 var versionRegex = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
 
+// PrintUpdateMessage prints the release received on updateMessageChan, if it is newer than version.
+// It never waits: a result that has not arrived yet is not printed, so the update check cannot
+// delay the end of a command.
 func PrintUpdateMessage(out *iostreams.IOStreams, version string, updateMessageChan chan string) {
 	c := out.ColorScheme()
-	const updateCheckTimeout = 500 * time.Millisecond
+	var rel string
 	select {
-	case rel, ok := <-updateMessageChan:
-		if ok {
-			if rel != "" {
-				if !versionRegex.MatchString(rel) {
-					return
-				}
-				version = strings.TrimPrefix(version, "v")
-				if version == "dev" {
-					version = "0.0.1"
-				}
-				fmt.Fprintf(out.Out, "\n\n%s %s → %s\n",
-					c.Yellow("A new release of vcr is available:"),
-					c.Cyan(strings.TrimPrefix(version, "v")),
-					c.Cyan(strings.TrimPrefix(rel, "v")))
-
-				fmt.Fprintf(out.Out, "To upgrade, run: %s\n", "vcr upgrade")
-				return
-			}
-			return
-		}
+	case rel = <-updateMessageChan:
+	default:
 		return
-	case <-time.After(updateCheckTimeout):
-		close(updateMessageChan)
+	}
+	if rel == "" || !versionRegex.MatchString(rel) {
 		return
 	}
+	version = strings.TrimPrefix(version, "v")
+	if version == "dev" {
+		version = "0.0.1"
+	}
+	fmt.Fprintf(out.Out, "\n\n%s %s → %s\n",
+		c.Yellow("A new release of vcr is available:"),
+		c.Cyan(strings.TrimPrefix(version, "v")),
+		c.Cyan(strings.TrimPrefix(rel, "v")))
+
+	fmt.Fprintf(out.Out, "To upgrade, run: %s\n", "vcr upgrade")
+}
+
+// PrintApplication prints the details of a Vonage application followed by a table of its capabilities and webhooks.
+func PrintApplication(out *iostreams.IOStreams, app api.VonageApplication) error {
+	c := out.ColorScheme()
+	keys := "generated"
+	if app.Keys.PublicKey == "" {
+		keys = fmt.Sprintf("not generated, run 'vcr app generate-keys --app-id %s'", app.ID)
+	}
+	fmt.Fprintf(out.Out, "%s id: %s\n", c.Blue(cmdutil.InfoIcon), app.ID)
+	fmt.Fprintf(out.Out, "%s name: %s\n", c.Blue(cmdutil.InfoIcon), app.Name)
+	fmt.Fprintf(out.Out, "%s keys: %s\n", c.Blue(cmdutil.InfoIcon), keys)
+
+	caps := app.Capabilities
+	var rows [][]string
+	if caps.Voice != nil {
+		rows = append(rows,
+			[]string{"voice", "enabled", "answer", caps.Voice.AnswerURL},
+			[]string{"voice", "enabled", "event", caps.Voice.EventURL})
+	} else {
+		rows = append(rows, []string{"voice", "disabled", "", ""})
+	}
+	if caps.Messages != nil {
+		rows = append(rows,
+			[]string{"messages", "enabled", "inbound", caps.Messages.InboundURL},
+			[]string{"messages", "enabled", "status", caps.Messages.StatusURL})
+	} else {
+		rows = append(rows, []string{"messages", "disabled", "", ""})
+	}
+	if caps.RTC != nil {
+		rows = append(rows, []string{"rtc", "enabled", "event", caps.RTC.EventURL})
+	} else {
+		rows = append(rows, []string{"rtc", "disabled", "", ""})
+	}
+
+	table := tablewriter.NewWriter(out.Out)
+	table.Header("Capability", "Status", "Webhook", "URL")
+	if err := table.Bulk(rows); err != nil {
+		return fmt.Errorf("failed to append capabilities to table: %w", err)
+	}
+	return table.Render()
+}
+
+// ByteSize formats a size in bytes for display, e.g. 12.3 KB. Zero, an unknown size, is shown as "-".
+func ByteSize(size int64) string {
+	const unit = 1024
+	switch {
+	case size <= 0:
+		return "-"
+	case size < unit:
+		return fmt.Sprintf("%d B", size)
+	case size < unit*unit:
+		return fmt.Sprintf("%.1f KB", float64(size)/unit)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(size)/(unit*unit))
+	}
+}
+
+// timestampLayouts are the formats of the timestamps of the datastore, with or without time zone.
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"}
+
+// Timestamp formats a timestamp of the datastore for display, e.g. 2024-01-31 09:30. An empty
+// timestamp is shown as "-", and one that can't be parsed as it is.
+func Timestamp(s string) string {
+	if s == "" {
+		return "-"
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC().Format("2006-01-02 15:04")
+		}
+	}
+	return s
+}
+
+// PrintJSON writes v as indented JSON, for the commands that have a --json flag.
+func PrintJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// RuntimeJSON is a runtime as printed by the --json flags, with its deprecation spelled out.
+type RuntimeJSON struct {
+	api.Runtime
+	Deprecated bool `json:"deprecated"`
+}
+
+func NewRuntimeJSON(r api.Runtime) RuntimeJSON {
+	return RuntimeJSON{Runtime: r, Deprecated: r.Deprecated()}
 }
 
 func PrintAPIError(out *iostreams.IOStreams, err error, httpErr *api.Error) string {
@@ -168,40 +168,36 @@ func TestParseCapabilities(t *testing.T) {
 func TestPrintUpdateMessage(t *testing.T) {
 	ios, _, stdout, _ := iostreams.Test()
 	version := "1.0.0"
-	updateMessageChan := make(chan string)
+	updateMessageChan := make(chan string, 1)
 	expectedOutput := fmt.Sprintf("\n\n%s %s → %s\nTo upgrade, run: %s\n",
 		"A new release of vcr is available:",
 		"1.0.0",
 		"2.0.0",
 		"vcr upgrade")
 
-	go func() {
-		updateMessageChan <- "2.0.0"
-	}()
+	updateMessageChan <- "2.0.0"
 
 	PrintUpdateMessage(ios, version, updateMessageChan)
 
 	require.Equal(t, expectedOutput, stdout.String())
 
 	ios, _, _, stderr := iostreams.Test()
-	updateMessageChan = make(chan string)
+	updateMessageChan = make(chan string, 1)
 	errMessage := "Invalid release message"
-	go func() {
-		updateMessageChan <- errMessage
-	}()
+	updateMessageChan <- errMessage
 
 	PrintUpdateMessage(ios, version, updateMessageChan)
 
 	require.Equal(t, "", stderr.String())
 
+	// a check that is still running must not delay the command
 	ios, _, stdout, stderr = iostreams.Test()
-	updateMessageChan = make(chan string)
-	go func() {
-		time.Sleep(1 * time.Second)
-	}()
+	updateMessageChan = make(chan string, 1)
+	start := time.Now()
 
 	PrintUpdateMessage(ios, version, updateMessageChan)
 
+	require.Less(t, time.Since(start), 100*time.Millisecond)
 	require.Equal(t, "", stdout.String())
 	require.Equal(t, "", stderr.String())
 }
@@ -315,3 +311,18 @@ func TestGetTemplateOptions(t *testing.T) {
 	require.Equal(t, expectedOptions.Labels, options.Labels)
 	require.Equal(t, expectedOptions.IDLookup, options.IDLookup)
 }
+
+func TestByteSize(t *testing.T) {
+	require.Equal(t, "-", ByteSize(0))
+	require.Equal(t, "512 B", ByteSize(512))
+	require.Equal(t, "1.5 KB", ByteSize(1536))
+	require.Equal(t, "2.0 MB", ByteSize(2*1024*1024))
+}
+
+func TestTimestamp(t *testing.T) {
+	require.Equal(t, "-", Timestamp(""))
+	require.Equal(t, "2024-01-31 09:30", Timestamp("2024-01-31T09:30:15"))
+	require.Equal(t, "2024-01-31 09:30", Timestamp("2024-01-31T09:30:15.123456"))
+	require.Equal(t, "2024-01-31 08:30", Timestamp("2024-01-31T09:30:15+01:00"))
+	require.Equal(t, "yesterday", Timestamp("yesterday"))
+}
@@ -25,7 +25,7 @@ const (
 
 var (
 	DefaultDeadline      = time.Now().Add(DefaultTimeout)
-	DefaultHTTPClient    = cmdutil.GetHTTPClient(DefaultAPIKey, DefaultAPISecret).SetHeader("X-Neru-Trace-Id", DefaultTraceID)
+	DefaultHTTPClient    = cmdutil.GetHTTPClient(DefaultAPIKey, DefaultAPISecret, 0).SetHeader("X-Neru-Trace-Id", DefaultTraceID)
 	DefaultGlobalOptions = config.GlobalOptions{
 		ConfigFilePath:  DefaultConfigFilePath,
 		APISecret:       DefaultAPISecret,
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 	time "time"
 	api "vonage-cloud-runtime-cli/pkg/api"
@@ -190,6 +191,36 @@ func (mr *MockReleaseInterfaceMockRecorder) GetLatestRelease(ctx interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestRelease", reflect.TypeOf((*MockReleaseInterface)(nil).GetLatestRelease), ctx)
 }
 
+// GetReleaseByTag mocks base method.
+func (m *MockReleaseInterface) GetReleaseByTag(ctx context.Context, tag string) (api.Release, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReleaseByTag", ctx, tag)
+	ret0, _ := ret[0].(api.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReleaseByTag indicates an expected call of GetReleaseByTag.
+func (mr *MockReleaseInterfaceMockRecorder) GetReleaseByTag(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReleaseByTag", reflect.TypeOf((*MockReleaseInterface)(nil).GetReleaseByTag), ctx, tag)
+}
+
+// ListReleases mocks base method.
+func (m *MockReleaseInterface) ListReleases(ctx context.Context) ([]api.Release, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReleases", ctx)
+	ret0, _ := ret[0].([]api.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReleases indicates an expected call of ListReleases.
+func (mr *MockReleaseInterfaceMockRecorder) ListReleases(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReleases", reflect.TypeOf((*MockReleaseInterface)(nil).ListReleases), ctx)
+}
+
 // MockMarketplaceInterface is a mock of MarketplaceInterface interface.
 type MockMarketplaceInterface struct {
 	ctrl     *gomock.Controller
@@ -367,6 +398,20 @@ func (mr *MockDeploymentInterfaceMockRecorder) DeleteMongoDatabase(ctx, version,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMongoDatabase", reflect.TypeOf((*MockDeploymentInterface)(nil).DeleteMongoDatabase), ctx, version, database)
 }
 
+// DeleteProject mocks base method.
+func (m *MockDeploymentInterface) DeleteProject(ctx context.Context, projectID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", ctx, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockDeploymentInterfaceMockRecorder) DeleteProject(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockDeploymentInterface)(nil).DeleteProject), ctx, projectID)
+}
+
 // DeleteVonageApplication mocks base method.
 func (m *MockDeploymentInterface) DeleteVonageApplication(ctx context.Context, appID string) error {
 	m.ctrl.T.Helper()
@@ -412,11 +457,12 @@ func (mr *MockDeploymentInterfaceMockRecorder) DeployInstance(ctx, deployInstanc
 }
 
 // GenerateVonageApplicationKeys mocks base method.
-func (m *MockDeploymentInterface) GenerateVonageApplicationKeys(ctx context.Context, appID string) error {
+func (m *MockDeploymentInterface) GenerateVonageApplicationKeys(ctx context.Context, appID string) (api.GenerateVonageApplicationKeysOutput, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GenerateVonageApplicationKeys", ctx, appID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(api.GenerateVonageApplicationKeysOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GenerateVonageApplicationKeys indicates an expected call of GenerateVonageApplicationKeys.
@@ -455,6 +501,21 @@ func (mr *MockDeploymentInterfaceMockRecorder) GetServiceReadyStatus(ctx, servic
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceReadyStatus", reflect.TypeOf((*MockDeploymentInterface)(nil).GetServiceReadyStatus), ctx, serviceName)
 }
 
+// GetVonageApplication mocks base method.
+func (m *MockDeploymentInterface) GetVonageApplication(ctx context.Context, appID string) (api.VonageApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVonageApplication", ctx, appID)
+	ret0, _ := ret[0].(api.VonageApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVonageApplication indicates an expected call of GetVonageApplication.
+func (mr *MockDeploymentInterfaceMockRecorder) GetVonageApplication(ctx, appID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVonageApplication", reflect.TypeOf((*MockDeploymentInterface)(nil).GetVonageApplication), ctx, appID)
+}
+
 // ListMongoDatabases mocks base method.
 func (m *MockDeploymentInterface) ListMongoDatabases(ctx context.Context, version string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -542,6 +603,21 @@ func (mr *MockDeploymentInterfaceMockRecorder) UpdateSecret(ctx, s interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockDeploymentInterface)(nil).UpdateSecret), ctx, s)
 }
 
+// UpdateVonageApplication mocks base method.
+func (m *MockDeploymentInterface) UpdateVonageApplication(ctx context.Context, appID string, input api.UpdateVonageApplicationInput) (api.VonageApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVonageApplication", ctx, appID, input)
+	ret0, _ := ret[0].(api.VonageApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVonageApplication indicates an expected call of UpdateVonageApplication.
+func (mr *MockDeploymentInterfaceMockRecorder) UpdateVonageApplication(ctx, appID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVonageApplication", reflect.TypeOf((*MockDeploymentInterface)(nil).UpdateVonageApplication), ctx, appID, input)
+}
+
 // UploadTgz mocks base method.
 func (m *MockDeploymentInterface) UploadTgz(ctx context.Context, fileBytes []byte) (api.UploadResponse, error) {
 	m.ctrl.T.Helper()
@@ -573,17 +649,17 @@ func (mr *MockDeploymentInterfaceMockRecorder) ValidateDeployment(ctx, req inter
 }
 
 // WatchDeployment mocks base method.
-func (m *MockDeploymentInterface) WatchDeployment(ctx context.Context, out *iostreams.IOStreams, packageID string) error {
+func (m *MockDeploymentInterface) WatchDeployment(ctx context.Context, out *iostreams.IOStreams, packageID string, buildLog io.Writer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WatchDeployment", ctx, out, packageID)
+	ret := m.ctrl.Call(m, "WatchDeployment", ctx, out, packageID, buildLog)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // WatchDeployment indicates an expected call of WatchDeployment.
-func (mr *MockDeploymentInterfaceMockRecorder) WatchDeployment(ctx, out, packageID interface{}) *gomock.Call {
+func (mr *MockDeploymentInterfaceMockRecorder) WatchDeployment(ctx, out, packageID, buildLog interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchDeployment", reflect.TypeOf((*MockDeploymentInterface)(nil).WatchDeployment), ctx, out, packageID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchDeployment", reflect.TypeOf((*MockDeploymentInterface)(nil).WatchDeployment), ctx, out, packageID, buildLog)
 }
 
 // MockDatastoreInterface is a mock of DatastoreInterface interface.
@@ -714,6 +790,35 @@ func (mr *MockDatastoreInterfaceMockRecorder) ListInstances(ctx, filter interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstances", reflect.TypeOf((*MockDatastoreInterface)(nil).ListInstances), ctx, filter)
 }
 
+// ListInstancesByProjectID mocks base method.
+func (m *MockDatastoreInterface) ListInstancesByProjectID(ctx context.Context, projectID string) ([]api.InstanceListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstancesByProjectID", ctx, projectID)
+	ret0, _ := ret[0].([]api.InstanceListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstancesByProjectID indicates an expected call of ListInstancesByProjectID.
+func (mr *MockDatastoreInterfaceMockRecorder) ListInstancesByProjectID(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstancesByProjectID", reflect.TypeOf((*MockDatastoreInterface)(nil).ListInstancesByProjectID), ctx, projectID)
+}
+
+// ListInstancesPages mocks base method.
+func (m *MockDatastoreInterface) ListInstancesPages(ctx context.Context, query api.InstanceQuery, pageSize int, fn func([]api.InstanceListItem) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstancesPages", ctx, query, pageSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListInstancesPages indicates an expected call of ListInstancesPages.
+func (mr *MockDatastoreInterfaceMockRecorder) ListInstancesPages(ctx, query, pageSize, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstancesPages", reflect.TypeOf((*MockDatastoreInterface)(nil).ListInstancesPages), ctx, query, pageSize, fn)
+}
+
 // ListLogsByInstanceID mocks base method.
 func (m *MockDatastoreInterface) ListLogsByInstanceID(ctx context.Context, instanceID string, limit int, timestamp time.Time) ([]api.Log, error) {
 	m.ctrl.T.Helper()
@@ -729,6 +834,21 @@ func (mr *MockDatastoreInterfaceMockRecorder) ListLogsByInstanceID(ctx, instance
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogsByInstanceID", reflect.TypeOf((*MockDatastoreInterface)(nil).ListLogsByInstanceID), ctx, instanceID, limit, timestamp)
 }
 
+// ListProductTemplates mocks base method.
+func (m *MockDatastoreInterface) ListProductTemplates(ctx context.Context) ([]api.ProductTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductTemplates", ctx)
+	ret0, _ := ret[0].([]api.ProductTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductTemplates indicates an expected call of ListProductTemplates.
+func (mr *MockDatastoreInterfaceMockRecorder) ListProductTemplates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductTemplates", reflect.TypeOf((*MockDatastoreInterface)(nil).ListProductTemplates), ctx)
+}
+
 // ListProducts mocks base method.
 func (m *MockDatastoreInterface) ListProducts(ctx context.Context) ([]api.Product, error) {
 	m.ctrl.T.Helper()
@@ -744,6 +864,21 @@ func (mr *MockDatastoreInterfaceMockRecorder) ListProducts(ctx interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockDatastoreInterface)(nil).ListProducts), ctx)
 }
 
+// ListProjects mocks base method.
+func (m *MockDatastoreInterface) ListProjects(ctx context.Context, accountID string) ([]api.ProjectListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjects", ctx, accountID)
+	ret0, _ := ret[0].([]api.ProjectListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjects indicates an expected call of ListProjects.
+func (mr *MockDatastoreInterfaceMockRecorder) ListProjects(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjects", reflect.TypeOf((*MockDatastoreInterface)(nil).ListProjects), ctx, accountID)
+}
+
 // ListRegions mocks base method.
 func (m *MockDatastoreInterface) ListRegions(ctx context.Context) ([]api.Region, error) {
 	m.ctrl.T.Helper()
@@ -980,9 +1115,11 @@ func (mr *MockFactoryMockRecorder) Init(ctx, cfg, opts interface{}) *gomock.Call
 }
 
 // InitDatastore mocks base method.
-func (m *MockFactory) InitDatastore(cfg config.CLIConfig, opts *config.GlobalOptions) {
+func (m *MockFactory) InitDatastore(cfg config.CLIConfig, opts *config.GlobalOptions) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "InitDatastore", cfg, opts)
+	ret := m.ctrl.Call(m, "InitDatastore", cfg, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // InitDatastore indicates an expected call of InitDatastore.
@@ -1006,9 +1143,11 @@ func (mr *MockFactoryMockRecorder) InitDeploymentClient(ctx, regionAlias interfa
 }
 
 // InitUpgrade mocks base method.
-func (m *MockFactory) InitUpgrade(opts *config.GlobalOptions) {
+func (m *MockFactory) InitUpgrade(opts *config.GlobalOptions) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "InitUpgrade", opts)
+	ret := m.ctrl.Call(m, "InitUpgrade", opts)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // InitUpgrade indicates an expected call of InitUpgrade.
@@ -6,9 +6,11 @@ import (
 
 	"vonage-cloud-runtime-cli/pkg/cmdutil"
 	createCmd "vonage-cloud-runtime-cli/vcr/app/create"
+	describeCmd "vonage-cloud-runtime-cli/vcr/app/describe"
 	generatekeysCmd "vonage-cloud-runtime-cli/vcr/app/generatekeys"
 	listCmd "vonage-cloud-runtime-cli/vcr/app/list"
 	removeCmd "vonage-cloud-runtime-cli/vcr/app/remove"
+	updateCmd "vonage-cloud-runtime-cli/vcr/app/update"
 )
 
 func NewCmdApp(f cmdutil.Factory) *cobra.Command {
@@ -28,6 +30,8 @@ func NewCmdApp(f cmdutil.Factory) *cobra.Command {
 
 			AVAILABLE COMMANDS
 			  create         Create a new Vonage application
+			  describe       Show the capabilities, webhooks and key status of an application
+			  update         Enable or disable capabilities and set webhook URLs
 			  remove (rm)    Remove a Vonage application
 			  list (ls)      List all Vonage applications in your account
 			  generate-keys  Generate new key pairs for an existing application
@@ -47,6 +51,12 @@ func NewCmdApp(f cmdutil.Factory) *cobra.Command {
 			# Generate new keys for an existing application
 			$ vcr app generate-keys --app-id 12345678-1234-1234-1234-123456789abc
 
+			# Show the capabilities and webhooks of an application
+			$ vcr app describe --app-id 12345678-1234-1234-1234-123456789abc
+
+			# Point the voice answer webhook at a deployed instance
+			$ vcr app update --app-id 12345678-1234-1234-1234-123456789abc --answer-url https://my-instance.example.com/answer
+
 			# List all applications
 			$ vcr app list
 
@@ -56,8 +66,10 @@ func NewCmdApp(f cmdutil.Factory) *cobra.Command {
 	}
 
 	cmd.AddCommand(createCmd.NewCmdAppCreate(f))
+	cmd.AddCommand(describeCmd.NewCmdAppDescribe(f))
 	cmd.AddCommand(generatekeysCmd.NewCmdAppGenerateKeys(f))
 	cmd.AddCommand(listCmd.NewCmdAppList(f))
 	cmd.AddCommand(removeCmd.NewCmdAppRemove(f))
+	cmd.AddCommand(updateCmd.NewCmdAppUpdate(f))
 	return cmd
 }
@@ -61,8 +61,8 @@ func NewCmdAppCreate(f cmdutil.Factory) *cobra.Command {
 			$ vcr app create --name my-app --yes
 		`),
 		Args: cobra.MaximumNArgs(0),
-		RunE: func(_ *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 
 			return runCreate(ctx, &opts)
@@ -0,0 +1,87 @@
+package describe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/format"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	AppID string
+}
+
+func NewCmdAppDescribe(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "describe --app-id <application-id>",
+		Short: "Show the details of a Vonage application",
+		Long: heredoc.Doc(`Show the details of a Vonage application.
+
+			This command displays the name of the application, whether its keys have been
+			generated, and for each capability whether it is enabled and which webhook
+			URLs it calls.
+
+			WEBHOOKS
+			  • voice     answer, event   - Called when a call is answered and for call events
+			  • messages  inbound, status - Called for inbound messages and delivery statuses
+			  • rtc       event           - Called for in-app voice/video events
+
+			Use 'vcr app update' to change the capabilities and webhooks.
+		`),
+		Args: cobra.MaximumNArgs(0),
+		Example: heredoc.Doc(`
+			$ vcr app describe --app-id 42066b10-c4ae-48a0-addd-feb2bd615a67
+			ℹ id: 42066b10-c4ae-48a0-addd-feb2bd615a67
+			ℹ name: my-app
+			ℹ keys: generated
+			┌────────────┬──────────┬─────────┬──────────────────────────────────┐
+			│ CAPABILITY │  STATUS  │ WEBHOOK │               URL                │
+			├────────────┼──────────┼─────────┼──────────────────────────────────┤
+			│ voice      │ enabled  │ answer  │ https://example.com/voice/answer │
+			│ voice      │ enabled  │ event   │ https://example.com/voice/event  │
+			│ messages   │ disabled │         │                                  │
+			│ rtc        │ disabled │         │                                  │
+			└────────────┴──────────┴─────────┴──────────────────────────────────┘
+		`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
+			defer cancel()
+
+			return runDescribe(ctx, &opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.AppID, "app-id", "i", "", "The UUID of the Vonage application (required)")
+	_ = cmd.MarkFlagRequired("app-id")
+	return cmd
+}
+
+func runDescribe(ctx context.Context, opts *Options) error {
+	if opts.AppID == "" {
+		return fmt.Errorf("app-id can not be empty")
+	}
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(fmt.Sprintf(" Fetching application %q...", opts.AppID))
+	app, err := opts.DeploymentClient().GetVonageApplication(ctx, opts.AppID)
+	spinner.Stop()
+	if errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("application %q not found, run 'vcr app list' to see your applications", opts.AppID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get application: %w", err)
+	}
+
+	return format.PrintApplication(opts.IOStreams(), app)
+}
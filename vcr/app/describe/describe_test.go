@@ -0,0 +1,141 @@
+package describe
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+func TestAppDescribe(t *testing.T) {
+	type mock struct {
+		DescribeAppID     string
+		DescribeTimes     int
+		DescribeReturnApp api.VonageApplication
+		DescribeReturnErr error
+	}
+	type want struct {
+		errMsg string
+		stdout string
+	}
+
+	tests := []struct {
+		name string
+		cli  string
+		mock mock
+		want want
+	}{
+		{
+			name: "happy-path",
+			cli:  "--app-id=42066b10-c4ae-48a0-addd-feb2bd615a67",
+			mock: mock{
+				DescribeAppID: "42066b10-c4ae-48a0-addd-feb2bd615a67",
+				DescribeTimes: 1,
+				DescribeReturnApp: api.VonageApplication{
+					ID:   "42066b10-c4ae-48a0-addd-feb2bd615a67",
+					Name: "my-app",
+					Capabilities: api.ApplicationCapabilities{
+						Voice: &api.VoiceCapability{AnswerURL: "https://example.com/answer", EventURL: "https://example.com/event"},
+					},
+					Keys: api.ApplicationKeys{PublicKey: "public-key"},
+				},
+			},
+			want: want{
+				stdout: heredoc.Doc(`
+				ℹ id: 42066b10-c4ae-48a0-addd-feb2bd615a67
+				ℹ name: my-app
+				ℹ keys: generated
+				┌────────────┬──────────┬─────────┬────────────────────────────┐
+				│ CAPABILITY │  STATUS  │ WEBHOOK │            URL             │
+				├────────────┼──────────┼─────────┼────────────────────────────┤
+				│ voice      │ enabled  │ answer  │ https://example.com/answer │
+				│ voice      │ enabled  │ event   │ https://example.com/event  │
+				│ messages   │ disabled │         │                            │
+				│ rtc        │ disabled │         │                            │
+				└────────────┴──────────┴─────────┴────────────────────────────┘
+				`),
+			},
+		},
+		{
+			name: "missing-app-id",
+			cli:  "",
+			want: want{
+				errMsg: "required flag(s) \"app-id\" not set",
+			},
+		},
+		{
+			name: "not-found",
+			cli:  "--app-id=unknown",
+			mock: mock{
+				DescribeAppID:     "unknown",
+				DescribeTimes:     1,
+				DescribeReturnErr: api.ErrNotFound,
+			},
+			want: want{
+				errMsg: "application \"unknown\" not found, run 'vcr app list' to see your applications",
+			},
+		},
+		{
+			name: "api-error",
+			cli:  "--app-id=42066b10-c4ae-48a0-addd-feb2bd615a67",
+			mock: mock{
+				DescribeAppID:     "42066b10-c4ae-48a0-addd-feb2bd615a67",
+				DescribeTimes:     1,
+				DescribeReturnErr: errors.New("api error"),
+			},
+			want: want{
+				errMsg: "failed to get application: api error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			deploymentMock := mocks.NewMockDeploymentInterface(ctrl)
+			deploymentMock.EXPECT().GetVonageApplication(gomock.Any(), tt.mock.DescribeAppID).
+				Times(tt.mock.DescribeTimes).
+				Return(tt.mock.DescribeReturnApp, tt.mock.DescribeReturnErr)
+
+			ios, _, stdout, stderr := iostreams.Test()
+
+			argv, err := shlex.Split(tt.cli)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, nil, deploymentMock, nil, nil)
+
+			cmd := NewCmdAppDescribe(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			if _, err := cmd.ExecuteC(); err != nil && tt.want.errMsg != "" {
+				require.Error(t, err, "should throw error")
+				require.Equal(t, tt.want.errMsg, err.Error())
+				return
+			}
+			cmdOut := &testutil.CmdOut{
+				OutBuf: stdout,
+				ErrBuf: stderr,
+			}
+
+			require.NoError(t, err, "should not throw error")
+			require.Equal(t, tt.want.stdout, cmdOut.String())
+		})
+	}
+}
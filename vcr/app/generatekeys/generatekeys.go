@@ -2,18 +2,28 @@ package generatekeys
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/spf13/cobra"
 
+	"vonage-cloud-runtime-cli/pkg/api"
 	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
 )
 
+const privateKeyFilePermission = 0600
+
 type Options struct {
 	cmdutil.Factory
 
-	AppID string
+	AppID          string
+	SavePrivateKey string
+	ToSecret       string
+	SkipPrompts    bool
 }
 
 func NewCmdAppGenerateKeys(f cmdutil.Factory) *cobra.Command {
@@ -35,7 +45,15 @@ func NewCmdAppGenerateKeys(f cmdutil.Factory) *cobra.Command {
 			  • You're troubleshooting authentication issues with VCR
 
 			WARNING: Regenerating keys will invalidate any existing private keys for this
-			application. Any services using the old keys will need to be updated.
+			application. Any services using the old keys will need to be updated. You are
+			asked to confirm unless --yes is set, and --yes is required when the terminal
+			is not interactive.
+
+			KEEPING THE PRIVATE KEY
+			  The new private key is only returned once. To use it outside VCR, keep it with:
+			  • --save-private-key <file>  - Write it to a file readable only by you (0600)
+			  • --to-secret <name>         - Store it as a VCR secret, updating the secret
+			                                 if it already exists
 
 			FINDING YOUR APPLICATION ID
 			  Use 'vcr app list' to see all your applications and their IDs.
@@ -48,9 +66,19 @@ func NewCmdAppGenerateKeys(f cmdutil.Factory) *cobra.Command {
 
 			# Using the short flag
 			$ vcr app generate-keys -i 42066b10-c4ae-48a0-addd-feb2bd615a67
+
+			# Save the new private key to a file
+			$ vcr app generate-keys -i 42066b10-c4ae-48a0-addd-feb2bd615a67 --save-private-key ./private.key
+			! Regenerating keys invalidates the current private key of application "42066b10-c4ae-48a0-addd-feb2bd615a67", services using it will stop authenticating
+			? Are you sure you want to generate new keys for application "42066b10-c4ae-48a0-addd-feb2bd615a67"? Yes
+			✓ Application "42066b10-c4ae-48a0-addd-feb2bd615a67" configured with newly generated keys
+			✓ Private key saved to ./private.key
+
+			# Store the new private key as a VCR secret, without prompting
+			$ vcr app generate-keys -i 42066b10-c4ae-48a0-addd-feb2bd615a67 --to-secret VONAGE_PRIVATE_KEY --yes
 		`),
-		RunE: func(_ *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 
 			return runGenerateKeys(ctx, &opts)
@@ -58,6 +86,9 @@ func NewCmdAppGenerateKeys(f cmdutil.Factory) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&opts.AppID, "app-id", "i", "", "The UUID of the Vonage application (required)")
+	cmd.Flags().StringVarP(&opts.SavePrivateKey, "save-private-key", "", "", "Write the new private key to this file")
+	cmd.Flags().StringVarP(&opts.ToSecret, "to-secret", "", "", "Store the new private key as a VCR secret with this name")
+	cmd.Flags().BoolVarP(&opts.SkipPrompts, "yes", "y", false, "Skip confirmation prompt (use with caution)")
 	_ = cmd.MarkFlagRequired("app-id")
 	return cmd
 }
@@ -69,14 +100,86 @@ func runGenerateKeys(ctx context.Context, opts *Options) error {
 	if opts.AppID == "" {
 		return fmt.Errorf("app-id can not be empty")
 	}
+	// the private key is only returned once, so make sure it can be kept before generating it
+	if err := checkDestinations(opts); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.ErrOut, "%s Regenerating keys invalidates the current private key of application %q, services using it will stop authenticating\n", c.WarningIcon(), opts.AppID)
+	if !opts.SkipPrompts {
+		if !io.CanPrompt() {
+			return fmt.Errorf("the terminal is not interactive, use --yes to confirm generating new keys for application %q", opts.AppID)
+		}
+		if !opts.Survey().AskYesNo(fmt.Sprintf("Are you sure you want to generate new keys for application %q?", opts.AppID)) {
+			fmt.Fprintf(io.ErrOut, "%s Key generation aborted\n", c.WarningIcon())
+			return nil
+		}
+	}
 
 	spinner := cmdutil.DisplaySpinnerMessageWithHandle(fmt.Sprintf(" Generating keys for application %q...", opts.AppID))
-	err := opts.DeploymentClient().GenerateVonageApplicationKeys(ctx, opts.AppID)
+	keys, err := opts.DeploymentClient().GenerateVonageApplicationKeys(ctx, opts.AppID)
 	spinner.Stop()
 	if err != nil {
 		return fmt.Errorf("failed to generate application keys: %w", err)
 	}
+	// the keys were rotated anyway, so the old private key no longer works either
+	if keys.PrivateKey == "" {
+		return fmt.Errorf("no private key returned for application %q, run the command again to generate new keys", opts.AppID)
+	}
 
 	fmt.Fprintf(io.Out, "%s Application %q configured with newly generated keys\n", c.SuccessIcon(), opts.AppID)
+
+	if opts.SavePrivateKey != "" {
+		if err := savePrivateKey(opts.SavePrivateKey, keys.PrivateKey); err != nil {
+			return fmt.Errorf("failed to save private key, run the command again to generate a new one: %w", err)
+		}
+		fmt.Fprintf(io.Out, "%s Private key saved to %s\n", c.SuccessIcon(), opts.SavePrivateKey)
+	}
+	if opts.ToSecret != "" {
+		if err := saveSecret(ctx, opts, config.NewSecret(opts.ToSecret, keys.PrivateKey)); err != nil {
+			return fmt.Errorf("failed to store private key as secret %q, run the command again to generate a new one: %w", opts.ToSecret, err)
+		}
+		fmt.Fprintf(io.Out, "%s Private key stored as secret %q\n", c.SuccessIcon(), opts.ToSecret)
+	}
 	return nil
 }
+
+func checkDestinations(opts *Options) error {
+	if opts.SavePrivateKey != "" {
+		if _, err := os.Stat(opts.SavePrivateKey); err == nil {
+			return fmt.Errorf("file %q already exists, choose another path for the private key", opts.SavePrivateKey)
+		}
+		if info, err := os.Stat(filepath.Dir(opts.SavePrivateKey)); err != nil || !info.IsDir() {
+			return fmt.Errorf("directory %q does not exist", filepath.Dir(opts.SavePrivateKey))
+		}
+	}
+	if opts.ToSecret != "" {
+		if _, err := config.ValidateSecretName(opts.ToSecret); err != nil {
+			return fmt.Errorf("invalid secret name: %w", err)
+		}
+	}
+	return nil
+}
+
+func savePrivateKey(path, privateKey string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, privateKeyFilePermission)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(privateKey); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// saveSecret creates the secret, or updates it when it already exists, e.g. when rotating keys.
+func saveSecret(ctx context.Context, opts *Options, secret config.Secret) error {
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(fmt.Sprintf(" Storing private key as secret %q...", secret.Name))
+	defer spinner.Stop()
+	err := opts.DeploymentClient().CreateSecret(ctx, secret)
+	if errors.Is(err, api.ErrAlreadyExists) {
+		return opts.DeploymentClient().UpdateSecret(ctx, secret)
+	}
+	return err
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/MakeNowJust/heredoc"
@@ -12,19 +14,36 @@ import (
 	"github.com/google/shlex"
 	"github.com/stretchr/testify/require"
 
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/config"
 	"vonage-cloud-runtime-cli/testutil"
 	"vonage-cloud-runtime-cli/testutil/mocks"
 )
 
 func TestAppGenerateKeys(t *testing.T) {
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "private.key")
+	existingPath := filepath.Join(dir, "existing.key")
+	emptyKeyPath := filepath.Join(dir, "empty.key")
+	require.NoError(t, os.WriteFile(existingPath, []byte("old key"), 0600))
+	secret := config.NewSecret("VONAGE_PRIVATE_KEY", "private-key")
+
 	type mock struct {
 		GenerateAppID         string
 		GenerateKeysTimes     int
+		GenerateKeysReturnKey string
 		GenerateKeysReturnErr error
+
+		CreateSecretTimes     int
+		CreateSecretReturnErr error
+		UpdateSecretTimes     int
 	}
 	type want struct {
-		errMsg string
-		stdout string
+		errMsg     string
+		stdout     string
+		privateKey string
+		// noFile must not have been created
+		noFile string
 	}
 
 	tests := []struct {
@@ -35,10 +54,11 @@ func TestAppGenerateKeys(t *testing.T) {
 	}{
 		{
 			name: "happy-path",
-			cli:  "--app-id=42066b10-c4ae-48a0-addd-feb2bd615a67",
+			cli:  "--yes --app-id=42066b10-c4ae-48a0-addd-feb2bd615a67",
 			mock: mock{
 				GenerateAppID:         "42066b10-c4ae-48a0-addd-feb2bd615a67",
 				GenerateKeysTimes:     1,
+				GenerateKeysReturnKey: "private-key",
 				GenerateKeysReturnErr: nil,
 			},
 			want: want{
@@ -47,6 +67,70 @@ func TestAppGenerateKeys(t *testing.T) {
 				`),
 			},
 		},
+		{
+			name: "save-private-key",
+			cli:  "--yes --app-id=42066b10-c4ae-48a0-addd-feb2bd615a67 --save-private-key " + keyPath,
+			mock: mock{
+				GenerateAppID:         "42066b10-c4ae-48a0-addd-feb2bd615a67",
+				GenerateKeysTimes:     1,
+				GenerateKeysReturnKey: "private-key",
+			},
+			want: want{
+				stdout: heredoc.Docf(`
+				✓ Application "42066b10-c4ae-48a0-addd-feb2bd615a67" configured with newly generated keys
+				✓ Private key saved to %s
+				`, keyPath),
+				privateKey: "private-key",
+			},
+		},
+		{
+			name: "private-key-file-exists",
+			cli:  "--yes --app-id=42066b10-c4ae-48a0-addd-feb2bd615a67 --save-private-key " + existingPath,
+			want: want{
+				errMsg: "file \"" + existingPath + "\" already exists, choose another path for the private key",
+			},
+		},
+		{
+			name: "to-new-secret",
+			cli:  "--yes --app-id=42066b10-c4ae-48a0-addd-feb2bd615a67 --to-secret VONAGE_PRIVATE_KEY",
+			mock: mock{
+				GenerateAppID:         "42066b10-c4ae-48a0-addd-feb2bd615a67",
+				GenerateKeysTimes:     1,
+				GenerateKeysReturnKey: "private-key",
+				CreateSecretTimes:     1,
+			},
+			want: want{
+				stdout: heredoc.Doc(`
+				✓ Application "42066b10-c4ae-48a0-addd-feb2bd615a67" configured with newly generated keys
+				✓ Private key stored as secret "VONAGE_PRIVATE_KEY"
+				`),
+			},
+		},
+		{
+			name: "to-existing-secret",
+			cli:  "--yes --app-id=42066b10-c4ae-48a0-addd-feb2bd615a67 --to-secret VONAGE_PRIVATE_KEY",
+			mock: mock{
+				GenerateAppID:         "42066b10-c4ae-48a0-addd-feb2bd615a67",
+				GenerateKeysTimes:     1,
+				GenerateKeysReturnKey: "private-key",
+				CreateSecretTimes:     1,
+				CreateSecretReturnErr: api.ErrAlreadyExists,
+				UpdateSecretTimes:     1,
+			},
+			want: want{
+				stdout: heredoc.Doc(`
+				✓ Application "42066b10-c4ae-48a0-addd-feb2bd615a67" configured with newly generated keys
+				✓ Private key stored as secret "VONAGE_PRIVATE_KEY"
+				`),
+			},
+		},
+		{
+			name: "invalid-secret-name",
+			cli:  "--yes --app-id=42066b10-c4ae-48a0-addd-feb2bd615a67 --to-secret 1-key",
+			want: want{
+				errMsg: "invalid secret name: must follow the regex format \"^[a-zA-Z_]+[a-zA-Z0-9_]*$\"",
+			},
+		},
 		{
 			name: "missing-app-id",
 			cli:  "",
@@ -60,7 +144,7 @@ func TestAppGenerateKeys(t *testing.T) {
 		},
 		{
 			name: "generate-keys-api-error",
-			cli:  "--app-id=42066b10-c4ae-48a0-addd-feb2bd615a67",
+			cli:  "--yes --app-id=42066b10-c4ae-48a0-addd-feb2bd615a67",
 			mock: mock{
 				GenerateAppID:         "42066b10-c4ae-48a0-addd-feb2bd615a67",
 				GenerateKeysTimes:     1,
@@ -70,6 +154,25 @@ func TestAppGenerateKeys(t *testing.T) {
 				errMsg: "failed to generate application keys: api error",
 			},
 		},
+		{
+			name: "empty-private-key",
+			cli:  "--yes --app-id=42066b10-c4ae-48a0-addd-feb2bd615a67 --save-private-key " + emptyKeyPath,
+			mock: mock{
+				GenerateAppID:     "42066b10-c4ae-48a0-addd-feb2bd615a67",
+				GenerateKeysTimes: 1,
+			},
+			want: want{
+				errMsg: "no private key returned for application \"42066b10-c4ae-48a0-addd-feb2bd615a67\", run the command again to generate new keys",
+				noFile: emptyKeyPath,
+			},
+		},
+		{
+			name: "not-interactive-without-yes",
+			cli:  "--app-id=42066b10-c4ae-48a0-addd-feb2bd615a67",
+			want: want{
+				errMsg: "the terminal is not interactive, use --yes to confirm generating new keys for application \"42066b10-c4ae-48a0-addd-feb2bd615a67\"",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -80,7 +183,13 @@ func TestAppGenerateKeys(t *testing.T) {
 			deploymentMock := mocks.NewMockDeploymentInterface(ctrl)
 			deploymentMock.EXPECT().GenerateVonageApplicationKeys(gomock.Any(), tt.mock.GenerateAppID).
 				Times(tt.mock.GenerateKeysTimes).
-				Return(tt.mock.GenerateKeysReturnErr)
+				Return(api.GenerateVonageApplicationKeysOutput{PrivateKey: tt.mock.GenerateKeysReturnKey}, tt.mock.GenerateKeysReturnErr)
+			deploymentMock.EXPECT().CreateSecret(gomock.Any(), secret).
+				Times(tt.mock.CreateSecretTimes).
+				Return(tt.mock.CreateSecretReturnErr)
+			deploymentMock.EXPECT().UpdateSecret(gomock.Any(), secret).
+				Times(tt.mock.UpdateSecretTimes).
+				Return(nil)
 
 			ios, _, stdout, stderr := iostreams.Test()
 
@@ -97,9 +206,13 @@ func TestAppGenerateKeys(t *testing.T) {
 			cmd.SetOut(io.Discard)
 			cmd.SetErr(io.Discard)
 
-			if _, err := cmd.ExecuteC(); err != nil && tt.want.errMsg != "" {
+			_, err = cmd.ExecuteC()
+			if tt.want.errMsg != "" {
 				require.Error(t, err, "should throw error")
 				require.Equal(t, tt.want.errMsg, err.Error())
+				if tt.want.noFile != "" {
+					require.NoFileExists(t, tt.want.noFile)
+				}
 				return
 			}
 			cmdOut := &testutil.CmdOut{
@@ -109,7 +222,16 @@ func TestAppGenerateKeys(t *testing.T) {
 
 			require.NoError(t, err, "should not throw error")
 			require.Equal(t, tt.want.stdout, cmdOut.String())
+			require.Contains(t, cmdOut.Stderr(), "Regenerating keys invalidates the current private key")
 
+			if tt.want.privateKey != "" {
+				data, err := os.ReadFile(keyPath)
+				require.NoError(t, err)
+				require.Equal(t, tt.want.privateKey, string(data))
+				info, err := os.Stat(keyPath)
+				require.NoError(t, err)
+				require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+			}
 		})
 	}
 }
@@ -61,8 +61,8 @@ func NewCmdAppList(f cmdutil.Factory) *cobra.Command {
 		Aliases: []string{"ls"},
 		Args:    cobra.MaximumNArgs(0),
 
-		RunE: func(_ *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 
 			return runList(ctx, &opts)
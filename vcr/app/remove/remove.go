@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/spf13/cobra"
@@ -17,6 +18,8 @@ type Options struct {
 
 	ApplicationID string
 	SkipPrompts   bool
+	Force         bool
+	Cascade       bool
 }
 
 func NewCmdAppRemove(f cmdutil.Factory) *cobra.Command {
@@ -34,8 +37,14 @@ func NewCmdAppRemove(f cmdutil.Factory) *cobra.Command {
 			credentials. Any VCR instances linked to this application will lose their
 			authentication credentials on next restart.
 
-			WARNING: This action is irreversible. Make sure no running instances
-			depend on this application before removing it.
+			DEPENDENT INSTANCES
+			  Before removing the application, the command looks for deployed instances
+			  that use it and lists them. If there are any, the removal is refused unless:
+			  • --cascade is set, to remove those instances first, which also needs --yes
+			    when the terminal is not interactive
+			  • --force is set, to remove the application and leave the instances broken
+
+			WARNING: This action is irreversible.
 		`),
 		Example: heredoc.Doc(`
 			# Remove an application (will prompt for confirmation)
@@ -46,12 +55,25 @@ func NewCmdAppRemove(f cmdutil.Factory) *cobra.Command {
 
 			# Using the short alias
 			$ vcr app rm 12345678-1234-1234-1234-123456789abc --yes
+
+			# Remove the application and the instances that use it
+			$ vcr app remove 12345678-1234-1234-1234-123456789abc --cascade
+			! Application "12345678-1234-1234-1234-123456789abc" is used by 2 instance(s):
+			  • neru-4f2ff535-my-app-dev (id=a1b2c3d4-...)
+			  • neru-4f2ff535-my-app-prod (id=e5f6a7b8-...)
+			? Are you sure you want to remove application "12345678-1234-1234-1234-123456789abc" and its 2 instance(s)? Yes
+			✓ Instance "neru-4f2ff535-my-app-dev" removed (1/2)
+			✓ Instance "neru-4f2ff535-my-app-prod" removed (2/2)
+			✓ Application "12345678-1234-1234-1234-123456789abc" successfully removed
 		`),
 		Args: cobra.ExactArgs(1),
-		RunE: func(_ *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.ApplicationID = args[0]
+			if err := cmdutil.MutuallyExclusive("specify only one of --force or --cascade", opts.Force, opts.Cascade); err != nil {
+				return err
+			}
 
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 
 			return runRemove(ctx, &opts)
@@ -59,6 +81,8 @@ func NewCmdAppRemove(f cmdutil.Factory) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&opts.SkipPrompts, "yes", "y", false, "Skip confirmation prompt (use with caution)")
+	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Remove the application even if instances still use it")
+	cmd.Flags().BoolVarP(&opts.Cascade, "cascade", "", false, "Remove the instances that use the application first")
 
 	return cmd
 }
@@ -67,15 +91,46 @@ func runRemove(ctx context.Context, opts *Options) error {
 	io := opts.IOStreams()
 	c := io.ColorScheme()
 
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Checking for instances using the application...")
+	dependents, err := getDependentInstances(ctx, opts)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list instances: %w", err)
+	}
+
+	question := fmt.Sprintf("Are you sure you want to remove application %q?", opts.ApplicationID)
+	if len(dependents) > 0 {
+		fmt.Fprintf(io.ErrOut, "%s Application %q is used by %d instance(s):\n", c.WarningIcon(), opts.ApplicationID, len(dependents))
+		for _, inst := range dependents {
+			fmt.Fprintf(io.ErrOut, "  • %s (id=%s)\n", inst.ServiceName, inst.ID)
+		}
+		if !opts.Force && !opts.Cascade {
+			return fmt.Errorf("application %q is still used by %d instance(s), use --cascade to remove them first or --force to remove the application anyway", opts.ApplicationID, len(dependents))
+		}
+		if opts.Cascade {
+			// removing instances is never done without a confirmation, unlike removing the application alone
+			if !io.CanPrompt() && !opts.SkipPrompts {
+				return fmt.Errorf("the terminal is not interactive, use --yes to confirm removing application %q and its %d instance(s)", opts.ApplicationID, len(dependents))
+			}
+			question = fmt.Sprintf("Are you sure you want to remove application %q and its %d instance(s)?", opts.ApplicationID, len(dependents))
+		}
+	}
+
 	if io.CanPrompt() && !opts.SkipPrompts {
-		if !opts.Survey().AskYesNo(fmt.Sprintf("Are you sure you want to remove application %q?", opts.ApplicationID)) {
+		if !opts.Survey().AskYesNo(question) {
 			fmt.Fprintf(io.ErrOut, "%s Application removal aborted\n", c.WarningIcon())
 			return nil
 		}
 	}
 
-	spinner := cmdutil.DisplaySpinnerMessageWithHandle(fmt.Sprintf(" Removing application %q...", opts.ApplicationID))
-	err := opts.DeploymentClient().DeleteVonageApplication(ctx, opts.ApplicationID)
+	if opts.Cascade {
+		if err := removeInstances(ctx, opts, dependents); err != nil {
+			return err
+		}
+	}
+
+	spinner = cmdutil.DisplaySpinnerMessageWithHandle(fmt.Sprintf(" Removing application %q...", opts.ApplicationID))
+	err = opts.DeploymentClient().DeleteVonageApplication(ctx, opts.ApplicationID)
 	spinner.Stop()
 	if err != nil {
 		if errors.Is(err, api.ErrNotFound) {
@@ -88,3 +143,54 @@ func runRemove(ctx context.Context, opts *Options) error {
 
 	return nil
 }
+
+// getDependentInstances returns the deployed instances that use the application.
+func getDependentInstances(ctx context.Context, opts *Options) ([]api.InstanceListItem, error) {
+	instances, err := opts.Datastore().ListInstances(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	var dependents []api.InstanceListItem
+	for _, inst := range instances {
+		if inst.APIApplicationID == opts.ApplicationID {
+			dependents = append(dependents, inst)
+		}
+	}
+	return dependents, nil
+}
+
+// removeInstances removes the instances one by one. If it fails or is interrupted partway, it reports the
+// instances that were removed and those that are left, so that the user can run the command again.
+func removeInstances(ctx context.Context, opts *Options, instances []api.InstanceListItem) (err error) {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	var removed []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		remaining := make([]string, 0, len(instances)-len(removed))
+		for _, inst := range instances[len(removed):] {
+			remaining = append(remaining, inst.ID)
+		}
+		status := "failed"
+		if cmdutil.Interrupted(ctx) {
+			status = "interrupted"
+		}
+		fmt.Fprintf(io.ErrOut, "%s Application removal %s, application %q is kept: removed instances=[%s], remaining instances=[%s]\n",
+			c.WarningIcon(), status, opts.ApplicationID, strings.Join(removed, ", "), strings.Join(remaining, ", "))
+	}()
+
+	for i, inst := range instances {
+		spinner := cmdutil.DisplaySpinnerMessageWithHandle(fmt.Sprintf(" Removing instance %q (%d/%d)...", inst.ServiceName, i+1, len(instances)))
+		err := opts.DeploymentClient().DeleteInstance(ctx, inst.ID)
+		spinner.Stop()
+		if err != nil {
+			return fmt.Errorf("failed to remove instance %q, the application was not removed: %w", inst.ServiceName, err)
+		}
+		fmt.Fprintf(io.Out, "%s Instance %q removed (%d/%d)\n", c.SuccessIcon(), inst.ServiceName, i+1, len(instances))
+		removed = append(removed, inst.ID)
+	}
+	return nil
+}
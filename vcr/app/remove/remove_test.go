@@ -2,6 +2,7 @@ package remove
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
 	"vonage-cloud-runtime-cli/testutil"
 	"vonage-cloud-runtime-cli/testutil/mocks"
 )
@@ -19,11 +21,25 @@ import (
 func TestAppRemove(t *testing.T) {
 	const appID = "12345678-1234-1234-1234-123456789abc"
 
+	dependents := []api.InstanceListItem{
+		{ID: "instance-1", APIApplicationID: appID, ServiceName: "neru-my-app-dev"},
+		{ID: "instance-2", APIApplicationID: appID, ServiceName: "neru-my-app-prod"},
+	}
+	instances := append([]api.InstanceListItem{{ID: "instance-3", APIApplicationID: "other-app", ServiceName: "neru-other"}}, dependents...)
+
 	type mock struct {
 		DeleteTimes     int
 		DeleteReturnErr error
 		AskYesNoTimes   int
 		AskYesNoReturn  bool
+
+		ListInstancesTimes     int
+		ListInstancesReturn    []api.InstanceListItem
+		ListInstancesReturnErr error
+		DeleteInstanceTimes    int
+		DeleteInstanceErr      error
+		// DeleteInstanceInterruptAt interrupts the command during the n-th instance removal
+		DeleteInstanceInterruptAt int
 	}
 	type want struct {
 		errMsg string
@@ -32,18 +48,20 @@ func TestAppRemove(t *testing.T) {
 	}
 
 	tests := []struct {
-		name string
-		cli  string
-		mock mock
-		want want
+		name   string
+		cli    string
+		notTTY bool
+		mock   mock
+		want   want
 	}{
 		{
 			name: "happy-path-with-yes-flag",
 			cli:  appID + " --yes",
 			mock: mock{
-				DeleteTimes:     1,
-				DeleteReturnErr: nil,
-				AskYesNoTimes:   0,
+				ListInstancesTimes: 1,
+				DeleteTimes:        1,
+				DeleteReturnErr:    nil,
+				AskYesNoTimes:      0,
 			},
 			want: want{
 				stdout: "✓ Application \"" + appID + "\" successfully removed\n",
@@ -53,10 +71,11 @@ func TestAppRemove(t *testing.T) {
 			name: "happy-path-confirm-prompt",
 			cli:  appID,
 			mock: mock{
-				DeleteTimes:     1,
-				DeleteReturnErr: nil,
-				AskYesNoTimes:   1,
-				AskYesNoReturn:  true,
+				ListInstancesTimes: 1,
+				DeleteTimes:        1,
+				DeleteReturnErr:    nil,
+				AskYesNoTimes:      1,
+				AskYesNoReturn:     true,
 			},
 			want: want{
 				stdout: "✓ Application \"" + appID + "\" successfully removed\n",
@@ -66,9 +85,10 @@ func TestAppRemove(t *testing.T) {
 			name: "user-aborts-prompt",
 			cli:  appID,
 			mock: mock{
-				DeleteTimes:     0,
-				AskYesNoTimes:   1,
-				AskYesNoReturn:  false,
+				ListInstancesTimes: 1,
+				DeleteTimes:        0,
+				AskYesNoTimes:      1,
+				AskYesNoReturn:     false,
 			},
 			want: want{
 				stderr: "! Application removal aborted\n",
@@ -89,8 +109,9 @@ func TestAppRemove(t *testing.T) {
 			name: "not-found",
 			cli:  appID + " --yes",
 			mock: mock{
-				DeleteTimes:     1,
-				DeleteReturnErr: api.ErrNotFound,
+				ListInstancesTimes: 1,
+				DeleteTimes:        1,
+				DeleteReturnErr:    api.ErrNotFound,
 			},
 			want: want{
 				errMsg: "application \"" + appID + "\" could not be found or may have already been deleted",
@@ -100,17 +121,134 @@ func TestAppRemove(t *testing.T) {
 			name: "api-error",
 			cli:  appID + " --yes",
 			mock: mock{
-				DeleteTimes:     1,
-				DeleteReturnErr: errors.New("internal server error"),
+				ListInstancesTimes: 1,
+				DeleteTimes:        1,
+				DeleteReturnErr:    errors.New("internal server error"),
 			},
 			want: want{
 				errMsg: "failed to remove application: internal server error",
 			},
 		},
+		{
+			name: "refuses-with-dependent-instances",
+			cli:  appID + " --yes",
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+			},
+			want: want{
+				errMsg: "application \"" + appID + "\" is still used by 2 instance(s), use --cascade to remove them first or --force to remove the application anyway",
+			},
+		},
+		{
+			name: "force-with-dependent-instances",
+			cli:  appID + " --yes --force",
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+				DeleteTimes:         1,
+			},
+			want: want{
+				stdout: "✓ Application \"" + appID + "\" successfully removed\n",
+			},
+		},
+		{
+			name: "cascade-removes-instances-first",
+			cli:  appID + " --cascade",
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+				AskYesNoTimes:       1,
+				AskYesNoReturn:      true,
+				DeleteInstanceTimes: 2,
+				DeleteTimes:         1,
+			},
+			want: want{
+				stdout: "✓ Instance \"neru-my-app-dev\" removed (1/2)\n" +
+					"✓ Instance \"neru-my-app-prod\" removed (2/2)\n" +
+					"✓ Application \"" + appID + "\" successfully removed\n",
+			},
+		},
+		{
+			name:   "cascade-not-interactive-without-yes",
+			cli:    appID + " --cascade",
+			notTTY: true,
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+			},
+			want: want{
+				errMsg: "the terminal is not interactive, use --yes to confirm removing application \"" + appID + "\" and its 2 instance(s)",
+			},
+		},
+		{
+			name:   "cascade-not-interactive-with-yes",
+			cli:    appID + " --cascade --yes",
+			notTTY: true,
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+				DeleteInstanceTimes: 2,
+				DeleteTimes:         1,
+			},
+			want: want{
+				stdout: "✓ Instance \"neru-my-app-dev\" removed (1/2)\n" +
+					"✓ Instance \"neru-my-app-prod\" removed (2/2)\n" +
+					"✓ Application \"" + appID + "\" successfully removed\n",
+			},
+		},
+		{
+			name: "cascade-instance-error",
+			cli:  appID + " --yes --cascade",
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+				DeleteInstanceTimes: 1,
+				DeleteInstanceErr:   errors.New("api error"),
+			},
+			want: want{
+				errMsg: "failed to remove instance \"neru-my-app-dev\", the application was not removed: api error",
+				stderr: "! Application removal failed, application \"" + appID + "\" is kept: removed instances=[], remaining instances=[instance-1, instance-2]\n",
+			},
+		},
+		{
+			name: "cascade-interrupted",
+			cli:  appID + " --yes --cascade",
+			mock: mock{
+				ListInstancesTimes:        1,
+				ListInstancesReturn:       instances,
+				DeleteInstanceTimes:       2,
+				DeleteInstanceInterruptAt: 2,
+			},
+			want: want{
+				errMsg: "failed to remove instance \"neru-my-app-prod\", the application was not removed: context canceled",
+				stderr: "! Application removal interrupted, application \"" + appID + "\" is kept: removed instances=[instance-1], remaining instances=[instance-2]\n",
+			},
+		},
+		{
+			name: "force-and-cascade",
+			cli:  appID + " --force --cascade",
+			want: want{
+				errMsg: "specify only one of --force or --cascade",
+			},
+		},
+		{
+			name: "list-instances-error",
+			cli:  appID + " --yes",
+			mock: mock{
+				ListInstancesTimes:     1,
+				ListInstancesReturnErr: errors.New("datastore error"),
+			},
+			want: want{
+				errMsg: "failed to list instances: datastore error",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancelCause(t.Context())
+			defer cancel(nil)
 			ctrl := gomock.NewController(t)
 
 			deploymentMock := mocks.NewMockDeploymentInterface(ctrl)
@@ -121,6 +259,25 @@ func TestAppRemove(t *testing.T) {
 					Return(tt.mock.DeleteReturnErr)
 			}
 
+			deleteInstanceCalls := 0
+			deploymentMock.EXPECT().
+				DeleteInstance(gomock.Any(), gomock.Any()).
+				Times(tt.mock.DeleteInstanceTimes).
+				DoAndReturn(func(context.Context, string) error {
+					deleteInstanceCalls++
+					if deleteInstanceCalls == tt.mock.DeleteInstanceInterruptAt {
+						cancel(cmdutil.ErrInterrupted)
+						return context.Canceled
+					}
+					return tt.mock.DeleteInstanceErr
+				})
+
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			datastoreMock.EXPECT().
+				ListInstances(gomock.Any(), "").
+				Times(tt.mock.ListInstancesTimes).
+				Return(tt.mock.ListInstancesReturn, tt.mock.ListInstancesReturnErr)
+
 			surveyMock := mocks.NewMockSurveyInterface(ctrl)
 			if tt.mock.AskYesNoTimes > 0 {
 				surveyMock.EXPECT().
@@ -130,15 +287,15 @@ func TestAppRemove(t *testing.T) {
 			}
 
 			ios, _, stdout, stderr := iostreams.Test()
-			ios.SetStdinTTY(true)
-			ios.SetStdoutTTY(true)
+			ios.SetStdinTTY(!tt.notTTY)
+			ios.SetStdoutTTY(!tt.notTTY)
 
 			argv, err := shlex.Split(tt.cli)
 			if err != nil {
 				t.Fatal(err)
 			}
 
-			f := testutil.DefaultFactoryMock(t, ios, nil, nil, nil, deploymentMock, surveyMock, nil)
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, datastoreMock, deploymentMock, surveyMock, nil)
 
 			cmd := NewCmdAppRemove(f)
 			cmd.SetArgs(argv)
@@ -146,10 +303,11 @@ func TestAppRemove(t *testing.T) {
 			cmd.SetOut(io.Discard)
 			cmd.SetErr(io.Discard)
 
-			_, cmdErr := cmd.ExecuteC()
+			_, cmdErr := cmd.ExecuteContextC(ctx)
 			if cmdErr != nil && tt.want.errMsg != "" {
 				require.Error(t, cmdErr, "should throw error")
 				require.Equal(t, tt.want.errMsg, cmdErr.Error())
+				require.Contains(t, stderr.String(), tt.want.stderr)
 				return
 			}
 
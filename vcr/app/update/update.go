@@ -0,0 +1,217 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/format"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	AppID          string
+	EnableRTC      bool
+	EnableVoice    bool
+	EnableMessages bool
+	AnswerURL      string
+	EventURL       string
+	InboundURL     string
+	StatusURL      string
+	RTCEventURL    string
+
+	input api.UpdateVonageApplicationInput
+}
+
+func NewCmdAppUpdate(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "update --app-id <application-id>",
+		Short: "Update the capabilities and webhooks of a Vonage application",
+		Long: heredoc.Doc(`Update the capabilities and webhooks of a Vonage application.
+
+			Only the capabilities and webhooks given as flags are changed, everything else
+			is left as it is.
+
+			CAPABILITIES
+			  Enable a capability with its flag, e.g. --voice, and disable it by setting the
+			  flag to false, e.g. --voice=false.
+
+			WEBHOOKS
+			  • Voice     --answer-url, --event-url
+			  • Messages  --inbound-url, --status-url
+			  • RTC       --rtc-event-url
+
+			  Setting a webhook enables its capability. Webhooks must be http(s) URLs, for
+			  example the host URL of an instance printed by 'vcr deploy'.
+
+			Use 'vcr app describe' to see the current capabilities and webhooks.
+		`),
+		Args: cobra.MaximumNArgs(0),
+		Example: heredoc.Doc(`
+			# Point the voice webhooks at a deployed instance
+			$ vcr app update --app-id 42066b10-c4ae-48a0-addd-feb2bd615a67 \
+			    --answer-url https://neru-4f2ff535-my-app-dev.euw1.runtime.vonage.cloud/voice/answer \
+			    --event-url https://neru-4f2ff535-my-app-dev.euw1.runtime.vonage.cloud/voice/event
+			✓ Application "42066b10-c4ae-48a0-addd-feb2bd615a67" updated
+			ℹ id: 42066b10-c4ae-48a0-addd-feb2bd615a67
+			...
+
+			# Enable Messages and disable RTC
+			$ vcr app update -i 42066b10-c4ae-48a0-addd-feb2bd615a67 --messages --rtc=false
+		`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
+			defer cancel()
+
+			if cmd.Flags().Changed("voice") {
+				opts.input.EnableVoice = &opts.EnableVoice
+			}
+			if cmd.Flags().Changed("messages") {
+				opts.input.EnableMessages = &opts.EnableMessages
+			}
+			if cmd.Flags().Changed("rtc") {
+				opts.input.EnableRTC = &opts.EnableRTC
+			}
+			if err := validateWebhooks(&opts); err != nil {
+				return err
+			}
+
+			return runUpdate(ctx, &opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.AppID, "app-id", "i", "", "The UUID of the Vonage application (required)")
+	cmd.Flags().BoolVarP(&opts.EnableRTC, "rtc", "r", false, "Enable or disable (--rtc=false) the RTC capability")
+	cmd.Flags().BoolVarP(&opts.EnableVoice, "voice", "v", false, "Enable or disable (--voice=false) the Voice API capability")
+	cmd.Flags().BoolVarP(&opts.EnableMessages, "messages", "m", false, "Enable or disable (--messages=false) the Messages API capability")
+	cmd.Flags().StringVarP(&opts.AnswerURL, "answer-url", "", "", "Voice webhook called when a call is answered")
+	cmd.Flags().StringVarP(&opts.EventURL, "event-url", "", "", "Voice webhook called for call events")
+	cmd.Flags().StringVarP(&opts.InboundURL, "inbound-url", "", "", "Messages webhook called for inbound messages")
+	cmd.Flags().StringVarP(&opts.StatusURL, "status-url", "", "", "Messages webhook called for message statuses")
+	cmd.Flags().StringVarP(&opts.RTCEventURL, "rtc-event-url", "", "", "RTC webhook called for in-app voice/video events")
+
+	_ = cmd.MarkFlagRequired("app-id")
+	return cmd
+}
+
+// validateWebhooks checks the webhook flags and copies them to the update input.
+func validateWebhooks(opts *Options) error {
+	webhooks := []struct {
+		flag    string
+		value   string
+		enabled *bool
+		target  *string
+	}{
+		{"answer-url", opts.AnswerURL, opts.input.EnableVoice, &opts.input.AnswerURL},
+		{"event-url", opts.EventURL, opts.input.EnableVoice, &opts.input.EventURL},
+		{"inbound-url", opts.InboundURL, opts.input.EnableMessages, &opts.input.InboundURL},
+		{"status-url", opts.StatusURL, opts.input.EnableMessages, &opts.input.StatusURL},
+		{"rtc-event-url", opts.RTCEventURL, opts.input.EnableRTC, &opts.input.RTCEventURL},
+	}
+	for _, w := range webhooks {
+		if w.value == "" {
+			continue
+		}
+		if w.enabled != nil && !*w.enabled {
+			return cmdutil.FlagErrorf("--%s cannot be set when its capability is disabled", w.flag)
+		}
+		u, err := url.Parse(w.value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return cmdutil.FlagErrorf("invalid value for --%s: %q is not an http(s) URL", w.flag, w.value)
+		}
+		*w.target = w.value
+	}
+	if opts.input == (api.UpdateVonageApplicationInput{}) {
+		return cmdutil.FlagErrorf("nothing to update, set at least one capability or webhook flag")
+	}
+	return nil
+}
+
+func runUpdate(ctx context.Context, opts *Options) error {
+	io := opts.IOStreams()
+	c := opts.IOStreams().ColorScheme()
+
+	if opts.AppID == "" {
+		return fmt.Errorf("app-id can not be empty")
+	}
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(fmt.Sprintf(" Updating application %q...", opts.AppID))
+	app, err := opts.DeploymentClient().UpdateVonageApplication(ctx, opts.AppID, opts.input)
+	spinner.Stop()
+	if errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("application %q not found, run 'vcr app list' to see your applications", opts.AppID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update application: %w", err)
+	}
+
+	// the application returned by the update is checked against the changes, so that a change the
+	// platform ignored is reported instead of printed as done
+	if missing := unappliedChanges(opts.input, app); len(missing) > 0 {
+		return fmt.Errorf("application %q was updated, but the platform did not apply: %s, run 'vcr app describe --app-id %s' to check it", opts.AppID, strings.Join(missing, ", "), opts.AppID)
+	}
+
+	fmt.Fprintf(io.Out, "%s Application %q updated\n", c.SuccessIcon(), opts.AppID)
+	return format.PrintApplication(io, app)
+}
+
+// unappliedChanges returns the changes of input that the updated application doesn't show.
+func unappliedChanges(input api.UpdateVonageApplicationInput, app api.VonageApplication) []string {
+	var missing []string
+	capabilities := []struct {
+		name    string
+		enable  *bool
+		enabled bool
+	}{
+		{"voice", input.EnableVoice, app.Capabilities.Voice != nil},
+		{"messages", input.EnableMessages, app.Capabilities.Messages != nil},
+		{"rtc", input.EnableRTC, app.Capabilities.RTC != nil},
+	}
+	for _, c := range capabilities {
+		if c.enable != nil && *c.enable != c.enabled {
+			missing = append(missing, fmt.Sprintf("--%s=%t", c.name, *c.enable))
+		}
+	}
+
+	var voice api.VoiceCapability
+	if app.Capabilities.Voice != nil {
+		voice = *app.Capabilities.Voice
+	}
+	var messages api.MessagesCapability
+	if app.Capabilities.Messages != nil {
+		messages = *app.Capabilities.Messages
+	}
+	var rtc api.RTCCapability
+	if app.Capabilities.RTC != nil {
+		rtc = *app.Capabilities.RTC
+	}
+	webhooks := []struct {
+		flag   string
+		value  string
+		actual string
+	}{
+		{"answer-url", input.AnswerURL, voice.AnswerURL},
+		{"event-url", input.EventURL, voice.EventURL},
+		{"inbound-url", input.InboundURL, messages.InboundURL},
+		{"status-url", input.StatusURL, messages.StatusURL},
+		{"rtc-event-url", input.RTCEventURL, rtc.EventURL},
+	}
+	for _, w := range webhooks {
+		if w.value != "" && w.value != w.actual {
+			missing = append(missing, "--"+w.flag)
+		}
+	}
+	return missing
+}
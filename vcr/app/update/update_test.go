@@ -0,0 +1,177 @@
+package update
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+func TestAppUpdate(t *testing.T) {
+	enable, disable := true, false
+	app := api.VonageApplication{
+		ID:   "42066b10-c4ae-48a0-addd-feb2bd615a67",
+		Name: "my-app",
+		Capabilities: api.ApplicationCapabilities{
+			Voice: &api.VoiceCapability{AnswerURL: "https://example.com/answer"},
+		},
+	}
+
+	type mock struct {
+		UpdateAppID     string
+		UpdateInput     api.UpdateVonageApplicationInput
+		UpdateTimes     int
+		UpdateReturnApp *api.VonageApplication
+		UpdateReturnErr error
+	}
+	type want struct {
+		errMsg string
+		stdout string
+	}
+
+	tests := []struct {
+		name string
+		cli  string
+		mock mock
+		want want
+	}{
+		{
+			name: "happy-path-webhook",
+			cli:  "--app-id=42066b10-c4ae-48a0-addd-feb2bd615a67 --answer-url https://example.com/answer",
+			mock: mock{
+				UpdateAppID: "42066b10-c4ae-48a0-addd-feb2bd615a67",
+				UpdateInput: api.UpdateVonageApplicationInput{AnswerURL: "https://example.com/answer"},
+				UpdateTimes: 1,
+			},
+			want: want{
+				stdout: "✓ Application \"42066b10-c4ae-48a0-addd-feb2bd615a67\" updated\nℹ id: 42066b10-c4ae-48a0-addd-feb2bd615a67\n",
+			},
+		},
+		{
+			name: "happy-path-capabilities",
+			cli:  "-i 42066b10-c4ae-48a0-addd-feb2bd615a67 --voice --messages=false",
+			mock: mock{
+				UpdateAppID: "42066b10-c4ae-48a0-addd-feb2bd615a67",
+				UpdateInput: api.UpdateVonageApplicationInput{EnableVoice: &enable, EnableMessages: &disable},
+				UpdateTimes: 1,
+			},
+			want: want{
+				stdout: "✓ Application \"42066b10-c4ae-48a0-addd-feb2bd615a67\" updated\n",
+			},
+		},
+		{
+			name: "changes-not-applied",
+			cli:  "-i 42066b10-c4ae-48a0-addd-feb2bd615a67 --rtc --status-url https://example.com/status",
+			mock: mock{
+				UpdateAppID:     "42066b10-c4ae-48a0-addd-feb2bd615a67",
+				UpdateInput:     api.UpdateVonageApplicationInput{EnableRTC: &enable, StatusURL: "https://example.com/status"},
+				UpdateTimes:     1,
+				UpdateReturnApp: &api.VonageApplication{ID: "42066b10-c4ae-48a0-addd-feb2bd615a67", Capabilities: api.ApplicationCapabilities{Messages: &api.MessagesCapability{}}},
+			},
+			want: want{
+				errMsg: "application \"42066b10-c4ae-48a0-addd-feb2bd615a67\" was updated, but the platform did not apply: --rtc=true, --status-url, run 'vcr app describe --app-id 42066b10-c4ae-48a0-addd-feb2bd615a67' to check it",
+			},
+		},
+		{
+			name: "nothing-to-update",
+			cli:  "--app-id=42066b10-c4ae-48a0-addd-feb2bd615a67",
+			want: want{
+				errMsg: "nothing to update, set at least one capability or webhook flag",
+			},
+		},
+		{
+			name: "webhook-of-disabled-capability",
+			cli:  "--app-id=42066b10-c4ae-48a0-addd-feb2bd615a67 --messages=false --inbound-url https://example.com/inbound",
+			want: want{
+				errMsg: "--inbound-url cannot be set when its capability is disabled",
+			},
+		},
+		{
+			name: "invalid-webhook-url",
+			cli:  "--app-id=42066b10-c4ae-48a0-addd-feb2bd615a67 --event-url example.com/event",
+			want: want{
+				errMsg: "invalid value for --event-url: \"example.com/event\" is not an http(s) URL",
+			},
+		},
+		{
+			name: "not-found",
+			cli:  "--app-id=unknown --rtc",
+			mock: mock{
+				UpdateAppID:     "unknown",
+				UpdateInput:     api.UpdateVonageApplicationInput{EnableRTC: &enable},
+				UpdateTimes:     1,
+				UpdateReturnErr: api.ErrNotFound,
+			},
+			want: want{
+				errMsg: "application \"unknown\" not found, run 'vcr app list' to see your applications",
+			},
+		},
+		{
+			name: "api-error",
+			cli:  "--app-id=42066b10-c4ae-48a0-addd-feb2bd615a67 --rtc",
+			mock: mock{
+				UpdateAppID:     "42066b10-c4ae-48a0-addd-feb2bd615a67",
+				UpdateInput:     api.UpdateVonageApplicationInput{EnableRTC: &enable},
+				UpdateTimes:     1,
+				UpdateReturnErr: errors.New("api error"),
+			},
+			want: want{
+				errMsg: "failed to update application: api error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			returned := app
+			if tt.mock.UpdateReturnApp != nil {
+				returned = *tt.mock.UpdateReturnApp
+			}
+			deploymentMock := mocks.NewMockDeploymentInterface(ctrl)
+			deploymentMock.EXPECT().UpdateVonageApplication(gomock.Any(), tt.mock.UpdateAppID, tt.mock.UpdateInput).
+				Times(tt.mock.UpdateTimes).
+				Return(returned, tt.mock.UpdateReturnErr)
+
+			ios, _, stdout, stderr := iostreams.Test()
+
+			argv, err := shlex.Split(tt.cli)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, nil, deploymentMock, nil, nil)
+
+			cmd := NewCmdAppUpdate(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			if tt.want.errMsg != "" {
+				require.Error(t, err, "should throw error")
+				require.Equal(t, tt.want.errMsg, err.Error())
+				return
+			}
+			cmdOut := &testutil.CmdOut{
+				OutBuf: stdout,
+				ErrBuf: stderr,
+			}
+
+			require.NoError(t, err, "should not throw error")
+			require.Contains(t, cmdOut.String(), tt.want.stdout)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/vcr/config/get"
+	"vonage-cloud-runtime-cli/vcr/config/list"
+	"vonage-cloud-runtime-cli/vcr/config/set"
+	"vonage-cloud-runtime-cli/vcr/config/unset"
+)
+
+func NewCmdConfig(f cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config <command>",
+		Short: "Read and write individual settings of the configuration file",
+		Long: heredoc.Doc(`Read and write individual settings of the configuration file.
+
+			Unlike 'vcr configure', these commands are not interactive, which makes them
+			suitable for scripts. Settings are read from and written to the profile selected
+			with --profile or VCR_PROFILE, or the top-level settings when neither is set.
+			Setting a key in a profile that does not exist yet creates the profile.
+
+			SETTINGS
+			  default_region      Region used when --region is not set
+			  graphql_endpoint    GraphQL endpoint used to fetch metadata
+			  api_key             Vonage API key
+			  api_secret          Vonage API secret
+			  credential_store    Where the API secret is kept: plaintext or encrypted
+			  credential_process  Command that prints the API key and secret as JSON
+			  update_check        Check for new CLI versions: true or false (all profiles)
+
+			VALIDATION
+			  Values are checked before they are written. Regions must be one of the aliases
+			  returned by the platform, so setting default_region requires valid credentials.
+
+			AVAILABLE COMMANDS
+			  get            Print the value of a setting
+			  set            Change the value of a setting
+			  unset          Remove a setting so that its default applies
+			  list (ls)      List all settings
+		`),
+		Example: heredoc.Doc(`
+			# Change the default region
+			$ vcr config set default_region aws.use1
+
+			# Print the API key of the "prod" profile
+			$ vcr config get api_key --profile prod
+
+			# Disable the update check
+			$ vcr config set update_check false
+
+			# List all settings
+			$ vcr config list
+		`),
+	}
+
+	cmdutil.DisableAuthCheck(cmd)
+
+	cmd.AddCommand(get.NewCmdConfigGet(f))
+	cmd.AddCommand(set.NewCmdConfigSet(f))
+	cmd.AddCommand(unset.NewCmdConfigUnset(f))
+	cmd.AddCommand(list.NewCmdConfigList(f))
+	return cmd
+}
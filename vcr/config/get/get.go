@@ -0,0 +1,67 @@
+package get
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	Key string
+}
+
+func NewCmdConfigGet(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the value of a setting",
+		Long: heredoc.Doc(`Print the value of a setting from the configuration file.
+
+			Only the value is printed, so the output can be used directly in scripts.
+			Nothing is printed for a setting that is not set. Run 'vcr config list' to
+			see all settings.
+		`),
+		Example: heredoc.Doc(`
+			# Print the default region
+			$ vcr config get default_region
+			aws.euw1
+
+			# Print the API key of the "prod" profile
+			$ vcr config get api_key --profile prod
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			opts.Key = args[0]
+			return runGet(&opts)
+		},
+	}
+
+	return cmd
+}
+
+func runGet(opts *Options) error {
+	io := opts.IOStreams()
+
+	cfg, err := config.ReadCLIConfig(opts.ConfigFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to read config file %q: %w", opts.ConfigFilePath(), err)
+	}
+
+	value, err := cfg.Get(opts.GlobalOptions().Profile, opts.Key)
+	if err != nil {
+		return err
+	}
+	if value != "" {
+		fmt.Fprintln(io.Out, value)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package get
+
+import (
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/testutil"
+)
+
+func TestConfigGet(t *testing.T) {
+	type want struct {
+		errMsg string
+		stdout string
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want want
+	}{
+		{
+			name: "profile-setting",
+			key:  "default_region",
+			want: want{
+				stdout: "aws.euw1\n",
+			},
+		},
+		{
+			name: "unset-setting",
+			key:  "credential_store",
+			want: want{
+				stdout: "",
+			},
+		},
+		{
+			name: "unknown-key",
+			key:  "colour",
+			want: want{
+				errMsg: "\"colour\": unknown setting, run 'vcr config list' to see all settings",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ios, _, stdout, _ := iostreams.Test()
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, nil, nil, nil, nil)
+
+			err := runGet(&Options{Factory: f, Key: tt.key})
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.stdout, stdout.String())
+		})
+	}
+}
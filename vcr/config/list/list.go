@@ -0,0 +1,87 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+const maskedValue = "********"
+
+type Options struct {
+	cmdutil.Factory
+}
+
+func NewCmdConfigList(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List all settings",
+		Long: heredoc.Doc(`List all settings of the selected profile.
+
+			Settings that are not set are shown with an empty value. The API secret is
+			masked; use 'vcr config get api_secret' to print it.
+		`),
+		Example: heredoc.Doc(`
+			# List the top-level settings
+			$ vcr config list
+			┌────────────────────┬────────────────────────────────────────────────────────┐
+			│        KEY         │                         VALUE                          │
+			├────────────────────┼────────────────────────────────────────────────────────┤
+			│ default_region     │ aws.euw1                                               │
+			│ graphql_endpoint   │ https://graphql.euw1.runtime.vonage.cloud/v1/graphql   │
+			│ api_key            │ abc123                                                 │
+			│ api_secret         │ ********                                               │
+			│ credential_store   │                                                        │
+			│ credential_process │                                                        │
+			│ update_check       │                                                        │
+			│ output_format      │                                                        │
+			└────────────────────┴────────────────────────────────────────────────────────┘
+
+			# List the settings of the "prod" profile
+			$ vcr config list --profile prod
+		`),
+		Args: cobra.MaximumNArgs(0),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runList(&opts)
+		},
+	}
+
+	return cmd
+}
+
+func runList(opts *Options) error {
+	io := opts.IOStreams()
+
+	cfg, err := config.ReadCLIConfig(opts.ConfigFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to read config file %q: %w", opts.ConfigFilePath(), err)
+	}
+
+	table := tablewriter.NewWriter(io.Out)
+	table.Header("Key", "Value")
+
+	for _, setting := range config.Settings {
+		value, err := cfg.Get(opts.GlobalOptions().Profile, setting.Key)
+		if err != nil {
+			return err
+		}
+		if setting.Secret && value != "" {
+			value = maskedValue
+		}
+		if err := table.Append([]string{setting.Key, value}); err != nil {
+			return fmt.Errorf("failed to append setting to table: %w", err)
+		}
+	}
+
+	return table.Render()
+}
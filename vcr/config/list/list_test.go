@@ -0,0 +1,42 @@
+package list
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/testutil"
+)
+
+func TestConfigList(t *testing.T) {
+	ios, _, stdout, _ := iostreams.Test()
+
+	f := testutil.DefaultFactoryMock(t, ios, nil, nil, nil, nil, nil, nil)
+
+	cmd := NewCmdConfigList(f)
+	cmd.SetArgs([]string{})
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	_, err := cmd.ExecuteC()
+	require.NoError(t, err, "should not throw error")
+	require.Equal(t, heredoc.Doc(`
+		┌────────────────────┬────────────────────────────────┐
+		│        KEY         │             VALUE              │
+		├────────────────────┼────────────────────────────────┤
+		│ default_region     │ aws.euw1                       │
+		│ graphql_endpoint   │ https://api.vonage.com/graphql │
+		│ api_key            │ sandbox                        │
+		│ api_secret         │ ********                       │
+		│ credential_store   │                                │
+		│ credential_process │                                │
+		│ update_check       │ false                          │
+		│ output_format      │                                │
+		└────────────────────┴────────────────────────────────┘
+	`), stdout.String())
+}
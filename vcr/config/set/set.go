@@ -0,0 +1,239 @@
+package set
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	Key   string
+	Value string
+
+	credentialStore *config.LocalSecretStore
+}
+
+func NewCmdConfigSet(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory:         f,
+		credentialStore: config.NewDefaultCredentialStore(),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "set <key> [<value>]",
+		Short: "Change the value of a setting",
+		Long: heredoc.Doc(`Change the value of a setting in the configuration file.
+
+			The value is validated before the file is written. A region must be one of
+			the aliases returned by the platform, and is checked with the credentials of
+			the selected profile.
+
+			The api_secret value is never taken as an argument, so that it does not end up in
+			your shell history. It is read from standard input instead.
+
+			When the profile keeps its API secret in the encrypted credential store, setting
+			api_secret updates the store instead of the configuration file. Changing
+			credential_store moves the existing API secret to the new store.
+		`),
+		Example: heredoc.Doc(`
+			# Change the default region
+			$ vcr config set default_region aws.use1
+			✓ Set default_region to "aws.use1"
+
+			# Create a "staging" profile with its own API key
+			$ vcr config set api_key abc123 --profile staging
+
+			# Disable the update check
+			$ vcr config set update_check false
+
+			# Print JSON by default from the commands with a --json flag
+			$ vcr config set output_format json
+
+			# Read the API secret from standard input
+			$ vcr config set api_secret < ./api_secret.txt
+			✓ Set api_secret
+
+			# Move the API secret to the encrypted credential store
+			$ vcr config set credential_store encrypted
+			✓ Set credential_store to "encrypted"
+		`),
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
+			defer cancel()
+
+			opts.Key = args[0]
+			switch {
+			case opts.Key == config.SettingAPISecret && len(args) == 2:
+				return fmt.Errorf("%s can't be passed as an argument, pipe it on standard input instead", config.SettingAPISecret)
+			case opts.Key == config.SettingAPISecret:
+				value, err := readSecret(opts.IOStreams())
+				if err != nil {
+					return fmt.Errorf("can't read %s: %w", config.SettingAPISecret, err)
+				}
+				opts.Value = value
+			case len(args) == 1:
+				return fmt.Errorf("missing value for %s", opts.Key)
+			default:
+				opts.Value = args[1]
+			}
+			return runSet(ctx, &opts)
+		},
+	}
+
+	return cmd
+}
+
+func runSet(ctx context.Context, opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	cfg, err := config.ReadCLIConfig(opts.ConfigFilePath())
+	if err != nil && !errors.Is(err, config.ErrNoConfig) {
+		return fmt.Errorf("failed to read config file %q: %w", opts.ConfigFilePath(), err)
+	}
+
+	setting, err := config.LookupSetting(opts.Key)
+	if err != nil {
+		return err
+	}
+	value, err := setting.Normalize(opts.Value)
+	if err != nil {
+		return err
+	}
+
+	profileName := opts.GlobalOptions().Profile
+	if setting.Key == config.SettingDefaultRegion {
+		if err := validateRegion(ctx, opts, cfg, profileName, value); err != nil {
+			return err
+		}
+	}
+
+	// a profile that does not exist yet starts out empty
+	profile, _ := cfg.Profile(profileName)
+	if setting.Key == config.SettingAPISecret && profile.CredentialStore == config.CredentialStoreEncrypted {
+		if err := config.SaveCredentials(opts.credentialStore, profileName, value); err != nil {
+			return fmt.Errorf("failed to save api secret: %w", err)
+		}
+		fmt.Fprintf(io.Out, "%s Set %s in the encrypted credential store\n", c.SuccessIcon(), setting.Key)
+		return nil
+	}
+
+	var removeStored bool
+	if setting.Key == config.SettingCredentialStore {
+		if removeStored, err = moveAPISecret(ctx, opts.credentialStore, &cfg, profileName, profile, value); err != nil {
+			return err
+		}
+	}
+
+	if err := cfg.Set(profileName, setting.Key, value); err != nil {
+		return err
+	}
+	if err := config.WriteCLIConfig(cfg, opts.ConfigFilePath()); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if removeStored {
+		if err := config.RemoveCredentials(opts.credentialStore, profileName); err != nil {
+			return fmt.Errorf("failed to remove api secret from the encrypted credential store: %w", err)
+		}
+	}
+
+	if setting.Secret {
+		fmt.Fprintf(io.Out, "%s Set %s\n", c.SuccessIcon(), setting.Key)
+		return nil
+	}
+	fmt.Fprintf(io.Out, "%s Set %s to %q\n", c.SuccessIcon(), setting.Key, value)
+	return nil
+}
+
+// moveAPISecret moves the API secret of the profile from its current credential store to the new one.
+// Only the config is updated, the caller writes it and then removes the secret from the encrypted store
+// when removeStored is true, so that the secret is never lost if the config can't be written.
+func moveAPISecret(ctx context.Context, store *config.LocalSecretStore, cfg *config.CLIConfig, profileName string, profile config.Profile, newStore string) (removeStored bool, err error) {
+	wasEncrypted := profile.CredentialStore == config.CredentialStoreEncrypted
+	isEncrypted := newStore == config.CredentialStoreEncrypted
+	switch {
+	case !wasEncrypted && isEncrypted:
+		if profile.APISecret == "" {
+			return false, nil
+		}
+		if err := config.SaveCredentials(store, profileName, profile.APISecret); err != nil {
+			return false, fmt.Errorf("failed to save api secret: %w", err)
+		}
+		profile.APISecret = ""
+	case wasEncrypted && !isEncrypted:
+		stored := profile
+		stored.CredentialProcess = ""
+		resolved, err := stored.ResolveCredentials(ctx, profileName, store)
+		switch {
+		case errors.Is(err, config.ErrNoStoredCredentials):
+			return false, nil
+		case err != nil:
+			return false, fmt.Errorf("failed to read api secret: %w", err)
+		}
+		profile.APISecret = resolved.APISecret
+		removeStored = true
+	default:
+		return false, nil
+	}
+	cfg.SetProfile(profileName, profile)
+	return removeStored, nil
+}
+
+// readSecret reads the API secret from standard input, without the trailing newline.
+func readSecret(ios *iostreams.IOStreams) (string, error) {
+	if ios.IsStdinTTY() {
+		fmt.Fprintf(ios.ErrOut, "Reading from STDIN - hit (Control + D) to stop.\n")
+	}
+	data, err := io.ReadAll(ios.In)
+	if err != nil {
+		return "", err
+	}
+	value := strings.TrimRight(string(data), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("no value provided")
+	}
+	return value, nil
+}
+
+// validateRegion checks the alias against the regions returned by the platform, using the credentials
+// of the selected profile unless they are overridden by flags or environment variables.
+func validateRegion(ctx context.Context, opts *Options, cfg config.CLIConfig, profileName, alias string) error {
+	if profile, err := cfg.Profile(profileName); err == nil && !opts.GlobalOptions().HasCredentials() {
+		resolved, err := profile.ResolveCredentials(ctx, profileName, opts.credentialStore)
+		if err != nil {
+			return fmt.Errorf("failed to resolve credentials: %w", err)
+		}
+		cfg.SetProfile(profileName, resolved)
+	}
+	if err := opts.InitDatastore(cfg, opts.GlobalOptions()); err != nil {
+		return fmt.Errorf("failed to initialize cli: %w", err)
+	}
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving region list... ")
+	regions, err := opts.Datastore().ListRegions(ctx)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve regions: %w", err)
+	}
+
+	aliases := make([]string, 0, len(regions))
+	for _, r := range regions {
+		if r.Alias == alias {
+			return nil
+		}
+		aliases = append(aliases, r.Alias)
+	}
+	return fmt.Errorf("invalid value for %s: region %q does not exist, must be one of: %s", config.SettingDefaultRegion, alias, strings.Join(aliases, ", "))
+}
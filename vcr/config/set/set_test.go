@@ -0,0 +1,315 @@
+package set
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/config"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+const existingConfig = `default_region = aws.euw1
+
+[credentials]
+api_key    = abc
+api_secret = secret
+
+[profile prod]
+api_key          = prod
+credential_store = encrypted
+`
+
+func TestConfigSet(t *testing.T) {
+	type mock struct {
+		ListRegionsTimes     int
+		ListRegionsReturn    []api.Region
+		ListRegionsReturnErr error
+	}
+	type want struct {
+		errMsg      string
+		stdout      string
+		profile     config.Profile
+		secrets     map[string]string
+		updateCheck string
+	}
+
+	tests := []struct {
+		name    string
+		profile string
+		key     string
+		value   string
+		stored  map[string]string
+		mock    mock
+		want    want
+	}{
+		{
+			name:  "set-api-key",
+			key:   "api_key",
+			value: "def",
+			want: want{
+				stdout:  "✓ Set api_key to \"def\"\n",
+				profile: config.Profile{DefaultRegion: "aws.euw1", APIKey: "def", APISecret: "secret"},
+			},
+		},
+		{
+			name:  "set-valid-region",
+			key:   "default_region",
+			value: "aws.use1",
+			mock: mock{
+				ListRegionsTimes:  1,
+				ListRegionsReturn: []api.Region{{Alias: "aws.euw1"}, {Alias: "aws.use1"}},
+			},
+			want: want{
+				stdout:  "✓ Set default_region to \"aws.use1\"\n",
+				profile: config.Profile{DefaultRegion: "aws.use1", APIKey: "abc", APISecret: "secret"},
+			},
+		},
+		{
+			name:  "set-unknown-region",
+			key:   "default_region",
+			value: "aws.mars1",
+			mock: mock{
+				ListRegionsTimes:  1,
+				ListRegionsReturn: []api.Region{{Alias: "aws.euw1"}, {Alias: "aws.use1"}},
+			},
+			want: want{
+				errMsg: "invalid value for default_region: region \"aws.mars1\" does not exist, must be one of: aws.euw1, aws.use1",
+			},
+		},
+		{
+			name:  "list-regions-error",
+			key:   "default_region",
+			value: "aws.use1",
+			mock: mock{
+				ListRegionsTimes:     1,
+				ListRegionsReturnErr: errors.New("unauthorized"),
+			},
+			want: want{
+				errMsg: "failed to retrieve regions: unauthorized",
+			},
+		},
+		{
+			name:  "set-secret-is-not-printed",
+			key:   "api_secret",
+			value: "new-secret",
+			want: want{
+				stdout:  "✓ Set api_secret\n",
+				profile: config.Profile{DefaultRegion: "aws.euw1", APIKey: "abc", APISecret: "new-secret"},
+			},
+		},
+		{
+			name:    "set-secret-in-encrypted-store",
+			profile: "prod",
+			key:     "api_secret",
+			value:   "prod-secret",
+			want: want{
+				stdout:  "✓ Set api_secret in the encrypted credential store\n",
+				profile: config.Profile{APIKey: "prod", CredentialStore: "encrypted"},
+				secrets: map[string]string{"prod": "prod-secret"},
+			},
+		},
+		{
+			name:  "switch-to-encrypted-store-moves-secret",
+			key:   "credential_store",
+			value: "encrypted",
+			want: want{
+				stdout:  "✓ Set credential_store to \"encrypted\"\n",
+				profile: config.Profile{DefaultRegion: "aws.euw1", APIKey: "abc", CredentialStore: "encrypted"},
+				secrets: map[string]string{"default": "secret"},
+			},
+		},
+		{
+			name:    "switch-to-plaintext-store-moves-secret",
+			profile: "prod",
+			key:     "credential_store",
+			value:   "plaintext",
+			stored:  map[string]string{"prod": "prod-secret", "other": "other-secret"},
+			want: want{
+				stdout:  "✓ Set credential_store to \"plaintext\"\n",
+				profile: config.Profile{APIKey: "prod", APISecret: "prod-secret", CredentialStore: "plaintext"},
+				secrets: map[string]string{"other": "other-secret"},
+			},
+		},
+		{
+			name:    "switch-store-without-secret",
+			profile: "staging",
+			key:     "credential_store",
+			value:   "encrypted",
+			want: want{
+				stdout:  "✓ Set credential_store to \"encrypted\"\n",
+				profile: config.Profile{CredentialStore: "encrypted"},
+				secrets: map[string]string{},
+			},
+		},
+		{
+			name:    "set-creates-profile",
+			profile: "staging",
+			key:     "api_key",
+			value:   "staging",
+			want: want{
+				stdout:  "✓ Set api_key to \"staging\"\n",
+				profile: config.Profile{APIKey: "staging"},
+			},
+		},
+		{
+			name:    "set-global-setting",
+			profile: "prod",
+			key:     "update_check",
+			value:   "FALSE",
+			want: want{
+				stdout:      "✓ Set update_check to \"false\"\n",
+				profile:     config.Profile{APIKey: "prod", CredentialStore: "encrypted"},
+				updateCheck: "false",
+			},
+		},
+		{
+			name:  "invalid-value",
+			key:   "graphql_endpoint",
+			value: "not-a-url",
+			want: want{
+				errMsg: "invalid value for graphql_endpoint: \"not-a-url\" is not an http(s) URL",
+			},
+		},
+		{
+			name:  "unknown-key",
+			key:   "colour",
+			value: "blue",
+			want: want{
+				errMsg: "\"colour\": unknown setting, run 'vcr config list' to see all settings",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			configPath := filepath.Join(tempDir, "config")
+			require.NoError(t, os.WriteFile(configPath, []byte(existingConfig), 0600))
+			store := config.NewLocalSecretStore(filepath.Join(tempDir, "credentials.enc"), filepath.Join(tempDir, "secrets.key"), "")
+			if tt.stored != nil {
+				require.NoError(t, store.Save(tt.stored))
+			}
+
+			ctrl := gomock.NewController(t)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			datastoreMock.EXPECT().ListRegions(gomock.Any()).
+				Times(tt.mock.ListRegionsTimes).
+				Return(tt.mock.ListRegionsReturn, tt.mock.ListRegionsReturnErr)
+
+			ios, _, stdout, _ := iostreams.Test()
+			globalOpts := &config.GlobalOptions{ConfigFilePath: configPath, Profile: tt.profile}
+			f := mocks.NewMockFactory(ctrl)
+			f.EXPECT().IOStreams().Return(ios).AnyTimes()
+			f.EXPECT().ConfigFilePath().Return(configPath).AnyTimes()
+			f.EXPECT().GlobalOptions().Return(globalOpts).AnyTimes()
+			f.EXPECT().InitDatastore(gomock.Any(), globalOpts).Times(tt.mock.ListRegionsTimes)
+			f.EXPECT().Datastore().Return(datastoreMock).AnyTimes()
+
+			opts := &Options{Factory: f, Key: tt.key, Value: tt.value, credentialStore: store}
+			err := runSet(context.Background(), opts)
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.stdout, stdout.String())
+
+			cfg, err := config.ReadCLIConfig(configPath)
+			require.NoError(t, err)
+			profile, err := cfg.Profile(tt.profile)
+			require.NoError(t, err)
+			require.Equal(t, tt.want.profile, profile)
+			require.Equal(t, tt.want.updateCheck, cfg.UpdateCheck)
+
+			if tt.want.secrets != nil {
+				secrets, err := store.Load()
+				require.NoError(t, err)
+				require.Equal(t, tt.want.secrets, secrets)
+
+				resolved, err := profile.ResolveCredentials(context.Background(), tt.profile, store)
+				if tt.want.profile.APIKey != "" {
+					require.NoError(t, err)
+					require.NotEmpty(t, resolved.APISecret)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigSetAPISecretInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		errMsg string
+		secret string
+	}{
+		{
+			name:   "read-from-stdin",
+			args:   []string{"api_secret"},
+			stdin:  "piped-secret\n",
+			secret: "piped-secret",
+		},
+		{
+			name:   "reject-argument",
+			args:   []string{"api_secret", "leaked-secret"},
+			errMsg: "api_secret can't be passed as an argument, pipe it on standard input instead",
+		},
+		{
+			name:   "empty-stdin",
+			args:   []string{"api_secret"},
+			errMsg: "can't read api_secret: no value provided",
+		},
+		{
+			name:   "missing-value",
+			args:   []string{"api_key"},
+			errMsg: "missing value for api_key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config")
+			require.NoError(t, os.WriteFile(configPath, []byte(existingConfig), 0600))
+
+			ctrl := gomock.NewController(t)
+			ios, stdin, _, _ := iostreams.Test()
+			stdin.WriteString(tt.stdin)
+			f := mocks.NewMockFactory(ctrl)
+			f.EXPECT().IOStreams().Return(ios).AnyTimes()
+			f.EXPECT().ConfigFilePath().Return(configPath).AnyTimes()
+			f.EXPECT().GlobalOptions().Return(&config.GlobalOptions{ConfigFilePath: configPath}).AnyTimes()
+			f.EXPECT().Deadline().Return(time.Now().Add(time.Minute)).AnyTimes()
+
+			cmd := NewCmdConfigSet(f)
+			cmd.SetArgs(tt.args)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err := cmd.ExecuteC()
+			if tt.errMsg != "" {
+				require.EqualError(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			cfg, err := config.ReadCLIConfig(configPath)
+			require.NoError(t, err)
+			profile, err := cfg.Profile("")
+			require.NoError(t, err)
+			require.Equal(t, tt.secret, profile.APISecret)
+		})
+	}
+}
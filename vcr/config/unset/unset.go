@@ -0,0 +1,68 @@
+package unset
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	Key string
+}
+
+func NewCmdConfigUnset(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a setting so that its default applies",
+		Long: heredoc.Doc(`Remove a setting from the configuration file so that its default applies.
+
+			For example, without graphql_endpoint the endpoint is derived from the region,
+			and without update_check the update check is enabled.
+		`),
+		Example: heredoc.Doc(`
+			# Use the endpoint derived from the default region again
+			$ vcr config unset graphql_endpoint
+			✓ Unset graphql_endpoint
+
+			# Re-enable the update check
+			$ vcr config unset update_check
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			opts.Key = args[0]
+			return runUnset(&opts)
+		},
+	}
+
+	return cmd
+}
+
+func runUnset(opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	cfg, err := config.ReadCLIConfig(opts.ConfigFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to read config file %q: %w", opts.ConfigFilePath(), err)
+	}
+
+	if err := cfg.Unset(opts.GlobalOptions().Profile, opts.Key); err != nil {
+		return err
+	}
+	if err := config.WriteCLIConfig(cfg, opts.ConfigFilePath()); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "%s Unset %s\n", c.SuccessIcon(), opts.Key)
+	return nil
+}
@@ -0,0 +1,96 @@
+package unset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/config"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+const existingConfig = `default_region   = aws.euw1
+graphql_endpoint = https://graphql.example.com
+update_check     = false
+
+[credentials]
+api_key    = abc
+api_secret = secret
+`
+
+func TestConfigUnset(t *testing.T) {
+	type want struct {
+		errMsg string
+		stdout string
+		config config.CLIConfig
+	}
+
+	tests := []struct {
+		name    string
+		profile string
+		key     string
+		want    want
+	}{
+		{
+			name: "profile-setting",
+			key:  "graphql_endpoint",
+			want: want{
+				stdout: "✓ Unset graphql_endpoint\n",
+				config: config.CLIConfig{
+					DefaultRegion: "aws.euw1",
+					UpdateCheck:   "false",
+					Credentials:   config.Credentials{APIKey: "abc", APISecret: "secret"},
+				},
+			},
+		},
+		{
+			name: "global-setting",
+			key:  "update_check",
+			want: want{
+				stdout: "✓ Unset update_check\n",
+				config: config.CLIConfig{
+					DefaultRegion:   "aws.euw1",
+					GraphqlEndpoint: "https://graphql.example.com",
+					Credentials:     config.Credentials{APIKey: "abc", APISecret: "secret"},
+				},
+			},
+		},
+		{
+			name:    "missing-profile",
+			profile: "prod",
+			key:     "api_key",
+			want: want{
+				errMsg: "\"prod\": profile not found, please use 'vcr configure --profile <name>' to create it",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config")
+			require.NoError(t, os.WriteFile(configPath, []byte(existingConfig), 0600))
+
+			ios, _, stdout, _ := iostreams.Test()
+			f := mocks.NewMockFactory(gomock.NewController(t))
+			f.EXPECT().IOStreams().Return(ios).AnyTimes()
+			f.EXPECT().ConfigFilePath().Return(configPath).AnyTimes()
+			f.EXPECT().GlobalOptions().Return(&config.GlobalOptions{Profile: tt.profile}).AnyTimes()
+
+			err := runUnset(&Options{Factory: f, Key: tt.key})
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.stdout, stdout.String())
+
+			cfg, err := config.ReadCLIConfig(configPath)
+			require.NoError(t, err)
+			require.Equal(t, tt.want.config, cfg)
+		})
+	}
+}
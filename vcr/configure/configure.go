@@ -11,10 +11,14 @@ import (
 	"vonage-cloud-runtime-cli/pkg/format"
 
 	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	listCmd "vonage-cloud-runtime-cli/vcr/configure/list"
 )
 
 type Options struct {
 	cmdutil.Factory
+
+	CredentialStore   string
+	CredentialProcess string
 }
 
 func NewCmdConfigure(f cmdutil.Factory) *cobra.Command {
@@ -44,6 +48,28 @@ func NewCmdConfigure(f cmdutil.Factory) *cobra.Command {
 			  multiple configuration files for different accounts/environments by using
 			  the --config-file flag with other commands.
 
+			PROFILES
+			  A single configuration file can also hold several named profiles, for example
+			  a sandbox and a production account. Use --profile to create or update one;
+			  it is saved as a "[profile <name>]" section next to the top-level settings.
+
+			  Select a profile for any command with --profile or the VCR_PROFILE
+			  environment variable. Without either, the top-level settings are used.
+			  Run 'vcr configure list' to see all profiles.
+
+			CREDENTIAL STORAGE
+			  The configuration file is written with 0600 permissions. By default the API
+			  secret is stored in it as plain text. Two alternatives keep it out of the file:
+
+			  --credential-store encrypted
+			      The secret is kept in ~/.vcr-cli.d/credentials.enc, encrypted with the
+			      same key file or VCR_SECRETS_PASSPHRASE as 'vcr secret local'.
+
+			  --credential-process "<command>"
+			      The command is run whenever credentials are needed and must print
+			      {"api_key": "...", "api_secret": "..."} on stdout. Nothing secret is
+			      written by the CLI. Use it to read credentials from a password manager.
+
 			NOTE: The VCR CLI requires configuration before any other commands will work.
 			Run this command first after installing the CLI.
 		`),
@@ -57,15 +83,42 @@ func NewCmdConfigure(f cmdutil.Factory) *cobra.Command {
 
 			# Use a custom configuration file path
 			$ vcr configure --config-file ~/.vcr-cli-staging
+
+			# Create or update a named profile
+			$ vcr configure --profile prod
+			✓ Profile "prod" written to /Users/you/.vcr-cli
+
+			# Use the profile with another command
+			$ vcr deploy --profile prod
+
+			# Keep the API secret in an encrypted file instead of the config file
+			$ vcr configure --credential-store encrypted
+
+			# Read credentials from an external command
+			$ vcr configure --credential-process "op read --no-newline op://vcr/credentials/json"
 		`),
 		Args: cobra.MaximumNArgs(0),
-		RunE: func(_ *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 
+			if err := cmdutil.MutuallyExclusive("specify only one of --credential-store or --credential-process", opts.CredentialStore != "", opts.CredentialProcess != ""); err != nil {
+				return err
+			}
+			if err := config.ValidateCredentialStore(opts.CredentialStore); err != nil {
+				return cmdutil.FlagErrorWrap(err)
+			}
+
 			return runConfigure(ctx, &opts)
 		},
 	}
+
+	cmd.Flags().StringVarP(&opts.CredentialStore, "credential-store", "", "", "Where to keep the API secret: \"plaintext\" (config file) or \"encrypted\" (encrypted file under ~/.vcr-cli.d)")
+	cmd.Flags().StringVarP(&opts.CredentialProcess, "credential-process", "", "", "Command that prints {\"api_key\": ..., \"api_secret\": ...} as JSON, run instead of storing the secret")
+
+	cmdutil.DisableAuthCheck(cmd)
+
+	cmd.AddCommand(listCmd.NewCmdConfigureList(f))
 	return cmd
 }
 
@@ -82,28 +135,55 @@ func runConfigure(ctx context.Context, opts *Options) error {
 	}
 	opts.SetCliConfig(cfg)
 
-	cfg.GraphqlEndpoint = opts.GraphQLURL()
-	if cfg.GraphqlEndpoint == "" {
-		cfg.GraphqlEndpoint = config.DefaultGraphqlURL
+	if worldReadable, err := config.IsWorldReadable(opts.ConfigFilePath()); err == nil && worldReadable {
+		fmt.Fprintf(io.ErrOut, "%s Config file %s is readable by other users, its permissions will be restricted to 0600\n", c.WarningIcon(), opts.ConfigFilePath())
 	}
 
-	cfg.APIKey, err = opts.Survey().AskForUserInput("Enter your Vonage api key:", opts.APIKey())
-	if err != nil {
-		return fmt.Errorf("failed to read api key: %w", err)
+	profileName := opts.GlobalOptions().Profile
+	// a profile that does not exist yet starts out empty
+	profile, _ := cfg.Profile(profileName)
+
+	profile.GraphqlEndpoint = opts.GraphQLURL()
+	if profile.GraphqlEndpoint == "" {
+		profile.GraphqlEndpoint = config.DefaultGraphqlURL
 	}
 
-	cfg.APISecret, err = opts.Survey().AskForUserInput("Enter your Vonage api secret:", opts.APISecret())
-	if err != nil {
-		return fmt.Errorf("failed to read api secret: %w", err)
+	var apiKey, apiSecret string
+	if opts.CredentialProcess != "" {
+		profile.CredentialProcess = opts.CredentialProcess
+		profile.CredentialStore = ""
+		resolved, err := profile.ResolveCredentials(ctx, profileName, nil)
+		if err != nil {
+			return fmt.Errorf("failed to read credentials: %w", err)
+		}
+		apiKey, apiSecret = resolved.APIKey, resolved.APISecret
+	} else {
+		profile.CredentialProcess = ""
+		apiKey, err = opts.Survey().AskForUserInput("Enter your Vonage api key:", opts.APIKey())
+		if err != nil {
+			return fmt.Errorf("failed to read api key: %w", err)
+		}
+
+		apiSecret, err = opts.Survey().AskForUserInput("Enter your Vonage api secret:", opts.APISecret())
+		if err != nil {
+			return fmt.Errorf("failed to read api secret: %w", err)
+		}
 	}
 
-	opts.InitDatastore(cfg, &config.GlobalOptions{
+	globalOpts := opts.GlobalOptions()
+	if err := opts.InitDatastore(cfg, &config.GlobalOptions{
 		ConfigFilePath:  opts.ConfigFilePath(),
-		GraphqlEndpoint: cfg.GraphqlEndpoint,
-		APIKey:          cfg.APIKey,
-		APISecret:       cfg.APISecret,
+		GraphqlEndpoint: profile.GraphqlEndpoint,
+		APIKey:          apiKey,
+		APISecret:       apiSecret,
 		Region:          opts.Region(),
-	})
+		Retries:         globalOpts.Retries,
+		DebugHTTP:       globalOpts.DebugHTTP,
+		CAFile:          globalOpts.CAFile,
+		Proxy:           globalOpts.Proxy,
+	}); err != nil {
+		return fmt.Errorf("failed to initialize cli: %w", err)
+	}
 
 	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving region list... ")
 	regions, err := opts.Datastore().ListRegions(ctx)
@@ -117,12 +197,32 @@ func runConfigure(ctx context.Context, opts *Options) error {
 	if err != nil {
 		return fmt.Errorf("failed to select region: %w", err)
 	}
-	cfg.DefaultRegion = regionOptions.AliasLookup[regionLabel]
+	profile.DefaultRegion = regionOptions.AliasLookup[regionLabel]
 
+	profile.APIKey = apiKey
+	profile.APISecret = ""
+	if opts.CredentialStore != "" {
+		profile.CredentialStore = opts.CredentialStore
+	}
+	switch {
+	case profile.CredentialProcess != "":
+	case profile.CredentialStore == config.CredentialStoreEncrypted:
+		if err := config.SaveCredentials(config.NewDefaultCredentialStore(), profileName, apiSecret); err != nil {
+			return fmt.Errorf("failed to save api secret: %w", err)
+		}
+	default:
+		profile.APISecret = apiSecret
+	}
+
+	cfg.SetProfile(profileName, profile)
 	if err := config.WriteCLIConfig(cfg, opts.ConfigFilePath()); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
+	if profileName != "" && profileName != config.DefaultProfile {
+		fmt.Fprintf(io.Out, "%s Profile %q written to %s\n", c.SuccessIcon(), profileName, opts.ConfigFilePath())
+		return nil
+	}
 	fmt.Fprintf(io.Out, "%s New configuration file written to %s\n", c.SuccessIcon(), opts.ConfigFilePath())
 
 	return nil
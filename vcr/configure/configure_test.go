@@ -73,6 +73,20 @@ func TestConfigure(t *testing.T) {
 				stdout: "✓ New configuration file written to testdata/config.yaml\n",
 			},
 		},
+		{
+			name: "credential-flags-conflict",
+			cli:  "--credential-store=encrypted --credential-process=helper",
+			want: want{
+				errMsg: "specify only one of --credential-store or --credential-process",
+			},
+		},
+		{
+			name: "invalid-credential-store",
+			cli:  "--credential-store=keychain",
+			want: want{
+				errMsg: `unsupported credential store "keychain", must be one of "plaintext" or "encrypted"`,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,87 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+type Options struct {
+	cmdutil.Factory
+}
+
+func NewCmdConfigureList(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the profiles in the configuration file",
+		Long: heredoc.Doc(`List the profiles in the configuration file.
+
+			The "default" profile is made of the top-level settings of the file. Named
+			profiles are created with 'vcr configure --profile <name>'. The profile that
+			would be used by other commands, selected with --profile or VCR_PROFILE, is
+			marked as active. API secrets are never shown.
+		`),
+		Example: heredoc.Doc(`
+			# List all profiles
+			$ vcr configure list
+			┌─────────┬──────────┬─────────┬────────┐
+			│ PROFILE │  REGION  │ API KEY │ ACTIVE │
+			├─────────┼──────────┼─────────┼────────┤
+			│ default │ aws.euw1 │ abc123  │ *      │
+			│ prod    │ aws.use1 │ def456  │        │
+			└─────────┴──────────┴─────────┴────────┘
+
+			# Show which profile VCR_PROFILE selects
+			$ VCR_PROFILE=prod vcr configure list
+		`),
+		Args: cobra.MaximumNArgs(0),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runList(&opts)
+		},
+	}
+
+	return cmd
+}
+
+func runList(opts *Options) error {
+	io := opts.IOStreams()
+
+	cfg, err := config.ReadCLIConfig(opts.ConfigFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to read config file %q: %w", opts.ConfigFilePath(), err)
+	}
+
+	active := opts.GlobalOptions().Profile
+	if active == "" {
+		active = config.DefaultProfile
+	}
+
+	table := tablewriter.NewWriter(io.Out)
+	table.Header("Profile", "Region", "API Key", "Active")
+
+	for _, name := range cfg.ProfileNames() {
+		p, err := cfg.Profile(name)
+		if err != nil {
+			return err
+		}
+		marker := ""
+		if name == active {
+			marker = "*"
+		}
+		if err := table.Append([]string{name, p.DefaultRegion, p.APIKey, marker}); err != nil {
+			return fmt.Errorf("failed to append profile to table: %w", err)
+		}
+	}
+
+	return table.Render()
+}
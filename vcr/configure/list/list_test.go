@@ -0,0 +1,36 @@
+package list
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/testutil"
+)
+
+func TestConfigureList(t *testing.T) {
+	ios, _, stdout, _ := iostreams.Test()
+
+	f := testutil.DefaultFactoryMock(t, ios, nil, nil, nil, nil, nil, nil)
+
+	cmd := NewCmdConfigureList(f)
+	cmd.SetArgs([]string{})
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	_, err := cmd.ExecuteC()
+	require.NoError(t, err, "should not throw error")
+	require.Equal(t, heredoc.Doc(`
+		┌─────────┬──────────┬─────────┬────────┐
+		│ PROFILE │  REGION  │ API KEY │ ACTIVE │
+		├─────────┼──────────┼─────────┼────────┤
+		│ default │ aws.euw1 │ sandbox │ *      │
+		│ prod    │ aws.use1 │ prod    │        │
+		└─────────┴──────────┴─────────┴────────┘
+	`), stdout.String())
+}
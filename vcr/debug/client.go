@@ -16,6 +16,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/tidwall/gjson"
+
+	"vonage-cloud-runtime-cli/pkg/api"
 )
 
 var (
@@ -78,17 +80,19 @@ type DebuggerConnectionClient struct {
 	appWebsocketServerURL   string
 	websocketServerURL      string
 	localAppHost            string
+	dialer                  *api.WebsocketDialer
 	httpClient              *http.Client
 	remoteResponseChannels  map[string]chan websocketResponseMessage
 	writeRemoteReqStream    chan remoteRequestStreamEvent
 	done                    chan struct{}
 }
 
-func NewDebuggerConnectionClient(websocketServerURL, proxyWebsocketServerURL, localAppHost string) *DebuggerConnectionClient {
+func NewDebuggerConnectionClient(websocketServerURL, proxyWebsocketServerURL, localAppHost string, dialer *api.WebsocketDialer) *DebuggerConnectionClient {
 	return &DebuggerConnectionClient{
 		proxyWebsocketServerURL: proxyWebsocketServerURL,
 		websocketServerURL:      websocketServerURL,
 		localAppHost:            localAppHost,
+		dialer:                  dialer,
 		writeRemoteReqStream:    make(chan remoteRequestStreamEvent, streamBufferSize),
 		remoteResponseChannels:  make(map[string]chan websocketResponseMessage),
 		httpClient: &http.Client{CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
@@ -233,7 +237,7 @@ func (c *DebuggerConnectionClient) connectWithRetry() error {
 }
 
 func (c *DebuggerConnectionClient) connect() error {
-	conn, resp, err := websocket.DefaultDialer.Dial(c.websocketServerURL, nil)
+	conn, resp, err := c.dialer.Dial(c.websocketServerURL, nil)
 	if err != nil {
 		if resp != nil {
 			data, err := io.ReadAll(resp.Body)
@@ -281,7 +285,7 @@ func (c *DebuggerConnectionClient) connectWSWithRetry(url string, id string, hea
 
 func (c *DebuggerConnectionClient) connectWS(url string, id string, headers http.Header) (*websocket.Conn, error) {
 	headers.Add("X-Connection-Id", id)
-	newConn, resp, err := websocket.DefaultDialer.Dial(url, headers)
+	newConn, resp, err := c.dialer.Dial(url, headers)
 	if err != nil {
 		if resp != nil {
 			if resp.StatusCode == http.StatusNotFound {
@@ -84,7 +84,7 @@ func Test_run(t *testing.T) {
 
 	mockLocalAppHost := hs.URL
 
-	client := NewDebuggerConnectionClient(mockWebsocketURL, "", mockLocalAppHost)
+	client := NewDebuggerConnectionClient(mockWebsocketURL, "", mockLocalAppHost, nil)
 
 	err := client.run()
 	require.Equal(t, "error reading inbound debugger message: websocket: close 1000 (normal)", err.Error())
@@ -183,7 +183,7 @@ func Test_run(t *testing.T) {
 
 	mockLocalAppHost = appWS.URL
 
-	client = NewDebuggerConnectionClient(mockWebsocketURL, mockProxyWebsocketURL, mockLocalAppHost)
+	client = NewDebuggerConnectionClient(mockWebsocketURL, mockProxyWebsocketURL, mockLocalAppHost, nil)
 
 	err = client.run()
 	require.Equal(t, "error reading inbound debugger message: websocket: close 1000 (normal)", err.Error())
@@ -1,11 +1,9 @@
 package debug
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"html/template"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -44,14 +42,16 @@ type Options struct {
 	ManifestFile string
 	SkipPrompts  bool
 
-	region   string
-	cwd      string
-	manifest *config.Manifest
+	region       string
+	cwd          string
+	manifest     *config.Manifest
+	localSecrets *config.LocalSecretStore
 }
 
 func NewCmdDebug(f cmdutil.Factory) *cobra.Command {
 	opts := &Options{
-		Factory: f,
+		Factory:      f,
+		localSecrets: config.NewDefaultLocalSecretStore(),
 	}
 
 	cmd := &cobra.Command{
@@ -93,7 +93,9 @@ func NewCmdDebug(f cmdutil.Factory) *cobra.Command {
 
 			ENVIRONMENT VARIABLES
 			  Environment variables from debug.environment (or instance.environment as fallback)
-			  in your manifest are loaded. For secrets, export them locally before running debug.
+			  in your manifest are loaded. Secret references are resolved from a locally exported
+			  environment variable of the same name, or from the local secret store managed with
+			  'vcr secret local set'.
 
 			CLEANUP
 			  Press Ctrl+C to stop debug mode. The remote debug server is automatically removed
@@ -131,8 +133,8 @@ func NewCmdDebug(f cmdutil.Factory) *cobra.Command {
 			# Use a specific manifest file
 			$ vcr debug --filename ./custom-vcr.yml
 		`),
-		RunE: func(_ *cobra.Command, args []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 			if len(args) > 0 {
 				opts.cwd = args[0]
@@ -220,9 +222,7 @@ func runDebug(ctx context.Context, opts *Options) error {
 	if err != nil {
 		fmt.Fprintf(io.ErrOut, "%s failed to kill debug process: %s\n", c.FailureIcon(), err)
 	}
-	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(opts.Timeout()))
-	defer cancel()
-	if err := opts.DeploymentClient().DeleteDebugService(ctx, resp.ServiceName, opts.PreserveData); err != nil {
+	if err := removeDebugServer(ctx, opts, resp.ServiceName); err != nil {
 		return fmt.Errorf("failed to remove debug server: %w", err)
 	}
 
@@ -230,12 +230,17 @@ func runDebug(ctx context.Context, opts *Options) error {
 	return nil
 }
 
-func injectEnvars(envs []config.Env) error {
+func injectEnvars(envs []config.Env, localSecrets map[string]string) error {
+	var missing []string
 	for _, e := range envs {
 		if e.Secret != "" {
 			value := os.Getenv(e.Secret)
 			if value == "" {
-				return fmt.Errorf("environment variable %q must be exported locally in debug mode", e.Secret)
+				value = localSecrets[e.Secret]
+			}
+			if value == "" {
+				missing = append(missing, strconv.Quote(e.Secret))
+				continue
 			}
 			e.Value = value
 		}
@@ -243,9 +248,22 @@ func injectEnvars(envs []config.Env) error {
 			return fmt.Errorf("%q: %w", e.Name, err)
 		}
 	}
+	if len(missing) > 0 {
+		return fmt.Errorf("secrets %s must be exported locally or stored with 'vcr secret local set' in debug mode", strings.Join(missing, ", "))
+	}
 	return nil
 }
 
+// readLocalSecrets loads the local secret store only when a secret reference is not already exported.
+func readLocalSecrets(store *config.LocalSecretStore, envs []config.Env) (map[string]string, error) {
+	for _, e := range envs {
+		if e.Secret != "" && os.Getenv(e.Secret) == "" {
+			return store.Load()
+		}
+	}
+	return nil, nil
+}
+
 func waitForServiceReady(ctx context.Context, opts *Options, serviceName string) error {
 	intervals := []time.Duration{1, 1, 2, 2, 3, 3, 5, 5, 5, 5, 5, 5, 5, 5}
 	for _, seconds := range intervals {
@@ -261,8 +279,8 @@ func waitForServiceReady(ctx context.Context, opts *Options, serviceName string)
 	return ErrTimeout
 }
 
-func getHTTPAndWebsocketURLs(appName, hostTemplate, websocketPath string) (string, string, string, error) {
-	hostAddress, err := executeHostTemplate(appName, hostTemplate)
+func getHTTPAndWebsocketURLs(appName string, region api.Region, websocketPath string) (string, string, string, error) {
+	hostAddress, err := region.HostURL(appName)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -276,22 +294,6 @@ func getHTTPAndWebsocketURLs(appName, hostTemplate, websocketPath string) (strin
 	return hostAddress, websocketServerURL, proxyWebsocketServerURL, nil
 }
 
-type hostTemplateParams struct {
-	ServiceName string
-}
-
-func executeHostTemplate(serviceName, hostTemplate string) (string, error) {
-	t, err := template.New("host").Parse(hostTemplate)
-	if err != nil {
-		return "", err
-	}
-	buf := bytes.NewBuffer([]byte{})
-	if err := t.Execute(buf, hostTemplateParams{serviceName}); err != nil {
-		return "", err
-	}
-	return buf.String(), nil
-}
-
 func deployDebugServer(ctx context.Context, opts *Options) (api.DeployResponse, error) {
 	io := opts.IOStreams()
 	c := io.ColorScheme()
@@ -338,11 +340,19 @@ func deployDebugServer(ctx context.Context, opts *Options) (api.DeployResponse,
 
 	switch {
 	case len(opts.manifest.Debug.Environment) != 0:
-		if err := injectEnvars(opts.manifest.Debug.Environment); err != nil {
+		localSecrets, err := readLocalSecrets(opts.localSecrets, opts.manifest.Debug.Environment)
+		if err != nil {
+			return api.DeployResponse{}, fmt.Errorf("failed to read local secrets: %w", err)
+		}
+		if err := injectEnvars(opts.manifest.Debug.Environment, localSecrets); err != nil {
 			return api.DeployResponse{}, fmt.Errorf("failed to inject debug environment variables: %w", err)
 		}
 	case len(opts.manifest.Instance.Environment) != 0:
-		if err := injectEnvars(opts.manifest.Instance.Environment); err != nil {
+		localSecrets, err := readLocalSecrets(opts.localSecrets, opts.manifest.Instance.Environment)
+		if err != nil {
+			return api.DeployResponse{}, fmt.Errorf("failed to read local secrets: %w", err)
+		}
+		if err := injectEnvars(opts.manifest.Instance.Environment, localSecrets); err != nil {
 			return api.DeployResponse{}, fmt.Errorf("failed to inject instance environment variables: %w", err)
 		}
 		fmt.Fprintf(io.Out, "%s Debug environment values were not detected in the manifest, the instance environment values were loaded as an alternative. Please consider adding debug environment values\n", c.WarningIcon())
@@ -364,7 +374,7 @@ func deployDebugServer(ctx context.Context, opts *Options) (api.DeployResponse,
 	err = waitForServiceReady(ctx, opts, resp.ServiceName)
 	spinner.Stop()
 	if err != nil {
-		if deleteErr := opts.DeploymentClient().DeleteDebugService(ctx, resp.ServiceName, opts.PreserveData); deleteErr != nil {
+		if deleteErr := removeDebugServer(ctx, opts, resp.ServiceName); deleteErr != nil {
 			fmt.Fprintf(io.ErrOut, "%s failed to deploy debug server: %s\n", c.FailureIcon(), err)
 			return api.DeployResponse{}, fmt.Errorf("failed to remove debug server: %w", deleteErr)
 		}
@@ -383,16 +393,16 @@ func startDebugProxy(ctx context.Context, opts *Options, resp api.DeployResponse
 	region, err := opts.Datastore().GetRegion(ctx, opts.region)
 	spinner.Stop()
 	if err != nil {
-		if deleteErr := opts.DeploymentClient().DeleteDebugService(ctx, resp.ServiceName, opts.PreserveData); deleteErr != nil {
+		if deleteErr := removeDebugServer(ctx, opts, resp.ServiceName); deleteErr != nil {
 			fmt.Fprintf(io.ErrOut, "%s failed to get region: %s\n", c.FailureIcon(), err)
 			return api.Region{}, "", fmt.Errorf("failed to remove debug server: %w", deleteErr)
 		}
 		return api.Region{}, "", fmt.Errorf("failed to get region: %w", err)
 	}
 
-	httpURL, wsURL, proxyWSURL, err := getHTTPAndWebsocketURLs(resp.ServiceName, region.HostTemplate, resp.WebsocketPath)
+	httpURL, wsURL, proxyWSURL, err := getHTTPAndWebsocketURLs(resp.ServiceName, region, resp.WebsocketPath)
 	if err != nil {
-		if deleteErr := opts.DeploymentClient().DeleteDebugService(ctx, resp.ServiceName, opts.PreserveData); deleteErr != nil {
+		if deleteErr := removeDebugServer(ctx, opts, resp.ServiceName); deleteErr != nil {
 			fmt.Fprintf(io.ErrOut, "%s failed to get http and websocket urls: %s\n", c.FailureIcon(), err)
 			return api.Region{}, "", fmt.Errorf("failed to remove debug server: %w", deleteErr)
 		}
@@ -400,9 +410,18 @@ func startDebugProxy(ctx context.Context, opts *Options, resp api.DeployResponse
 	}
 
 	localAppHost := "http://localhost:" + strconv.Itoa(opts.AppPort)
+	transport, err := cmdutil.NewTransport(opts.GlobalOptions())
+	if err != nil {
+		if deleteErr := removeDebugServer(ctx, opts, resp.ServiceName); deleteErr != nil {
+			fmt.Fprintf(io.ErrOut, "%s failed to set up websocket dialer: %s\n", c.FailureIcon(), err)
+			return api.Region{}, "", fmt.Errorf("failed to remove debug server: %w", deleteErr)
+		}
+		return api.Region{}, "", fmt.Errorf("failed to set up websocket dialer: %w", err)
+	}
+	dialer := cmdutil.NewWebsocketDialer(transport, cmdutil.NewHTTPDebugger(opts.GlobalOptions(), io.ErrOut, opts.APISecret()))
 
 	go func() {
-		if err := startDebugProxyServer(resp.ServiceName, localAppHost, httpURL, wsURL, proxyWSURL, opts.DebuggerPort, done); err != nil {
+		if err := startDebugProxyServer(resp.ServiceName, localAppHost, httpURL, wsURL, proxyWSURL, opts.DebuggerPort, dialer, done); err != nil {
 			serverErrStream <- err
 		}
 	}()
@@ -431,7 +450,7 @@ func startApp(ctx context.Context, opts *Options, resp api.DeployResponse, regio
 		region.DebuggerURLScheme,
 	)
 	if err != nil {
-		if deleteErr := opts.DeploymentClient().DeleteDebugService(ctx, resp.ServiceName, opts.PreserveData); deleteErr != nil {
+		if deleteErr := removeDebugServer(ctx, opts, resp.ServiceName); deleteErr != nil {
 			fmt.Fprintf(io.ErrOut, "%s failed to generate process command: %s\n", c.FailureIcon(), err)
 			return nil, fmt.Errorf("failed to remove debug server: %w", deleteErr)
 		}
@@ -439,7 +458,7 @@ func startApp(ctx context.Context, opts *Options, resp api.DeployResponse, regio
 	}
 	command := cmdGenerator.generateCmd()
 	if err := command.Start(); err != nil {
-		if deleteErr := opts.DeploymentClient().DeleteDebugService(ctx, resp.ServiceName, opts.PreserveData); deleteErr != nil {
+		if deleteErr := removeDebugServer(ctx, opts, resp.ServiceName); deleteErr != nil {
 			fmt.Fprintf(io.ErrOut, "%s failed to run local debug process: %s\n", c.FailureIcon(), err)
 			return nil, fmt.Errorf("failed to remove debug server: %w", deleteErr)
 		}
@@ -448,6 +467,21 @@ func startApp(ctx context.Context, opts *Options, resp api.DeployResponse, regio
 	return command, nil
 }
 
+// removeDebugServer removes the debug server once debugging is over or has failed. It does not give up
+// when the command is interrupted, and reports the server as left behind when it can't be removed.
+func removeDebugServer(ctx context.Context, opts *Options, serviceName string) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	ctx, cancel := context.WithDeadline(context.WithoutCancel(ctx), time.Now().Add(opts.Timeout()))
+	defer cancel()
+	if err := opts.DeploymentClient().DeleteDebugService(ctx, serviceName, opts.PreserveData); err != nil {
+		fmt.Fprintf(io.ErrOut, "%s Debug server left behind: service_name=%q, remove it with 'vcr debug prune-sessions'\n", c.WarningIcon(), serviceName)
+		return err
+	}
+	return nil
+}
+
 func getPreserveDataArg(manifestValue, flagValue bool) bool {
 	if flagValue {
 		return true
@@ -3,6 +3,7 @@ package debug
 import (
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
 
 	"github.com/cli/cli/v2/pkg/iostreams"
@@ -310,9 +311,11 @@ func Test_stringVarFromManifest(t *testing.T) {
 
 func Test_injectEnvars(t *testing.T) {
 	tests := []struct {
-		name    string
-		envs    []config.Env
-		wantErr bool
+		name         string
+		envs         []config.Env
+		localSecrets map[string]string
+		wantErr      bool
+		errMsg       string
 	}{
 		{
 			name: "Test with secret environment variable not set",
@@ -346,6 +349,37 @@ func Test_injectEnvars(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Test with secret from local store",
+			envs: []config.Env{
+				{
+					Name:   "TEST_ENV",
+					Secret: "LOCAL_SECRET_ENV",
+				},
+			},
+			localSecrets: map[string]string{"LOCAL_SECRET_ENV": "local value"},
+			wantErr:      false,
+		},
+		{
+			name: "Test with several missing secrets",
+			envs: []config.Env{
+				{
+					Name:   "FIRST_ENV",
+					Secret: "FIRST_SECRET",
+				},
+				{
+					Name:  "PLAIN_ENV",
+					Value: "plain",
+				},
+				{
+					Name:   "SECOND_ENV",
+					Secret: "SECOND_SECRET",
+				},
+			},
+			localSecrets: map[string]string{"OTHER_SECRET": "value"},
+			wantErr:      true,
+			errMsg:       "secrets \"FIRST_SECRET\", \"SECOND_SECRET\" must be exported locally or stored with 'vcr secret local set' in debug mode",
+		},
 	}
 
 	for _, tt := range tests {
@@ -354,9 +388,32 @@ func Test_injectEnvars(t *testing.T) {
 				t.Setenv("SECRET_ENV", "secret value")
 			}
 
-			if err := injectEnvars(tt.envs); (err != nil) != tt.wantErr {
+			err := injectEnvars(tt.envs, tt.localSecrets)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("injectEnvars() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.errMsg != "" {
+				require.EqualError(t, err, tt.errMsg)
+			}
 		})
 	}
 }
+
+func Test_readLocalSecrets(t *testing.T) {
+	tempDir := t.TempDir()
+	store := config.NewLocalSecretStore(filepath.Join(tempDir, "secrets.enc"), filepath.Join(tempDir, "secrets.key"), "")
+	require.NoError(t, store.Set("STORED_SECRET", "stored value"))
+
+	secrets, err := readLocalSecrets(nil, []config.Env{{Name: "PLAIN_ENV", Value: "plain"}})
+	require.NoError(t, err)
+	require.Nil(t, secrets)
+
+	t.Setenv("EXPORTED_SECRET", "exported value")
+	secrets, err = readLocalSecrets(nil, []config.Env{{Name: "TEST_ENV", Secret: "EXPORTED_SECRET"}})
+	require.NoError(t, err)
+	require.Nil(t, secrets)
+
+	secrets, err = readLocalSecrets(store, []config.Env{{Name: "TEST_ENV", Secret: "STORED_SECRET"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"STORED_SECRET": "stored value"}, secrets)
+}
@@ -20,8 +20,8 @@ func NewCmdPruneSessions(f cmdutil.Factory) *cobra.Command {
 		Use:   "prune-sessions",
 		Short: "Remove all active debug sessions",
 		Long:  "Remove all active debug sessions for the configured API key.",
-		RunE: func(_ *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 			return runPruneSessions(ctx, opts)
 		},
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"path"
 	"time"
+
+	"vonage-cloud-runtime-cli/pkg/api"
 )
 
 const (
@@ -15,8 +17,8 @@ const (
 	shutdownTimeoutSeconds  = 5
 )
 
-func startDebugProxyServer(appName, localAppHost, hostAddress, websocketServerURL string, proxyWebsocketServerURL string, port int, done <-chan struct{}) error {
-	connClient := NewDebuggerConnectionClient(websocketServerURL, proxyWebsocketServerURL, localAppHost)
+func startDebugProxyServer(appName, localAppHost, hostAddress, websocketServerURL string, proxyWebsocketServerURL string, port int, dialer *api.WebsocketDialer, done <-chan struct{}) error {
+	connClient := NewDebuggerConnectionClient(websocketServerURL, proxyWebsocketServerURL, localAppHost, dialer)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -84,7 +84,7 @@ func Test_startDebugProxyServer(t *testing.T) {
 	defer close(done)
 
 	go func() {
-		if err := startDebugProxyServer("app-name", mockLocalAppHost, "host-address", mockWebsocketURL, "", 9027, done); err != nil {
+		if err := startDebugProxyServer("app-name", mockLocalAppHost, "host-address", mockWebsocketURL, "", 9027, nil, done); err != nil {
 			fmt.Println("Error starting debug proxy server")
 		}
 	}()
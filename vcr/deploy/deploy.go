@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -20,6 +21,9 @@ import (
 	"vonage-cloud-runtime-cli/pkg/format"
 )
 
+// buildLogDir is where the build logs are saved.
+var buildLogDir = config.DefaultBuildLogDir
+
 var (
 	skipFiles = map[string]bool{
 		".jfs.config":    true,
@@ -37,6 +41,7 @@ type Options struct {
 	Capabilities              string
 	CapabilitiesParsed        api.Capabilities
 	TgzFile                   string
+	PackageID                 string
 
 	cwd          string
 	ManifestFile string
@@ -144,6 +149,21 @@ func NewCmdDeploy(f cmdutil.Factory) *cobra.Command {
 			       @app.route('/_/health')
 			       def health(): return 'OK', 200
 
+			INTERRUPTING A DEPLOYMENT
+			  Press Ctrl+C to stop a deployment. The CLI then reports what it left
+			  behind, e.g. a package that was built but not deployed. Deploy such a
+			  package without uploading and building it again with --package-id.
+
+			RUNTIMES
+			  Before uploading, the runtime is checked against the runtimes of the
+			  platform: an unknown runtime stops the deployment, and a deprecated one is
+			  reported with what to use instead. See 'vcr runtime list'.
+
+			BUILD LOGS
+			  The build of your application is shown phase by phase, and its full log is
+			  saved to ~/.vcr-cli.d/builds/<package_id>.log. When the build fails, the CLI
+			  shows the failing phase with the last lines of the log.
+
 			IGNORING FILES
 			  Create a .vcrignore file to exclude files from deployment (similar to .gitignore).
 			  Common exclusions: node_modules/, .git/, *.log, .env
@@ -195,19 +215,26 @@ func NewCmdDeploy(f cmdutil.Factory) *cobra.Command {
 			# Deploy a pre-compressed tarball
 			$ vcr deploy --tgz ./my-app.tar.gz
 
+			# Deploy a package that was already built
+			$ vcr deploy --package-id 12345678-1234-1234-1234-123456789abc
+
 			# Use a custom manifest file
 			$ vcr deploy --filename ./custom-manifest.yml
 
 			# Override capabilities
 			$ vcr deploy --capabilities "messages-v1,voice"
 		`),
-		RunE: func(_ *cobra.Command, args []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 			if len(args) > 0 {
 				opts.cwd = args[0]
 			}
 
+			if err := cmdutil.MutuallyExclusive("specify only one of --tgz or --package-id", opts.TgzFile != "", opts.PackageID != ""); err != nil {
+				return err
+			}
+
 			absPath, err := config.GetAbsDir(opts.cwd)
 			if err != nil {
 				return fmt.Errorf("failed to get absolute path of %q: %w", opts.cwd, err)
@@ -224,14 +251,23 @@ func NewCmdDeploy(f cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringVarP(&opts.Capabilities, "capabilities", "c", "", "Comma-separated capabilities: messages-v1,voice,rtc (overrides manifest)")
 	cmd.Flags().StringVarP(&opts.TgzFile, "tgz", "z", "", "Path to pre-compressed tar.gz file to deploy (skips local compression)")
 	cmd.Flags().StringVarP(&opts.ManifestFile, "filename", "f", "", "Path to manifest file (default: vcr.yml in project directory)")
+	cmd.Flags().StringVarP(&opts.PackageID, "package-id", "", "", "ID of a package that was already built, to deploy without uploading and building the source code")
 	return cmd
 }
 
-func runDeploy(ctx context.Context, opts *Options) error {
+func runDeploy(ctx context.Context, opts *Options) (err error) {
 	io := opts.IOStreams()
 	c := io.ColorScheme()
 
-	var err error
+	// leftBehind describes the state of the platform once part of the deployment is done, which is
+	// reported when it is interrupted so that the user can resume it
+	var leftBehind string
+	defer func() {
+		if err != nil && leftBehind != "" && cmdutil.Interrupted(ctx) {
+			fmt.Fprintf(io.ErrOut, "%s Deployment interrupted, %s\n", c.WarningIcon(), leftBehind)
+		}
+	}()
+
 	opts.ManifestFile, err = config.FindManifestFile(opts.ManifestFile, opts.cwd)
 	if err != nil {
 		return err
@@ -269,18 +305,30 @@ func runDeploy(ctx context.Context, opts *Options) error {
 		return err
 	}
 
-	uploadResp, err := uploadSourceCode(ctx, opts)
-	if err != nil {
-		return err
-	}
+	createPkgResp := api.CreatePackageResponse{PackageID: opts.PackageID}
+	if opts.PackageID == "" {
+		if err := checkRuntime(ctx, opts); err != nil {
+			return err
+		}
+		uploadResp, err := uploadSourceCode(ctx, opts)
+		if err != nil {
+			return err
+		}
+		leftBehind = fmt.Sprintf("source code uploaded but not packaged: source_code_key=%q", uploadResp.SourceCodeKey)
 
-	createPkgResp, err := createPackage(ctx, opts, uploadResp)
-	if err != nil {
-		return err
+		createPkgResp, err = createPackage(ctx, opts, uploadResp)
+		if err != nil {
+			if createPkgResp.PackageID != "" {
+				leftBehind = fmt.Sprintf("package created but its build may still be running: package_id=%q", createPkgResp.PackageID)
+			}
+			return err
+		}
 	}
+	leftBehind = fmt.Sprintf("package built but not deployed: package_id=%q", createPkgResp.PackageID)
 
 	deploymentResponse, err := Deploy(ctx, opts, createPkgResp)
 	if err != nil {
+		leftBehind = fmt.Sprintf("the deployment of package_id=%q may still be in progress, check it with 'vcr instance list'", createPkgResp.PackageID)
 		return err
 	}
 
@@ -326,7 +374,7 @@ func tgzUpload(ctx context.Context, opts *Options) (api.UploadResponse, error) {
 	}
 
 	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Compressing files...")
-	fileCount, tgzBytes, messages, err := compressDir(".")
+	fileCount, tgzBytes, messages, err := compressDir(ctx, ".")
 	spinner.Stop()
 	if err != nil {
 		return api.UploadResponse{}, fmt.Errorf("failed to compress directory %q: %w", dir, err)
@@ -375,7 +423,7 @@ func readTgzUpload(ctx context.Context, opts *Options) (api.UploadResponse, erro
 	return upload, nil
 }
 
-func compressDir(source string) (int, []byte, []string, error) {
+func compressDir(ctx context.Context, source string) (int, []byte, []string, error) {
 	enableIgnoreCheck := true
 	vcrIgnore, err := vcrIgnore.CompileIgnoreFile(".vcrignore")
 	if err != nil {
@@ -430,7 +478,7 @@ func compressDir(source string) (int, []byte, []string, error) {
 	}
 
 	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Compressing files...")
-	err = format.Archive(context.Background(), out, files)
+	err = format.Archive(ctx, out, files)
 	spinner.Stop()
 	if err != nil {
 		return 0, nil, nil, err
@@ -482,6 +530,34 @@ func createProject(ctx context.Context, opts *Options) (string, error) {
 	return projectID, nil
 }
 
+// checkRuntime makes sure the runtime exists before the source code is uploaded, and warns when it
+// is deprecated. The runtimes are usually in the metadata cache, so this costs no request.
+func checkRuntime(ctx context.Context, opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	name, err := cmdutil.StringVar("runtime", opts.Runtime, opts.manifest.Instance.Runtime, "", true)
+	if err != nil {
+		return fmt.Errorf("failed to get runtime: %w", err)
+	}
+	runtime, err := opts.Datastore().GetRuntimeByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("runtime %q not found, see 'vcr runtime list' for the available runtimes", name)
+		}
+		return fmt.Errorf("failed to get runtime %q: %w", name, err)
+	}
+	if !runtime.Deprecated() {
+		return nil
+	}
+	if note := runtime.DeprecationNote(); note != "" {
+		fmt.Fprintf(io.ErrOut, "%s Runtime %q is deprecated: %s\n", c.WarningIcon(), name, note)
+	} else {
+		fmt.Fprintf(io.ErrOut, "%s Runtime %q is deprecated, see 'vcr runtime list' for the supported runtimes\n", c.WarningIcon(), name)
+	}
+	return nil
+}
+
 func uploadSourceCode(ctx context.Context, opts *Options) (api.UploadResponse, error) {
 	io := opts.IOStreams()
 	c := io.ColorScheme()
@@ -539,10 +615,17 @@ func createPackage(ctx context.Context, opts *Options, uploadResp api.UploadResp
 
 	fmt.Fprintf(io.Out, "%s Package created: package_id=%q\n", c.SuccessIcon(), createPkgResp.PackageID)
 
+	buildLog, buildLogPath := openBuildLog(opts, createPkgResp.PackageID)
+	defer buildLog.Close()
+
 	fmt.Fprintf(io.Out, "%s Waiting for build to start...\n", c.Blue(cmdutil.InfoIcon))
-	err = opts.DeploymentClient().WatchDeployment(ctx, opts.IOStreams(), createPkgResp.PackageID)
+	err = opts.DeploymentClient().WatchDeployment(ctx, opts.IOStreams(), createPkgResp.PackageID, buildLog)
 	if err != nil {
-		return api.CreatePackageResponse{}, fmt.Errorf("failed to watch deployment for package_id=%q: %w", createPkgResp.PackageID, err)
+		if buildLogPath != "" {
+			fmt.Fprintf(io.ErrOut, "%s Build log saved to %s\n", c.Blue(cmdutil.InfoIcon), buildLogPath)
+		}
+		// the package is returned so that the caller can report it
+		return createPkgResp, fmt.Errorf("failed to watch deployment for package_id=%q: %w", createPkgResp.PackageID, err)
 	}
 
 	fmt.Fprintf(io.Out, "%s Package %q built successfully\n", c.SuccessIcon(), createPkgResp.PackageID)
@@ -550,6 +633,24 @@ func createPackage(ctx context.Context, opts *Options, uploadResp api.UploadResp
 	return createPkgResp, nil
 }
 
+// openBuildLog creates the file the build log of a package is saved to, and returns it with its path.
+// When the file can't be created the build log is discarded, and the path is empty.
+func openBuildLog(opts *Options, packageID string) (io.WriteCloser, string) {
+	file, path, err := config.CreateBuildLog(buildLogDir, packageID)
+	if err != nil {
+		ios := opts.IOStreams()
+		fmt.Fprintf(ios.ErrOut, "%s The build log won't be saved: %s\n", ios.ColorScheme().WarningIcon(), err)
+		return nopWriteCloser{io.Discard}, ""
+	}
+	return file, path
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
 func validateDeployment(ctx context.Context, opts *Options) error {
 	io := opts.IOStreams()
 	c := io.ColorScheme()
@@ -2,7 +2,10 @@ package deploy
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/cli/cli/v2/pkg/iostreams"
@@ -11,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
 	"vonage-cloud-runtime-cli/pkg/config"
 	"vonage-cloud-runtime-cli/testutil"
 	"vonage-cloud-runtime-cli/testutil/mocks"
@@ -29,6 +33,10 @@ func TestDeploy(t *testing.T) {
 		DeployReturnProjectCreateResponse api.CreateProjectResponse
 		DeployCreateProjectReturnErr      error
 
+		DeployGetRuntimeTimes     int
+		DeployReturnRuntime       api.Runtime
+		DeployGetRuntimeReturnErr error
+
 		DeployValidateDeploymentReq        api.ValidateDeploymentRequest
 		DeployValidateDeploymentTimes      int
 		DeployReturnValidateDeploymentResp api.ValidateDeploymentResponse
@@ -100,6 +108,9 @@ func TestDeploy(t *testing.T) {
 				DeployReturnReadUploadResponse: api.UploadResponse{SourceCodeKey: "test-key"},
 				DeployReadUploadTgzReturnErr:   nil,
 
+				DeployGetRuntimeTimes: 1,
+				DeployReturnRuntime:   api.Runtime{Name: "nodejs16", Language: "nodejs"},
+
 				DeployUploadTgzTimes:       1,
 				DeployReturnUploadResponse: api.UploadResponse{SourceCodeKey: "test-key"},
 				DeployUploadTgzReturnErr:   nil,
@@ -159,6 +170,9 @@ func TestDeploy(t *testing.T) {
 				DeployReturnReadUploadResponse: api.UploadResponse{SourceCodeKey: "test-key"},
 				DeployReadUploadTgzReturnErr:   nil,
 
+				DeployGetRuntimeTimes: 1,
+				DeployReturnRuntime:   api.Runtime{Name: "nodejs16", Language: "nodejs"},
+
 				DeployUploadTgzTimes:       1,
 				DeployReturnUploadResponse: api.UploadResponse{SourceCodeKey: "test-key"},
 				DeployUploadTgzReturnErr:   nil,
@@ -217,6 +231,9 @@ func TestDeploy(t *testing.T) {
 				DeployReturnReadUploadResponse: api.UploadResponse{SourceCodeKey: "test-key"},
 				DeployReadUploadTgzReturnErr:   nil,
 
+				DeployGetRuntimeTimes: 1,
+				DeployReturnRuntime:   api.Runtime{Name: "nodejs16", Language: "nodejs"},
+
 				DeployUploadTgzTimes:       1,
 				DeployReturnUploadResponse: api.UploadResponse{SourceCodeKey: "test-key"},
 				DeployUploadTgzReturnErr:   nil,
@@ -283,6 +300,9 @@ func TestDeploy(t *testing.T) {
 				DeployReturnReadUploadResponse: api.UploadResponse{SourceCodeKey: "test-key"},
 				DeployReadUploadTgzReturnErr:   nil,
 
+				DeployGetRuntimeTimes: 1,
+				DeployReturnRuntime:   api.Runtime{Name: "nodejs16", Language: "nodejs"},
+
 				DeployUploadTgzTimes:       1,
 				DeployReturnUploadResponse: api.UploadResponse{SourceCodeKey: "test-key"},
 				DeployUploadTgzReturnErr:   nil,
@@ -342,6 +362,9 @@ func TestDeploy(t *testing.T) {
 				DeployReturnReadUploadResponse: api.UploadResponse{SourceCodeKey: "test-key"},
 				DeployReadUploadTgzReturnErr:   nil,
 
+				DeployGetRuntimeTimes: 1,
+				DeployReturnRuntime:   api.Runtime{Name: "nodejs16", Language: "nodejs"},
+
 				DeployUploadTgzTimes:       1,
 				DeployReturnUploadResponse: api.UploadResponse{SourceCodeKey: "test-key"},
 				DeployUploadTgzReturnErr:   nil,
@@ -420,9 +443,77 @@ func TestDeploy(t *testing.T) {
 				errMsg: "Deployment validation failed:\n  - region: region not found\n  - apiApplicationId: credentials not found for application",
 			},
 		},
+		{
+			name: "deprecated-runtime",
+			cli:  "testdata/",
+			mock: mock{
+				DeployAPIKey:             testutil.DefaultAPIKey,
+				DeployGetProjectProjName: "test",
+				DeployGetProjectTimes:    1,
+				DeployReturnProject:      api.Project{ID: "id", Name: "test-project"},
+
+				DeployValidateDeploymentReq:        defaultValidateReq,
+				DeployValidateDeploymentTimes:      1,
+				DeployReturnValidateDeploymentResp: api.ValidateDeploymentResponse{Valid: true},
+
+				DeployGetRuntimeTimes: 1,
+				DeployReturnRuntime:   api.Runtime{Name: "nodejs16", Language: "nodejs", Comments: "deprecated, use nodejs22"},
+
+				DeployUploadTgzTimes:       1,
+				DeployReturnUploadResponse: api.UploadResponse{SourceCodeKey: "test-key"},
+
+				DeployCreatePackageArgs: api.CreatePackageArgs{
+					SourceCodeKey: "test-key",
+					Entrypoint:    []string{"node", "index.js"},
+					Capabilities:  api.Capabilities{Messages: "v1"},
+					Runtime:       "nodejs16",
+				},
+				DeployCreatePackageTimes:          1,
+				DeployReturnCreatePackageResponse: api.CreatePackageResponse{PackageID: "test-package-id"},
+
+				DeployWatchDeploymentPackageID: "test-package-id",
+				DeployWatchDeploymentTimes:     1,
+
+				DeployDeployInstanceArgs: api.DeployInstanceArgs{
+					ProjectID:        "id",
+					PackageID:        "test-package-id",
+					APIApplicationID: "0f39f387-579b-4259-9f76-2715ff73b8b7",
+					InstanceName:     "dev",
+					Region:           "eu-west-1",
+					Environment:      []config.Env{{Name: "test-env-name", Value: "test-env-value"}},
+				},
+				DeployDeployInstanceTimes:          1,
+				DeployReturnDeployInstanceResponse: api.DeployInstanceResponse{InstanceID: "test-instance-id", ServiceName: "test-service-name", DeploymentID: "test-deployment-id", HostURLs: []string{"test-host-url"}},
+			},
+			want: want{
+				stderr: "! Runtime \"nodejs16\" is deprecated: use nodejs22\n",
+			},
+		},
+		{
+			name: "runtime-not-found",
+			cli:  "testdata/",
+			mock: mock{
+				DeployAPIKey:             testutil.DefaultAPIKey,
+				DeployGetProjectProjName: "test",
+				DeployGetProjectTimes:    1,
+				DeployReturnProject:      api.Project{ID: "id", Name: "test-project"},
+
+				DeployValidateDeploymentReq:        defaultValidateReq,
+				DeployValidateDeploymentTimes:      1,
+				DeployReturnValidateDeploymentResp: api.ValidateDeploymentResponse{Valid: true},
+
+				DeployGetRuntimeTimes:     1,
+				DeployGetRuntimeReturnErr: api.ErrNotFound,
+			},
+			want: want{
+				errMsg: "runtime \"nodejs16\" not found, see 'vcr runtime list' for the available runtimes",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			buildLogDir = t.TempDir()
+
 			ctrl := gomock.NewController(t)
 			surveyMock := mocks.NewMockSurveyInterface(ctrl)
 			deploymentMock := mocks.NewMockDeploymentInterface(ctrl)
@@ -433,6 +524,10 @@ func TestDeploy(t *testing.T) {
 				Times(tt.mock.DeployGetProjectTimes).
 				Return(tt.mock.DeployReturnProject, tt.mock.DeployGetProjectReturnErr)
 
+			datastoreMock.EXPECT().GetRuntimeByName(gomock.Any(), "nodejs16").
+				Times(tt.mock.DeployGetRuntimeTimes).
+				Return(tt.mock.DeployReturnRuntime, tt.mock.DeployGetRuntimeReturnErr)
+
 			deploymentMock.EXPECT().CreateProject(gomock.Any(), tt.mock.DeployCreateProjectProjName).
 				Times(tt.mock.DeployCreateProjectTimes).
 				Return(tt.mock.DeployReturnProjectCreateResponse, tt.mock.DeployCreateProjectReturnErr)
@@ -453,7 +548,7 @@ func TestDeploy(t *testing.T) {
 				Times(tt.mock.DeployCreatePackageTimes).
 				Return(tt.mock.DeployReturnCreatePackageResponse, tt.mock.DeployCreatePackageReturnErr)
 
-			deploymentMock.EXPECT().WatchDeployment(gomock.Any(), gomock.Any(), tt.mock.DeployWatchDeploymentPackageID).
+			deploymentMock.EXPECT().WatchDeployment(gomock.Any(), gomock.Any(), tt.mock.DeployWatchDeploymentPackageID, gomock.Any()).
 				Times(tt.mock.DeployWatchDeploymentTimes).
 				Return(tt.mock.DeployWatchDeploymentReturnErr)
 
@@ -495,3 +590,129 @@ func TestDeploy(t *testing.T) {
 		})
 	}
 }
+
+func TestDeployInterrupted(t *testing.T) {
+	validateReq := api.ValidateDeploymentRequest{
+		ProjectID:        "id",
+		APIApplicationID: "0f39f387-579b-4259-9f76-2715ff73b8b7",
+		InstanceName:     "dev",
+		Region:           "eu-west-1",
+		Environment:      []config.Env{{Name: "test-env-name", Value: "test-env-value"}},
+	}
+
+	type mock struct {
+		UploadTimes        int
+		WatchInterrupted   bool
+		DeployTimes        int
+		DeployInterrupted  bool
+		DeployInstanceResp api.DeployInstanceResponse
+	}
+	type want struct {
+		errMsg string
+		stderr string
+	}
+
+	tests := []struct {
+		name string
+		cli  string
+		mock mock
+		want want
+	}{
+		{
+			name: "during-build",
+			cli:  "testdata/",
+			mock: mock{UploadTimes: 1, WatchInterrupted: true},
+			want: want{
+				errMsg: "failed to watch deployment for package_id=\"test-package-id\": stopped watching the build: context canceled",
+				stderr: "ℹ Build log saved to %s/test-package-id.log\n! Deployment interrupted, package created but its build may still be running: package_id=\"test-package-id\"\n",
+			},
+		},
+		{
+			name: "during-deploy",
+			cli:  "testdata/",
+			mock: mock{UploadTimes: 1, DeployTimes: 1, DeployInterrupted: true},
+			want: want{
+				errMsg: "failed to deploy instance: context canceled",
+				stderr: "! Deployment interrupted, the deployment of package_id=\"test-package-id\" may still be in progress, check it with 'vcr instance list'\n",
+			},
+		},
+		{
+			name: "resume-with-package-id",
+			cli:  "testdata/ --package-id test-package-id",
+			mock: mock{DeployTimes: 1, DeployInstanceResp: api.DeployInstanceResponse{InstanceID: "test-instance-id"}},
+		},
+		{
+			name: "package-id-and-tgz",
+			cli:  "testdata/ --package-id test-package-id --tgz testdata/test.tar.gz",
+			want: want{
+				errMsg: "specify only one of --tgz or --package-id",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buildLogDir = t.TempDir()
+			ctx, cancel := context.WithCancelCause(t.Context())
+			defer cancel(nil)
+
+			ctrl := gomock.NewController(t)
+			deploymentMock := mocks.NewMockDeploymentInterface(ctrl)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+
+			datastoreMock.EXPECT().GetProject(gomock.Any(), testutil.DefaultAPIKey, "test").
+				MaxTimes(1).Return(api.Project{ID: "id", Name: "test"}, nil)
+			datastoreMock.EXPECT().GetRuntimeByName(gomock.Any(), "nodejs16").
+				MaxTimes(1).Return(api.Runtime{Name: "nodejs16", Language: "nodejs"}, nil)
+			deploymentMock.EXPECT().ValidateDeployment(gomock.Any(), validateReq).
+				MaxTimes(1).Return(api.ValidateDeploymentResponse{Valid: true}, nil)
+			deploymentMock.EXPECT().UploadTgz(gomock.Any(), gomock.Any()).
+				Times(tt.mock.UploadTimes).Return(api.UploadResponse{SourceCodeKey: "test-key"}, nil)
+			deploymentMock.EXPECT().CreatePackage(gomock.Any(), gomock.Any()).
+				Times(tt.mock.UploadTimes).Return(api.CreatePackageResponse{PackageID: "test-package-id"}, nil)
+			deploymentMock.EXPECT().WatchDeployment(gomock.Any(), gomock.Any(), "test-package-id", gomock.Any()).
+				Times(tt.mock.UploadTimes).
+				DoAndReturn(func(context.Context, *iostreams.IOStreams, string, io.Writer) error {
+					if tt.mock.WatchInterrupted {
+						cancel(cmdutil.ErrInterrupted)
+						return fmt.Errorf("stopped watching the build: %w", context.Canceled)
+					}
+					return nil
+				})
+			deploymentMock.EXPECT().DeployInstance(gomock.Any(), gomock.Any()).
+				Times(tt.mock.DeployTimes).
+				DoAndReturn(func(context.Context, api.DeployInstanceArgs) (api.DeployInstanceResponse, error) {
+					if tt.mock.DeployInterrupted {
+						cancel(cmdutil.ErrInterrupted)
+						return api.DeployInstanceResponse{}, context.Canceled
+					}
+					return tt.mock.DeployInstanceResp, nil
+				})
+
+			ios, _, stdout, stderr := iostreams.Test()
+			argv, err := shlex.Split(tt.cli)
+			require.NoError(t, err)
+
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, datastoreMock, deploymentMock, nil, nil)
+
+			cmd := NewCmdDeploy(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteContextC(ctx)
+			if strings.Contains(tt.want.stderr, "%s") {
+				tt.want.stderr = fmt.Sprintf(tt.want.stderr, buildLogDir)
+			}
+			require.Equal(t, tt.want.stderr, stderr.String())
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, stdout.String(), "Instance id: test-instance-id")
+			require.NotContains(t, stdout.String(), "Source code uploaded")
+		})
+	}
+}
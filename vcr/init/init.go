@@ -1,9 +1,7 @@
 package init
 
 import (
-	"archive/tar"
 	"bytes"
-	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -11,12 +9,16 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
 	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/archive"
 	"vonage-cloud-runtime-cli/pkg/cmdutil"
 	"vonage-cloud-runtime-cli/pkg/config"
 	"vonage-cloud-runtime-cli/pkg/format"
@@ -24,6 +26,24 @@ import (
 
 const defaultRuntime = "nodejs18"
 
+// templateCacheDir is where downloaded templates are cached, see 'vcr template'.
+var templateCacheDir = config.DefaultTemplateCacheDir
+
+// Answers holds the values given with flags or an answers file instead of prompting.
+// The answers file uses the flag names as keys.
+type Answers struct {
+	ProjectName  string            `yaml:"project-name"`
+	InstanceName string            `yaml:"instance-name"`
+	Runtime      string            `yaml:"runtime"`
+	Region       string            `yaml:"region"`
+	AppID        string            `yaml:"app-id"`
+	DebugAppID   string            `yaml:"debug-app-id"`
+	Template     string            `yaml:"template"`
+	CreateApp    string            `yaml:"create-app"`
+	OnConflict   string            `yaml:"on-conflict"`
+	Vars         map[string]string `yaml:"var"`
+}
+
 type Options struct {
 	cmdutil.Factory
 
@@ -32,6 +52,10 @@ type Options struct {
 	manifestFilePath         string
 	templateManifestFilePath string
 	programmingLang          string
+
+	answers     Answers
+	answersFile string
+	yes         bool
 }
 
 func NewCmdInit(f cmdutil.Factory) *cobra.Command {
@@ -54,6 +78,13 @@ func NewCmdInit(f cmdutil.Factory) *cobra.Command {
 			  • Debug Application   - The Vonage application for debug mode
 			  • Template            - A starter template for your chosen runtime
 
+			EXISTING FILES
+			  Template files are never written outside of the project directory. If some of
+			  them already exist, you are asked whether to abort, overwrite the existing
+			  files, skip them, or keep both by adding ".template" to the name of the
+			  template files, e.g. index.template.js. Use --on-conflict to choose without
+			  a prompt.
+
 			OUTPUT
 			  A vcr.yml manifest file is created with your configuration. This file defines
 			  how your application is built and deployed to the VCR platform.
@@ -65,9 +96,48 @@ func NewCmdInit(f cmdutil.Factory) *cobra.Command {
 			  • Messaging applications
 			  • Real-time communication apps
 
+			  Downloaded templates are cached, and --template also accepts a template
+			  pulled earlier with 'vcr template pull'. When the marketplace cannot be
+			  reached, the cached version of the chosen template is used.
+
+			  Besides the product templates, --template accepts a local directory or
+			  tar.gz archive (e.g. ./my-template), a git repository (git+https://host/repo.git,
+			  optionally followed by #branch or #tag), or an https URL to a tar.gz archive.
+
+			  A template.yml at the root of a template declares prompts and variables:
+			    prompts:
+			      - name: greeting
+			        message: Greeting to display
+			        default: Hello
+			    variables:
+			      team: platform
+			    render:
+			      - vcr.yml
+			      - "src/*.js"
+			  The files matching the render patterns, by path or by name, are Go templates
+			  that can use {{ .ProjectName }}, {{ .InstanceName }}, {{ .Runtime }},
+			  {{ .Region }}, the variables and the prompts, e.g. {{ .greeting }}. The other
+			  files, and binary ones, are copied as is. Answer prompts with --var
+			  or the "var" key of the answers file. The answers are merged into the
+			  template's vcr.yml.
+
 			PROJECT NAME REQUIREMENTS
 			  • Must contain only lowercase letters, numbers, and hyphens
 			  • Must start and end with an alphanumeric character
+
+			NON-INTERACTIVE USE
+			  Every prompt can be answered with a flag instead, or with an answers file
+			  given with --answers. The file is YAML and uses the flag names as keys; flags
+			  take precedence over it. The region comes from the global --region flag or the
+			  "region" key.
+
+			  --app-id, --debug-app-id and --template accept "skip" to leave them unset,
+			  and --create-app creates a new Vonage application for deployment.
+
+			  With --yes, questions without a value take their default: the directory
+			  name as project name, "dev", nodejs18, the default region, and no
+			  applications or template. Without a terminal and without --yes, a missing
+			  value is an error.
 		`),
 		Args: cobra.MaximumNArgs(1),
 		Example: heredoc.Doc(`
@@ -87,11 +157,37 @@ func NewCmdInit(f cmdutil.Factory) *cobra.Command {
 
 			# Initialize using the short alias
 			$ vcr i my-project
+
+			# Initialize without prompts, e.g. in a script
+			$ vcr init my-project --project-name my-project --instance-name dev --runtime nodejs18 \
+			    --region aws.euw1 --app-id 42066b10-c4ae-48a0-addd-feb2bd615a67 --debug-app-id skip --template skip
+
+			# Initialize from an internal starter project on a git tag
+			$ vcr init my-project --template git+https://github.com/my-org/vcr-starter.git#v1.2.0 --var greeting=hi
+
+			# Initialize from an answers file, using defaults for anything it leaves out
+			$ cat answers.yml
+			project-name: my-project
+			runtime: nodejs18
+			region: aws.euw1
+			create-app: my-project-app
+			$ vcr init my-project --answers answers.yml --yes
 		`),
-		RunE: func(_ *cobra.Command, args []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 
+			// the global --region flag answers the region question, while $VCR_REGION is only its default
+			if cmd.Flags().Changed("region") {
+				opts.answers.Region = opts.GlobalOptions().Region
+			}
+			if err := cmdutil.MutuallyExclusive("specify only one of --app-id or --create-app", opts.answers.AppID != "", opts.answers.CreateApp != ""); err != nil {
+				return err
+			}
+			if err := loadAnswers(opts); err != nil {
+				return err
+			}
+
 			if len(args) > 0 {
 				opts.cwd = args[0]
 			}
@@ -109,9 +205,89 @@ func NewCmdInit(f cmdutil.Factory) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVarP(&opts.answers.ProjectName, "project-name", "", "", "Project name")
+	cmd.Flags().StringVarP(&opts.answers.InstanceName, "instance-name", "", "", "Instance name, e.g. dev")
+	cmd.Flags().StringVarP(&opts.answers.Runtime, "runtime", "", "", "Runtime, e.g. nodejs18")
+	cmd.Flags().StringVarP(&opts.answers.AppID, "app-id", "", "", "Vonage application ID for deployment, or \"skip\"")
+	cmd.Flags().StringVarP(&opts.answers.DebugAppID, "debug-app-id", "", "", "Vonage application ID for debug, or \"skip\"")
+	cmd.Flags().StringVarP(&opts.answers.Template, "template", "", "", "Product template name or ID, a local path, git+<url>[#ref], an https tar.gz URL, or \"skip\"")
+	cmd.Flags().StringToStringVarP(&opts.answers.Vars, "var", "", nil, "Answer a prompt of the template's template.yml, e.g. --var greeting=hello")
+	cmd.Flags().StringVarP(&opts.answers.CreateApp, "create-app", "", "", "Create a Vonage application with this name for deployment")
+	cmd.Flags().StringVarP(&opts.answers.OnConflict, "on-conflict", "", "", "What to do with template files that already exist: abort, overwrite, skip or keep-both")
+	cmd.Flags().StringVarP(&opts.answersFile, "answers", "", "", "YAML file with the answers to the prompts, keyed by flag name")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Use the default for every question without an answer instead of prompting")
+
 	return cmd
 }
 
+// loadAnswers reads the answers file, if any, and fills in the values not given as flags.
+func loadAnswers(opts *Options) error {
+	if opts.answersFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(opts.answersFile)
+	if err != nil {
+		return fmt.Errorf("failed to read answers file: %w", err)
+	}
+	var fromFile Answers
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&fromFile); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse answers file %q: %w", opts.answersFile, err)
+	}
+	if fromFile.AppID != "" && fromFile.CreateApp != "" {
+		return fmt.Errorf("answers file %q sets both app-id and create-app, only one can be used", opts.answersFile)
+	}
+
+	a := &opts.answers
+	for _, field := range []struct{ flag, file *string }{
+		{&a.ProjectName, &fromFile.ProjectName},
+		{&a.InstanceName, &fromFile.InstanceName},
+		{&a.Runtime, &fromFile.Runtime},
+		{&a.Region, &fromFile.Region},
+		{&a.AppID, &fromFile.AppID},
+		{&a.DebugAppID, &fromFile.DebugAppID},
+		{&a.Template, &fromFile.Template},
+		{&a.CreateApp, &fromFile.CreateApp},
+		{&a.OnConflict, &fromFile.OnConflict},
+	} {
+		if *field.flag == "" {
+			*field.flag = *field.file
+		}
+	}
+	for name, value := range fromFile.Vars {
+		if _, ok := a.Vars[name]; !ok {
+			if a.Vars == nil {
+				a.Vars = make(map[string]string)
+			}
+			a.Vars[name] = value
+		}
+	}
+	// an app ID given as a flag wins over create-app from the file, and the other way around
+	if a.AppID != "" && a.CreateApp != "" {
+		if fromFile.AppID == a.AppID {
+			a.AppID = ""
+		} else {
+			a.CreateApp = ""
+		}
+	}
+	return nil
+}
+
+// canPrompt reports whether a question without an answer can be asked.
+func (o *Options) canPrompt() bool {
+	return o.IOStreams().CanPrompt() && !o.yes
+}
+
+// missingAnswerError is returned when a question has no answer and cannot be asked.
+func missingAnswerError(key string) error {
+	return fmt.Errorf("no %s given and the terminal is not interactive, set --%s, add %q to the answers file or use --yes to accept the default", strings.ReplaceAll(key, "-", " "), key, key)
+}
+
+func isSkip(value string) bool {
+	return strings.EqualFold(value, format.SkipValue)
+}
+
 func runInit(ctx context.Context, opts *Options) error {
 	io := opts.IOStreams()
 	c := io.ColorScheme()
@@ -165,6 +341,19 @@ func askProjectName(opts *Options) error {
 	if projName == "" {
 		projName = filepath.Base(opts.cwd)
 	}
+	if opts.answers.ProjectName != "" || !opts.canPrompt() {
+		switch {
+		case opts.answers.ProjectName != "":
+			projName = opts.answers.ProjectName
+		case !opts.yes:
+			return missingAnswerError("project-name")
+		}
+		if !projNameRe.MatchString(projName) {
+			return fmt.Errorf("project name %q is not correct, project name should be lower case alphanumeric or - characters", projName)
+		}
+		opts.manifest.Project.Name = projName
+		return nil
+	}
 	var err error
 promptProjectName:
 	projName, err = opts.Survey().AskForUserInput("Enter your project name:", projName)
@@ -184,6 +373,20 @@ promptProjectName:
 func askInstanceAppID(ctx context.Context, opts *Options) error {
 	appID := opts.manifest.Instance.ApplicationID
 
+	if opts.answers.CreateApp != "" {
+		var err error
+		opts.manifest.Instance.ApplicationID, err = createApp(ctx, opts, opts.answers.CreateApp)
+		return err
+	}
+	if opts.answers.AppID != "" || !opts.canPrompt() {
+		id, err := answerAppID(ctx, opts, "app-id", opts.answers.AppID)
+		if err != nil {
+			return err
+		}
+		opts.manifest.Instance.ApplicationID = id
+		return nil
+	}
+
 	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving app list... ")
 	apps, err := opts.DeploymentClient().ListVonageApplications(ctx, "")
 	spinner.Stop()
@@ -214,6 +417,15 @@ func askInstanceAppID(ctx context.Context, opts *Options) error {
 func askDebugAppID(ctx context.Context, opts *Options) error {
 	appID := opts.manifest.Debug.ApplicationID
 
+	if opts.answers.DebugAppID != "" || !opts.canPrompt() {
+		id, err := answerAppID(ctx, opts, "debug-app-id", opts.answers.DebugAppID)
+		if err != nil {
+			return err
+		}
+		opts.manifest.Debug.ApplicationID = id
+		return nil
+	}
+
 	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving app list... ")
 	apps, err := opts.DeploymentClient().ListVonageApplications(ctx, "")
 	spinner.Stop()
@@ -248,6 +460,22 @@ func askRuntime(ctx context.Context, opts *Options) error {
 		return err
 	}
 	runtimeOptions := format.GetRuntimeOptions(runtimes)
+	if opts.answers.Runtime != "" || !opts.canPrompt() {
+		runtime := opts.answers.Runtime
+		if runtime == "" {
+			if !opts.yes {
+				return missingAnswerError("runtime")
+			}
+			runtime = defaultRuntime
+		}
+		label, ok := runtimeOptions.Lookup[runtime]
+		if !ok {
+			return fmt.Errorf("runtime %q is not available, must be one of: %s", runtime, strings.Join(sortedKeys(runtimeOptions.Lookup), ", "))
+		}
+		opts.manifest.Instance.Runtime = runtime
+		opts.programmingLang = runtimeOptions.ProgrammingLangLookup[label]
+		return nil
+	}
 	runtimeLabel, err := opts.Survey().AskForUserChoice("Select a runtime:", runtimeOptions.Labels, runtimeOptions.RuntimeLookup, defaultRuntime)
 	if err != nil {
 		return err
@@ -273,6 +501,21 @@ func askRegion(ctx context.Context, opts *Options) error {
 		return err
 	}
 	regionOptions := format.GetRegionOptions(regions)
+	if opts.answers.Region != "" || !opts.canPrompt() {
+		switch {
+		case opts.answers.Region != "":
+			regionAlias = opts.answers.Region
+		case !opts.yes:
+			return missingAnswerError("region")
+		case regionAlias == "":
+			return errors.New("no region given and no default region configured, set --region or add \"region\" to the answers file")
+		}
+		if _, ok := regionOptions.Lookup[regionAlias]; !ok {
+			return fmt.Errorf("region %q does not exist, must be one of: %s", regionAlias, strings.Join(sortedKeys(regionOptions.Lookup), ", "))
+		}
+		opts.manifest.Instance.Region = regionAlias
+		return nil
+	}
 	regionLabel, err := opts.Survey().AskForUserChoice("Select a region:", regionOptions.Labels, regionOptions.AliasLookup, regionOptions.Lookup[regionAlias])
 	if err != nil {
 		return err
@@ -286,6 +529,16 @@ func askInstanceName(opts *Options) error {
 	if instanceName == "" {
 		instanceName = "dev"
 	}
+	if opts.answers.InstanceName != "" || !opts.canPrompt() {
+		switch {
+		case opts.answers.InstanceName != "":
+			instanceName = opts.answers.InstanceName
+		case !opts.yes:
+			return missingAnswerError("instance-name")
+		}
+		opts.manifest.Instance.Name = instanceName
+		return nil
+	}
 
 	instanceName, err := opts.Survey().AskForUserInput("Enter your Instance name:", instanceName)
 	if err != nil {
@@ -299,16 +552,37 @@ func askTemplate(ctx context.Context, opts *Options) error {
 	io := opts.IOStreams()
 	c := io.ColorScheme()
 
+	if isExternalTemplate(opts.answers.Template) {
+		spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving template files... ")
+		template, err := fetchExternalTemplate(ctx, opts, opts.answers.Template)
+		spinner.Stop()
+		if err != nil {
+			return err
+		}
+		return useTemplate(opts, template)
+	}
+
 	programingLang := opts.programmingLang
 
+	cache, err := config.NewTemplateCache(templateCacheDir)
+	if err != nil {
+		return err
+	}
+
 	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving product templates... ")
 	products, err := opts.Datastore().ListProducts(ctx)
 	spinner.Stop()
 	if err != nil {
+		if template, ok := cachedTemplate(opts, cache, opts.answers.Template, err); ok {
+			return useTemplate(opts, template)
+		}
 		return fmt.Errorf("failed to list product templates: %w", err)
 	}
 
 	productTemplates := getProductTemplatesByLang(products, programingLang)
+	if len(productTemplates) == 0 && opts.answers.Template != "" && !isSkip(opts.answers.Template) {
+		return fmt.Errorf("template %q is not available, there are no product templates for runtime %q", opts.answers.Template, opts.manifest.Instance.Runtime)
+	}
 	if len(productTemplates) == 0 {
 		fmt.Fprintf(io.ErrOut, "%s No product templates available for the selected runtime %q\n", c.WarningIcon(), opts.manifest.Instance.Runtime)
 		return nil
@@ -316,38 +590,96 @@ func askTemplate(ctx context.Context, opts *Options) error {
 
 	templateOptions := format.GetTemplateOptions(productTemplates)
 
-	templateLabel, err := opts.Survey().AskForUserChoice(fmt.Sprintf("Select a product template for runtime %s: ", opts.manifest.Instance.Runtime), templateOptions.Labels, templateOptions.IDLookup, "")
-	if err != nil {
-		return fmt.Errorf("failed to ask user to select a product template for runtime %s: %w", opts.manifest.Instance.Runtime, err)
-	}
-	if templateLabel == format.SkipValue {
-		return nil
+	var selectedProductID string
+	if opts.answers.Template != "" || !opts.canPrompt() {
+		switch {
+		case opts.answers.Template == "" && !opts.yes:
+			return missingAnswerError("template")
+		case opts.answers.Template == "" || isSkip(opts.answers.Template):
+			return nil
+		}
+		selectedProductID, err = findTemplate(productTemplates, opts.answers.Template)
+		if err != nil {
+			// e.g. a template of the asset store, pulled with 'vcr template pull'
+			if template, ok := cachedTemplate(opts, cache, opts.answers.Template, nil); ok {
+				return useTemplate(opts, template)
+			}
+			return fmt.Errorf("%w for runtime %s", err, opts.manifest.Instance.Runtime)
+		}
+	} else {
+		templateLabel, err := opts.Survey().AskForUserChoice(fmt.Sprintf("Select a product template for runtime %s: ", opts.manifest.Instance.Runtime), templateOptions.Labels, templateOptions.IDLookup, "")
+		if err != nil {
+			return fmt.Errorf("failed to ask user to select a product template for runtime %s: %w", opts.manifest.Instance.Runtime, err)
+		}
+		if templateLabel == format.SkipValue {
+			return nil
+		}
+		selectedProductID = templateOptions.IDLookup[templateLabel]
 	}
 
-	selectedProductID := templateOptions.IDLookup[templateLabel]
-
 	spinner = cmdutil.DisplaySpinnerMessageWithHandle(" Retrieve the latest product template version... ")
 	selectedProductVersion, err := opts.Datastore().GetLatestProductVersionByID(ctx, selectedProductID)
 	spinner.Stop()
 	if err != nil {
+		if template, ok := cachedTemplate(opts, cache, selectedProductID, err); ok {
+			return useTemplate(opts, template)
+		}
 		return fmt.Errorf("failed to get the latest product template version: %w", err)
 	}
-	selectedProductVersionID := selectedProductVersion.ID
 
+	info := cmdutil.TemplateInfo{ID: selectedProductID, Source: cmdutil.TemplateSourceMarketplace, Version: selectedProductVersion.ID}
+	for _, product := range productTemplates {
+		if product.ID == selectedProductID {
+			info.Name = product.Name
+			info.Language = strings.ToLower(product.ProgrammingLanguage)
+		}
+	}
 	spinner = cmdutil.DisplaySpinnerMessageWithHandle(" Downloading template files... ")
-	template, err := opts.MarketplaceClient().GetTemplate(ctx, selectedProductID, selectedProductVersionID)
+	template, err := cmdutil.FetchTemplate(ctx, opts, cache, info)
 	spinner.Stop()
 	if err != nil {
-		return fmt.Errorf("failed to download template files: %w", err)
+		if template, ok := cachedTemplate(opts, cache, selectedProductID, err); ok {
+			return useTemplate(opts, template)
+		}
+		return err
 	}
+	return useTemplate(opts, template)
+}
 
-	spinner = cmdutil.DisplaySpinnerMessageWithHandle(" Uncompressing template files... ")
-	err = uncompressToDir(template, opts.cwd)
-	spinner.Stop()
+// useTemplate writes the files of a template archive to the project directory and merges its manifest.
+func useTemplate(opts *Options, template []byte) error {
+	if err := extractTemplate(opts, template); err != nil {
+		return err
+	}
+	return mergeTemplateManifest(opts)
+}
+
+// cachedTemplate returns the archive of a template downloaded earlier, if there is one, after telling
+// the user that it is used. cause is why the template could not be downloaded, if it is why the cache is used.
+func cachedTemplate(opts *Options, cache config.TemplateCache, idOrName string, cause error) ([]byte, bool) {
+	if idOrName == "" || isSkip(idOrName) {
+		return nil, false
+	}
+	cached, template, err := cache.Find(idOrName)
 	if err != nil {
-		return fmt.Errorf("failed to uncompress template files: %w", err)
+		return nil, false
 	}
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+	if cause != nil {
+		fmt.Fprintf(io.ErrOut, "%s The marketplace could not be reached (%s), using template %q downloaded on %s\n", c.WarningIcon(), cause, cached.Name, cached.CachedAt.Format(time.DateOnly))
+	} else {
+		fmt.Fprintf(io.ErrOut, "%s Using template %q downloaded on %s\n", c.WarningIcon(), cached.Name, cached.CachedAt.Format(time.DateOnly))
+	}
+	return template, true
+}
 
+// mergeTemplateManifest merges the answers into the manifest of the template, if it has one.
+func mergeTemplateManifest(opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	var err error
 	opts.templateManifestFilePath, err = config.FindTemplateManifestFile(opts.cwd)
 	if err != nil {
 		return fmt.Errorf("failed to find template manifest file: %w", err)
@@ -381,49 +713,129 @@ func getProductTemplatesByLang(productList []api.Product, programmingLang string
 	return append(starterProjects, otherProjects...)
 }
 
-// uncompressToDir uncompresses the given tar.gz file bytes to the given directory.
-func uncompressToDir(fileBytes []byte, dest string) error {
-	gzr, err := gzip.NewReader(bytes.NewReader(fileBytes))
+// extractTemplate writes the template files to the project directory, asking what to do with the
+// files that already exist unless --on-conflict is set.
+func extractTemplate(opts *Options, template []byte) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	entries, err := archive.Read(template)
 	if err != nil {
-		return fmt.Errorf("failed to create a new gzip reader: %w", err)
+		return fmt.Errorf("failed to uncompress template files: %w", err)
+	}
+	if entries, err = renderTemplate(opts, entries); err != nil {
+		return err
+	}
+	conflicts, err := archive.FindConflicts(entries, opts.cwd)
+	if err != nil {
+		return fmt.Errorf("failed to uncompress template files: %w", err)
 	}
-	defer gzr.Close()
-
-	tr := tar.NewReader(gzr)
 
-	for {
-		header, err := tr.Next()
-		if err == io.EOF {
-			break
+	policy := archive.ConflictAbort
+	if len(conflicts) > 0 {
+		fmt.Fprintf(io.ErrOut, "%s %d template file(s) already exist in %s:\n", c.WarningIcon(), len(conflicts), opts.cwd)
+		for _, name := range conflicts {
+			fmt.Fprintf(io.ErrOut, "  • %s\n", name)
 		}
-		if err != nil {
-			return fmt.Errorf("failed to read tar file: %w", err)
+		if policy, err = askConflictPolicy(opts); err != nil {
+			return err
 		}
-		target := filepath.Join(dest, header.Name)
-		switch header.Typeflag {
-		case tar.TypeDir:
-			if err := os.MkdirAll(target, os.ModePerm); err != nil {
-				return fmt.Errorf("failed to create directory %s: %w", target, err)
-			}
-		case tar.TypeReg:
-			if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
-				return err
-			}
-			file, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(header.Mode))
-			if err != nil {
-				return fmt.Errorf("failed to open file %s: %w", target, err)
-			}
-			if _, err := io.Copy(file, tr); err != nil {
-				return fmt.Errorf("failed to copy file %s: %w", target, err)
-			}
-			if err := file.Close(); err != nil {
-				return fmt.Errorf("failed to close file %s: %w", target, err)
-			}
+		if policy == archive.ConflictAbort {
+			return errors.New("template files conflict with existing files, init aborted")
 		}
 	}
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Uncompressing template files... ")
+	err = archive.Write(entries, opts.cwd, policy)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to uncompress template files: %w", err)
+	}
 	return nil
 }
 
+func askConflictPolicy(opts *Options) (archive.ConflictPolicy, error) {
+	if opts.answers.OnConflict != "" {
+		for _, p := range archive.ConflictPolicies {
+			if string(p) == opts.answers.OnConflict {
+				return p, nil
+			}
+		}
+		return "", fmt.Errorf("invalid value for --on-conflict %q, must be one of: abort, overwrite, skip, keep-both", opts.answers.OnConflict)
+	}
+	if !opts.canPrompt() {
+		return "", errors.New("template files conflict with existing files, set --on-conflict to overwrite, skip or keep-both")
+	}
+
+	labels := []string{
+		"Abort",
+		"Overwrite the existing files",
+		"Skip the template files that already exist",
+		fmt.Sprintf("Keep both, adding %q to the name of the template files", archive.KeepBothSuffix),
+	}
+	lookup := make(map[string]string, len(labels))
+	for i, label := range labels {
+		lookup[label] = string(archive.ConflictPolicies[i])
+	}
+	label, err := opts.Survey().AskForUserChoice("What do you want to do with the existing files?", labels, lookup, labels[0])
+	if err != nil {
+		return "", err
+	}
+	return archive.ConflictPolicy(lookup[label]), nil
+}
+
+// findTemplate returns the ID of the product template with the given name or ID.
+func findTemplate(products []api.Product, template string) (string, error) {
+	names := make([]string, 0, len(products))
+	for _, product := range products {
+		if product.ID == template || strings.EqualFold(product.Name, template) {
+			return product.ID, nil
+		}
+		names = append(names, product.Name)
+	}
+	return "", fmt.Errorf("template %q is not available, must be one of: %s", template, strings.Join(names, ", "))
+}
+
+// answerAppID validates an application ID given as an answer. "skip", or no answer with --yes, leaves it unset.
+func answerAppID(ctx context.Context, opts *Options, key, appID string) (string, error) {
+	switch {
+	case appID == "" && !opts.yes:
+		return "", missingAnswerError(key)
+	case appID == "" || isSkip(appID):
+		return "", nil
+	}
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving app list... ")
+	apps, err := opts.DeploymentClient().ListVonageApplications(ctx, "")
+	spinner.Stop()
+	if err != nil {
+		return "", err
+	}
+	if _, ok := format.GetAppOptions(apps.Applications).Lookup[appID]; !ok {
+		return "", fmt.Errorf("application %q not found, run 'vcr app list' to see your applications", appID)
+	}
+	return appID, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func createApp(ctx context.Context, opts *Options, appName string) (string, error) {
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(fmt.Sprintf(" Creating Application %q...", appName))
+	result, err := opts.DeploymentClient().CreateVonageApplication(ctx, appName, false, false, false)
+	spinner.Stop()
+	if err != nil {
+		return "", fmt.Errorf("failed to create application %q: %w", appName, err)
+	}
+	return result.ApplicationID, nil
+}
+
 func createNewApp(ctx context.Context, opts *Options, question string) (string, error) {
 	io := opts.IOStreams()
 	c := io.ColorScheme()
@@ -1,23 +1,35 @@
 package init
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/MakeNowJust/heredoc"
 	"github.com/cli/cli/v2/pkg/iostreams"
 	"github.com/golang/mock/gomock"
 	"github.com/google/shlex"
 	"github.com/stretchr/testify/require"
 
 	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/config"
 	"vonage-cloud-runtime-cli/testutil"
 	"vonage-cloud-runtime-cli/testutil/mocks"
 )
 
+// useTempTemplateCache keeps the templates downloaded by a test out of the user's cache.
+func useTempTemplateCache(t *testing.T) {
+	previousDir := templateCacheDir
+	templateCacheDir = t.TempDir()
+	t.Cleanup(func() { templateCacheDir = previousDir })
+}
+
 func TestInit(t *testing.T) {
 	filePath := "testdata/test.tar.gz"
 
@@ -378,6 +390,7 @@ func TestInit(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			useTempTemplateCache(t)
 			ctrl := gomock.NewController(t)
 			surveyMock := mocks.NewMockSurveyInterface(ctrl)
 			deploymentMock := mocks.NewMockDeploymentInterface(ctrl)
@@ -458,6 +471,8 @@ func TestInit(t *testing.T) {
 				Return(tt.mock.InitGetTemplateReturnTemplate, tt.mock.InitGetTemplateReturnErr)
 
 			ios, _, stdout, stderr := iostreams.Test()
+			ios.SetStdinTTY(true)
+			ios.SetStdoutTTY(true)
 
 			argv, err := shlex.Split(tt.cli)
 			if err != nil {
@@ -491,3 +506,367 @@ func TestInit(t *testing.T) {
 		})
 	}
 }
+
+func TestInitNonInteractive(t *testing.T) {
+	template := craftTarGz(t,
+		tarEntry{name: "vcr.yaml", typeflag: tar.TypeReg, body: "project:\n  name: test\n"},
+		tarEntry{name: "index.js", typeflag: tar.TypeReg, body: "console.log('template')"},
+	)
+
+	runtimes := []api.Runtime{{Name: "nodejs18", Language: "nodejs"}, {Name: "python3", Language: "python"}}
+	regions := []api.Region{{Name: "AWS - Europe Ireland", Alias: "aws.euw1"}, {Name: "AWS - US East", Alias: "aws.use1"}}
+	apps := api.ListVonageApplicationsOutput{Applications: []api.ApplicationListItem{{Name: "app-name", ID: "app-id"}}}
+	products := []api.Product{{ID: "product-id", Name: "Starter Project", ProgrammingLanguage: "NodeJS"}}
+
+	type mock struct {
+		ListRuntimesTimes     int
+		ListRegionsTimes      int
+		ListAppsTimes         int
+		CreateAppTimes        int
+		CreateAppName         string
+		ListProductsTimes     int
+		DownloadTemplateTimes int
+	}
+	type want struct {
+		errMsg   string
+		manifest config.Manifest
+	}
+
+	tests := []struct {
+		name     string
+		cli      string
+		answers  string
+		existing string
+		mock     mock
+		want     want
+	}{
+		{
+			name: "flags",
+			cli:  "--project-name my-project --instance-name prod --runtime python3 --app-id app-id --debug-app-id skip --template skip",
+			answers: heredoc.Doc(`
+				region: aws.use1
+			`),
+			mock: mock{ListRuntimesTimes: 1, ListRegionsTimes: 1, ListAppsTimes: 1, ListProductsTimes: 1},
+			want: want{
+				manifest: config.Manifest{
+					Project:  config.Project{Name: "my-project"},
+					Instance: config.Instance{Name: "prod", Runtime: "python3", Region: "aws.use1", ApplicationID: "app-id"},
+				},
+			},
+		},
+		{
+			name: "flags-take-precedence-over-answers",
+			cli:  "--project-name from-flag --yes",
+			answers: heredoc.Doc(`
+				project-name: from-file
+				region: aws.euw1
+				create-app: new-app
+				template: starter project
+			`),
+			mock: mock{ListRuntimesTimes: 1, ListRegionsTimes: 1, CreateAppTimes: 1, CreateAppName: "new-app", ListProductsTimes: 1, DownloadTemplateTimes: 1},
+			want: want{
+				manifest: config.Manifest{
+					Project:  config.Project{Name: "from-flag"},
+					Instance: config.Instance{Name: "dev", Runtime: "nodejs18", Region: "aws.euw1", ApplicationID: "new-app-id"},
+				},
+			},
+		},
+		{
+			name: "missing-value-without-terminal",
+			cli:  "",
+			want: want{
+				errMsg: "failed to ask project name: no project name given and the terminal is not interactive, set --project-name, add \"project-name\" to the answers file or use --yes to accept the default",
+			},
+		},
+		{
+			name: "invalid-project-name",
+			cli:  "--project-name My_Project",
+			want: want{
+				errMsg: "failed to ask project name: project name \"My_Project\" is not correct, project name should be lower case alphanumeric or - characters",
+			},
+		},
+		{
+			name: "unknown-runtime",
+			cli:  "--project-name my-project --instance-name dev --runtime java",
+			mock: mock{ListRuntimesTimes: 1},
+			want: want{
+				errMsg: "failed to ask runtime: runtime \"java\" is not available, must be one of: nodejs18, python3",
+			},
+		},
+		{
+			name: "unknown-region",
+			cli:  "--project-name my-project --instance-name dev --runtime nodejs18",
+			answers: heredoc.Doc(`
+				region: mars.north1
+			`),
+			mock: mock{ListRuntimesTimes: 1, ListRegionsTimes: 1},
+			want: want{
+				errMsg: "failed to ask region: region \"mars.north1\" does not exist, must be one of: aws.euw1, aws.use1",
+			},
+		},
+		{
+			name: "unknown-app-id",
+			cli:  "--yes --app-id unknown",
+			answers: heredoc.Doc(`
+				region: aws.euw1
+			`),
+			mock: mock{ListRuntimesTimes: 1, ListRegionsTimes: 1, ListAppsTimes: 1},
+			want: want{
+				errMsg: "failed to ask instance app id: application \"unknown\" not found, run 'vcr app list' to see your applications",
+			},
+		},
+		{
+			name: "unknown-template",
+			cli:  "--yes --template voice",
+			answers: heredoc.Doc(`
+				region: aws.euw1
+			`),
+			mock: mock{ListRuntimesTimes: 1, ListRegionsTimes: 1, ListProductsTimes: 1},
+			want: want{
+				errMsg: "template \"voice\" is not available, must be one of: Starter Project for runtime nodejs18",
+			},
+		},
+		{
+			name: "unknown-answers-key",
+			cli:  "--yes",
+			answers: heredoc.Doc(`
+				project: my-project
+			`),
+			want: want{
+				errMsg: "failed to parse answers file",
+			},
+		},
+		{
+			name:     "existing-template-files",
+			cli:      "--yes --project-name my-project --template 'starter project'",
+			answers:  "region: aws.euw1\n",
+			existing: "index.js",
+			mock:     mock{ListRuntimesTimes: 1, ListRegionsTimes: 1, ListProductsTimes: 1, DownloadTemplateTimes: 1},
+			want: want{
+				errMsg: "template files conflict with existing files, set --on-conflict to overwrite, skip or keep-both",
+			},
+		},
+		{
+			name:     "existing-template-files-skipped",
+			cli:      "--yes --project-name my-project --template 'starter project' --on-conflict skip",
+			answers:  "region: aws.euw1\n",
+			existing: "index.js",
+			mock:     mock{ListRuntimesTimes: 1, ListRegionsTimes: 1, ListProductsTimes: 1, DownloadTemplateTimes: 1},
+			want: want{
+				manifest: config.Manifest{
+					Project:  config.Project{Name: "my-project"},
+					Instance: config.Instance{Name: "dev", Runtime: "nodejs18", Region: "aws.euw1"},
+				},
+			},
+		},
+		{
+			name:     "invalid-on-conflict",
+			cli:      "--yes --project-name my-project --template 'starter project' --on-conflict replace",
+			answers:  "region: aws.euw1\n",
+			existing: "index.js",
+			mock:     mock{ListRuntimesTimes: 1, ListRegionsTimes: 1, ListProductsTimes: 1, DownloadTemplateTimes: 1},
+			want: want{
+				errMsg: "invalid value for --on-conflict \"replace\", must be one of: abort, overwrite, skip, keep-both",
+			},
+		},
+		{
+			name: "app-id-and-create-app",
+			cli:  "--app-id app-id --create-app new-app",
+			want: want{
+				errMsg: "specify only one of --app-id or --create-app",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempTemplateCache(t)
+			ctrl := gomock.NewController(t)
+			deploymentMock := mocks.NewMockDeploymentInterface(ctrl)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			marketplaceMock := mocks.NewMockMarketplaceInterface(ctrl)
+
+			datastoreMock.EXPECT().ListRuntimes(gomock.Any()).Times(tt.mock.ListRuntimesTimes).Return(runtimes, nil)
+			datastoreMock.EXPECT().ListRegions(gomock.Any()).Times(tt.mock.ListRegionsTimes).Return(regions, nil)
+			deploymentMock.EXPECT().ListVonageApplications(gomock.Any(), "").Times(tt.mock.ListAppsTimes).Return(apps, nil)
+			deploymentMock.EXPECT().CreateVonageApplication(gomock.Any(), tt.mock.CreateAppName, false, false, false).
+				Times(tt.mock.CreateAppTimes).
+				Return(api.CreateVonageApplicationOutput{ApplicationID: tt.mock.CreateAppName + "-id"}, nil)
+			datastoreMock.EXPECT().ListProducts(gomock.Any()).Times(tt.mock.ListProductsTimes).Return(products, nil)
+			datastoreMock.EXPECT().GetLatestProductVersionByID(gomock.Any(), "product-id").
+				Times(tt.mock.DownloadTemplateTimes).
+				Return(api.ProductVersion{ID: "product-version-id"}, nil)
+			marketplaceMock.EXPECT().GetTemplate(gomock.Any(), "product-id", "product-version-id").
+				Times(tt.mock.DownloadTemplateTimes).
+				Return(template, nil)
+
+			// not a terminal, so nothing can be prompted
+			ios, _, _, _ := iostreams.Test()
+
+			dir := t.TempDir()
+			argv, err := shlex.Split(tt.cli)
+			require.NoError(t, err)
+			argv = append(argv, dir)
+			if tt.existing != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, tt.existing), []byte("mine"), 0600))
+			}
+			if tt.answers != "" {
+				answersPath := filepath.Join(t.TempDir(), "answers.yml")
+				require.NoError(t, os.WriteFile(answersPath, []byte(tt.answers), 0600))
+				argv = append(argv, "--answers", answersPath)
+			}
+
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, datastoreMock, deploymentMock, nil, marketplaceMock)
+
+			cmd := NewCmdInit(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			if tt.want.errMsg != "" {
+				require.ErrorContains(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			manifest, err := config.ReadManifest(filepath.Join(dir, "vcr.yml"))
+			require.NoError(t, err)
+			require.Equal(t, tt.want.manifest.Project.Name, manifest.Project.Name)
+			require.Equal(t, tt.want.manifest.Instance.Name, manifest.Instance.Name)
+			require.Equal(t, tt.want.manifest.Instance.Runtime, manifest.Instance.Runtime)
+			require.Equal(t, tt.want.manifest.Instance.Region, manifest.Instance.Region)
+			require.Equal(t, tt.want.manifest.Instance.ApplicationID, manifest.Instance.ApplicationID)
+			require.Empty(t, manifest.Debug.ApplicationID)
+			if tt.existing != "" {
+				data, err := os.ReadFile(filepath.Join(dir, tt.existing))
+				require.NoError(t, err)
+				require.Equal(t, "mine", string(data))
+			}
+		})
+	}
+}
+
+func TestInitCachedTemplate(t *testing.T) {
+	useTempTemplateCache(t)
+
+	template := craftTarGz(t,
+		tarEntry{name: "vcr.yaml", typeflag: tar.TypeReg, body: "project:\n  name: test\n"},
+		tarEntry{name: "index.js", typeflag: tar.TypeReg, body: "console.log('template')"},
+	)
+	products := []api.Product{{ID: "product-id", Name: "Starter Project", ProgrammingLanguage: "NodeJS"}}
+
+	type mock struct {
+		ListProductsErr       error
+		GetVersionTimes       int
+		GetVersionErr         error
+		DownloadTemplateTimes int
+		DownloadTemplateErr   error
+	}
+
+	tests := []struct {
+		name   string
+		mock   mock
+		stderr string
+		errMsg string
+	}{
+		{
+			name: "download-and-cache",
+			mock: mock{GetVersionTimes: 1, DownloadTemplateTimes: 1},
+		},
+		{
+			name: "same-version-from-cache",
+			mock: mock{GetVersionTimes: 1},
+		},
+		{
+			name:   "marketplace-unreachable",
+			mock:   mock{ListProductsErr: errors.New("connection refused")},
+			stderr: "The marketplace could not be reached (connection refused), using template \"Starter Project\" downloaded on",
+		},
+		{
+			name:   "download-failed",
+			mock:   mock{GetVersionTimes: 1, DownloadTemplateTimes: 1, GetVersionErr: nil, DownloadTemplateErr: errors.New("bad gateway")},
+			stderr: "using template \"Starter Project\" downloaded on",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			marketplaceMock := mocks.NewMockMarketplaceInterface(ctrl)
+
+			version := "version-1"
+			if i == len(tests)-1 {
+				version = "version-2"
+			}
+			datastoreMock.EXPECT().ListRuntimes(gomock.Any()).AnyTimes().Return([]api.Runtime{{Name: "nodejs18", Language: "nodejs"}}, nil)
+			datastoreMock.EXPECT().ListRegions(gomock.Any()).AnyTimes().Return([]api.Region{{Name: "AWS - Europe Ireland", Alias: "aws.euw1"}}, nil)
+			datastoreMock.EXPECT().ListProducts(gomock.Any()).Times(1).Return(products, tt.mock.ListProductsErr)
+			datastoreMock.EXPECT().GetLatestProductVersionByID(gomock.Any(), "product-id").
+				Times(tt.mock.GetVersionTimes).
+				Return(api.ProductVersion{ID: version}, tt.mock.GetVersionErr)
+			marketplaceMock.EXPECT().GetTemplate(gomock.Any(), "product-id", version).
+				Times(tt.mock.DownloadTemplateTimes).
+				Return(template, tt.mock.DownloadTemplateErr)
+
+			ios, _, _, stderr := iostreams.Test()
+
+			dir := t.TempDir()
+			answersPath := filepath.Join(t.TempDir(), "answers.yml")
+			require.NoError(t, os.WriteFile(answersPath, []byte("region: aws.euw1\n"), 0600))
+
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, datastoreMock, nil, nil, marketplaceMock)
+
+			cmd := NewCmdInit(f)
+			cmd.SetArgs([]string{"--yes", "--project-name", "my-project", "--template", "Starter Project", "--answers", answersPath, dir})
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err := cmd.ExecuteC()
+			if tt.errMsg != "" {
+				require.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, stderr.String(), tt.stderr)
+
+			data, err := os.ReadFile(filepath.Join(dir, "index.js"))
+			require.NoError(t, err)
+			require.Equal(t, "console.log('template')", string(data))
+		})
+	}
+}
+
+type tarEntry struct {
+	name     string
+	typeflag byte
+	body     string
+	linkname string
+}
+
+func craftTarGz(t *testing.T, entries ...tarEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Typeflag: e.typeflag, Linkname: e.linkname, Mode: 0644}
+		if e.typeflag == tar.TypeDir {
+			header.Mode = 0755
+		}
+		if e.typeflag == tar.TypeReg {
+			header.Size = int64(len(e.body))
+		}
+		require.NoError(t, tw.WriteHeader(header))
+		if e.typeflag == tar.TypeReg {
+			_, err := tw.Write([]byte(e.body))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,321 @@
+package init
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/go-resty/resty/v2"
+	"gopkg.in/yaml.v3"
+
+	"vonage-cloud-runtime-cli/pkg/archive"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+// templateConfigFile declares the prompts and variables of a template. It is not copied to the project.
+const templateConfigFile = "template.yml"
+
+// templateConfig is the content of template.yml.
+type templateConfig struct {
+	Prompts   []templatePrompt  `yaml:"prompts"`
+	Variables map[string]string `yaml:"variables"`
+	// Render lists glob patterns, matched against the path or the name of a file, of the files executed
+	// as Go templates. The other files are copied as is, so that files using a templating syntax of their
+	// own, e.g. Handlebars views, don't have to be escaped.
+	Render []string `yaml:"render"`
+}
+
+// templatePrompt is a value asked when the template is used, unless it is given with --var.
+type templatePrompt struct {
+	Name    string `yaml:"name"`
+	Message string `yaml:"message"`
+	Default string `yaml:"default"`
+}
+
+var templateVarRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// isExternalTemplate reports whether template is a local path, a git repository or a URL instead of a
+// marketplace product.
+func isExternalTemplate(template string) bool {
+	for _, prefix := range []string{"git+", "https://", "http://", "./", "../", "~/"} {
+		if strings.HasPrefix(template, prefix) {
+			return true
+		}
+	}
+	return template == "." || filepath.IsAbs(template)
+}
+
+// newTemplateHTTPClient returns the client downloading templates given as a URL. It goes through the
+// proxy and trusts the CA file of the global options like the API client, but doesn't send the Vonage
+// credentials.
+func newTemplateHTTPClient(opts *config.GlobalOptions) (*resty.Client, error) {
+	transport, err := cmdutil.NewTransport(opts)
+	if err != nil {
+		return nil, err
+	}
+	return resty.New().SetTransport(transport), nil
+}
+
+// fetchExternalTemplate returns the files of a local directory or archive, a git repository given as
+// git+<url>[#ref], or an https tar.gz URL, as a tar.gz archive.
+func fetchExternalTemplate(ctx context.Context, opts *Options, source string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(source, "git+"):
+		repo, ref, _ := strings.Cut(strings.TrimPrefix(source, "git+"), "#")
+		return cloneTemplate(ctx, repo, ref)
+	case strings.HasPrefix(source, "http://"):
+		return nil, fmt.Errorf("template URL %q must use https", source)
+	case strings.HasPrefix(source, "https://"):
+		client, err := newTemplateHTTPClient(opts.GlobalOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to download template %s: %w", source, err)
+		}
+		resp, err := client.R().SetContext(ctx).Get(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download template %s: %w", source, err)
+		}
+		if resp.IsError() {
+			return nil, fmt.Errorf("failed to download template %s: %s", source, resp.Status())
+		}
+		return resp.Body(), nil
+	}
+
+	if strings.HasPrefix(source, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		source = filepath.Join(home, source[2:])
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", source, err)
+	}
+	if !info.IsDir() {
+		return os.ReadFile(source)
+	}
+	return packDir(source)
+}
+
+// cloneTemplate makes a shallow clone of a git repository at ref, a branch or a tag, and packs it.
+func cloneTemplate(ctx context.Context, repo, ref string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "vcr-template-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, "--", repo, dir)
+	out, err := exec.CommandContext(ctx, "git", args...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone template repository %s: %w: %s", repo, err, strings.TrimSpace(string(out)))
+	}
+	return packDir(dir)
+}
+
+// packDir returns the files of dir as a tar.gz archive, leaving out the .git directory.
+func packDir(dir string) ([]byte, error) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		file, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(tw, file)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template directory %s: %w", dir, err)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderTemplate removes template.yml from the entries, if there is one, and executes the files matching
+// its render patterns as Go templates with the project settings, the variables and the answers to the
+// prompts it declares.
+func renderTemplate(opts *Options, entries []archive.Entry) ([]archive.Entry, error) {
+	index := -1
+	for i, entry := range entries {
+		if entry.Name == templateConfigFile && entry.Type == tar.TypeReg {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		if len(opts.answers.Vars) > 0 {
+			return nil, errors.New("--var is only supported by templates with a template.yml")
+		}
+		return entries, nil
+	}
+
+	var cfg templateConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(entries[index].Data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse %s: %w", templateConfigFile, err)
+	}
+	data, err := templateData(opts, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := make([]archive.Entry, 0, len(entries)-1)
+	for i, entry := range entries {
+		if i == index {
+			continue
+		}
+		// binary files, e.g. images, are copied as is even if they match
+		if entry.Type != tar.TypeReg || bytes.IndexByte(entry.Data, 0) >= 0 || !matchesAny(cfg.Render, entry.Name) {
+			rendered = append(rendered, entry)
+			continue
+		}
+		tmpl, err := template.New(entry.Name).Option("missingkey=error").Parse(string(entry.Data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template file %s: %w", entry.Name, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render template file %s: %w", entry.Name, err)
+		}
+		entry.Data = buf.Bytes()
+		rendered = append(rendered, entry)
+	}
+	return rendered, nil
+}
+
+// templateData returns the values available to the template files: ProjectName, InstanceName, Runtime,
+// Region, the variables of template.yml, and the answers to its prompts.
+func templateData(opts *Options, cfg templateConfig) (map[string]string, error) {
+	data := map[string]string{
+		"ProjectName":  opts.manifest.Project.Name,
+		"InstanceName": opts.manifest.Instance.Name,
+		"Runtime":      opts.manifest.Instance.Runtime,
+		"Region":       opts.manifest.Instance.Region,
+	}
+	for name, value := range cfg.Variables {
+		if !templateVarRe.MatchString(name) {
+			return nil, fmt.Errorf("invalid variable name %q in %s", name, templateConfigFile)
+		}
+		data[name] = value
+	}
+
+	prompts := make(map[string]bool, len(cfg.Prompts))
+	for _, prompt := range cfg.Prompts {
+		if !templateVarRe.MatchString(prompt.Name) {
+			return nil, fmt.Errorf("invalid prompt name %q in %s", prompt.Name, templateConfigFile)
+		}
+		prompts[prompt.Name] = true
+		value, ok := opts.answers.Vars[prompt.Name]
+		switch {
+		case ok:
+		case opts.canPrompt():
+			message := prompt.Message
+			if message == "" {
+				message = prompt.Name
+			}
+			answer, err := opts.Survey().AskForUserInput(message, prompt.Default)
+			if err != nil {
+				return nil, err
+			}
+			value = answer
+		case opts.yes:
+			value = prompt.Default
+		default:
+			return nil, fmt.Errorf("no value given for template prompt %q and the terminal is not interactive, set --var %s=<value>, add it to \"var\" in the answers file or use --yes to accept the default", prompt.Name, prompt.Name)
+		}
+		data[prompt.Name] = value
+	}
+
+	var unknown []string
+	for name := range opts.answers.Vars {
+		if !prompts[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown template prompt(s) %s, the template asks for: %s", strings.Join(unknown, ", "), strings.Join(sortedPromptNames(cfg.Prompts), ", "))
+	}
+	return data, nil
+}
+
+func sortedPromptNames(prompts []templatePrompt) []string {
+	names := make([]string, 0, len(prompts))
+	for _, prompt := range prompts {
+		names = append(names, prompt.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func matchesAny(patterns []string, name string) bool {
+	name = filepath.ToSlash(name)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(name)); ok {
+			return true
+		}
+	}
+	return false
+}
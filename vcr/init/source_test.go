@@ -0,0 +1,267 @@
+package init
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/archive"
+	"vonage-cloud-runtime-cli/pkg/config"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+var externalTemplateFiles = map[string]string{
+	"template.yml": heredoc.Doc(`
+		prompts:
+		  - name: greeting
+		    message: Greeting to display
+		    default: Hello
+		variables:
+		  team: platform
+		render:
+		  - vcr.yml
+		  - "src/*.js"
+		  - "*.png"
+	`),
+	"vcr.yml": heredoc.Doc(`
+		project:
+		  name: template
+		instance:
+		  name: template
+		  environment:
+		    - name: TEAM
+		      value: {{ .team }}
+	`),
+	"src/index.js":   `console.log("{{ .greeting }} from {{ .ProjectName }} in {{ .Region }}")`,
+	"views/page.hbs": "{{> header }}",
+	"logo.png":       "\x89PNG\x00{{",
+}
+
+func writeTemplateDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, body := range externalTemplateFiles {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0600))
+	}
+	return dir
+}
+
+func TestInitExternalTemplate(t *testing.T) {
+	templateDir := writeTemplateDir(t)
+
+	type want struct {
+		errMsg string
+		files  map[string]string
+	}
+
+	tests := []struct {
+		name string
+		cli  string
+		want want
+	}{
+		{
+			name: "local-directory-with-var",
+			cli:  "--template " + templateDir + " --var greeting=Hi",
+			want: want{
+				files: map[string]string{
+					"src/index.js":   `console.log("Hi from my-project in aws.euw1")`,
+					"views/page.hbs": "{{> header }}",
+					"logo.png":       "\x89PNG\x00{{",
+				},
+			},
+		},
+		{
+			name: "local-directory-with-default",
+			cli:  "--template " + templateDir,
+			want: want{
+				files: map[string]string{
+					"src/index.js": `console.log("Hello from my-project in aws.euw1")`,
+				},
+			},
+		},
+		{
+			name: "unknown-var",
+			cli:  "--template " + templateDir + " --var greting=Hi",
+			want: want{
+				errMsg: "unknown template prompt(s) greting, the template asks for: greeting",
+			},
+		},
+		{
+			name: "missing-directory",
+			cli:  "--template " + filepath.Join(templateDir, "missing"),
+			want: want{
+				errMsg: "failed to read template",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			datastoreMock.EXPECT().ListRuntimes(gomock.Any()).AnyTimes().Return([]api.Runtime{{Name: "nodejs18", Language: "nodejs"}}, nil)
+			datastoreMock.EXPECT().ListRegions(gomock.Any()).AnyTimes().Return([]api.Region{{Name: "AWS - Europe Ireland", Alias: "aws.euw1"}}, nil)
+
+			ios, _, _, _ := iostreams.Test()
+
+			dir := t.TempDir()
+			answersPath := filepath.Join(t.TempDir(), "answers.yml")
+			require.NoError(t, os.WriteFile(answersPath, []byte("region: aws.euw1\n"), 0600))
+			argv, err := shlex.Split(tt.cli)
+			require.NoError(t, err)
+			argv = append(argv, "--yes", "--project-name", "my-project", "--answers", answersPath, dir)
+
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, datastoreMock, nil, nil, nil)
+
+			cmd := NewCmdInit(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			if tt.want.errMsg != "" {
+				require.ErrorContains(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			for name, body := range tt.want.files {
+				data, err := os.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err)
+				require.Equal(t, body, string(data), name)
+			}
+			_, err = os.Stat(filepath.Join(dir, templateConfigFile))
+			require.True(t, os.IsNotExist(err), "template.yml should not be copied")
+
+			manifest, err := config.ReadManifest(filepath.Join(dir, "vcr.yml"))
+			require.NoError(t, err)
+			require.Equal(t, "my-project", manifest.Project.Name)
+			require.Equal(t, "dev", manifest.Instance.Name)
+			require.Equal(t, "aws.euw1", manifest.Instance.Region)
+			require.Equal(t, []config.Env{{Name: "TEAM", Value: "platform"}}, manifest.Instance.Environment)
+		})
+	}
+}
+
+func TestFetchExternalTemplate(t *testing.T) {
+	templateArchive := craftTarGz(t, tarEntry{name: "index.js", typeflag: tar.TypeReg, body: "console.log()"})
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/template.tar.gz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.Empty(t, r.Header.Get("Authorization"), "credentials must not be sent")
+		_, _ = w.Write(templateArchive)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+
+	ctrl := gomock.NewController(t)
+	f := mocks.NewMockFactory(ctrl)
+	f.EXPECT().GlobalOptions().Return(&config.GlobalOptions{CAFile: caFile}).AnyTimes()
+	opts := &Options{Factory: f}
+
+	t.Run("https-untrusted", func(t *testing.T) {
+		untrusted := mocks.NewMockFactory(ctrl)
+		untrusted.EXPECT().GlobalOptions().Return(&config.GlobalOptions{}).AnyTimes()
+		_, err := fetchExternalTemplate(t.Context(), &Options{Factory: untrusted}, server.URL+"/template.tar.gz")
+		require.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("https", func(t *testing.T) {
+		data, err := fetchExternalTemplate(t.Context(), opts, server.URL+"/template.tar.gz")
+		require.NoError(t, err)
+		require.Equal(t, templateArchive, data)
+	})
+
+	t.Run("https-not-found", func(t *testing.T) {
+		_, err := fetchExternalTemplate(t.Context(), opts, server.URL+"/missing.tar.gz")
+		require.ErrorContains(t, err, "404 Not Found")
+	})
+
+	t.Run("http", func(t *testing.T) {
+		_, err := fetchExternalTemplate(t.Context(), opts, "http://example.com/template.tar.gz")
+		require.EqualError(t, err, `template URL "http://example.com/template.tar.gz" must use https`)
+	})
+
+	t.Run("local-archive", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "template.tar.gz")
+		require.NoError(t, os.WriteFile(path, templateArchive, 0600))
+		data, err := fetchExternalTemplate(t.Context(), opts, path)
+		require.NoError(t, err)
+		require.Equal(t, templateArchive, data)
+	})
+
+	t.Run("git", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git is not installed")
+		}
+		repo := writeTemplateDir(t)
+		git := func(args ...string) {
+			cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+			cmd.Dir = repo
+			out, err := cmd.CombinedOutput()
+			require.NoError(t, err, string(out))
+		}
+		git("init", "--quiet")
+		git("add", "-A")
+		git("commit", "--quiet", "-m", "template")
+		git("tag", "v1")
+		require.NoError(t, os.WriteFile(filepath.Join(repo, "after-tag.js"), []byte(""), 0600))
+		git("add", "-A")
+		git("commit", "--quiet", "-m", "after tag")
+
+		data, err := fetchExternalTemplate(t.Context(), opts, "git+file://"+filepath.ToSlash(repo)+"#v1")
+		require.NoError(t, err)
+		entries, err := archive.Read(data)
+		require.NoError(t, err)
+		var names []string
+		for _, e := range entries {
+			names = append(names, filepath.ToSlash(e.Name))
+		}
+		require.Contains(t, names, "src/index.js")
+		require.NotContains(t, names, "after-tag.js")
+		for _, name := range names {
+			require.NotContains(t, name, ".git/")
+		}
+
+		_, err = fetchExternalTemplate(t.Context(), opts, "git+file://"+filepath.ToSlash(repo)+"#missing")
+		require.ErrorContains(t, err, "failed to clone template repository")
+	})
+}
+
+func TestIsExternalTemplate(t *testing.T) {
+	for template, want := range map[string]bool{
+		"./starter":                            true,
+		"../starter":                           true,
+		"/opt/templates/starter":               true,
+		"git+https://example.com/t.git#main":   true,
+		"https://example.com/t.tar.gz":         true,
+		"Starter Project":                      false,
+		"skip":                                 false,
+		"8d7a46b8-4d0c-4d5f-a4e0-9d2a1c1f6b0e": false,
+	} {
+		require.Equal(t, want, isExternalTemplate(template), template)
+	}
+}
@@ -2,19 +2,58 @@ package list
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 
+	"vonage-cloud-runtime-cli/pkg/api"
 	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/format"
 )
 
+// errLimitReached stops the pagination once --limit instances were listed.
+var errLimitReached = errors.New("limit reached")
+
+// column is a column of the instance table, selected by its name with --columns.
+type column struct {
+	name   string
+	header string
+}
+
+var columns = []column{
+	{"id", "Instance ID"},
+	{"app-id", "API Application ID"},
+	{"name", "Instance Name"},
+	{"service", "Service Name"},
+	{"project", "Project"},
+	{"region", "Region"},
+	{"runtime", "Runtime"},
+	{"created", "Created"},
+	{"updated", "Updated"},
+	{"host", "Host URL"},
+}
+
+// defaultColumns are shown without --columns or --wide.
+var defaultColumns = []string{"id", "app-id", "name", "service"}
+
 type Options struct {
 	cmdutil.Factory
 
-	Filter string
+	Filter           string
+	ProjectName      string
+	InstanceRegion   string
+	APIApplicationID string
+	Runtime          string
+	Sort             string
+	Columns          []string
+	Wide             bool
+	Limit            int
+	JSON             bool
 }
 
 func NewCmdInstanceList(f cmdutil.Factory) *cobra.Command {
@@ -26,11 +65,22 @@ func NewCmdInstanceList(f cmdutil.Factory) *cobra.Command {
 		Use:     "list",
 		Aliases: []string{"ls"},
 		Short:   "List all deployed VCR instances",
-		Long: heredoc.Doc(`List all deployed VCR instances.
+		Long: heredoc.Docf(`List all deployed VCR instances.
 
 			This command displays a table of all non-deleted VCR instances, showing their
-			IDs, linked API application IDs, instance names and service names.
-		`),
+			IDs, linked API application IDs, instance names and service names, most
+			recently updated first.
+
+			The instances can be narrowed down by service name, project, region, API
+			application ID and runtime, and sorted by name or creation time with --sort.
+
+			Use --columns to choose the columns to show, in order, or --wide to show all
+			of them. The available columns are:
+			  %s
+
+			Instances are fetched %d at a time. Use --json to print them as JSON, with
+			all their fields, for use in scripts.
+		`, strings.Join(columnNames(), ", "), api.DefaultPageSize),
 		Example: heredoc.Doc(`
 			# List all instances
 			$ vcr instance list
@@ -48,10 +98,36 @@ func NewCmdInstanceList(f cmdutil.Factory) *cobra.Command {
 
 			# Filter with short flag
 			$ vcr instance list -f "prod"
+
+			# List the instances of a project running a given runtime
+			$ vcr instance list --project my-project --runtime nodejs22
+
+			# List the instances of a region, newest first
+			$ vcr instance list --instance-region aws.euw1 --sort created
+
+			# Show the instance names, projects and host URLs
+			$ vcr instance list --columns name,project,host
+
+			# Show all the columns
+			$ vcr instance list --wide
+
+			# List the 10 most recently updated instances
+			$ vcr instance list --limit 10
+
+			# Print the service names of all instances
+			$ vcr instance list --json | jq -r '.[].service_name'
 		`),
 		Args: cobra.MaximumNArgs(0),
-		RunE: func(_ *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := cmdutil.MutuallyExclusive("specify only one of --columns, --wide or --json", len(opts.Columns) > 0, opts.Wide, opts.JSON); err != nil {
+				return err
+			}
+			// the output_format setting doesn't apply when the columns of the table are chosen
+			if len(opts.Columns) == 0 && !opts.Wide {
+				opts.JSON = cmdutil.JSONOutput(cmd, opts.CliConfig(), opts.JSON)
+			}
+
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 
 			return runList(ctx, &opts)
@@ -59,6 +135,15 @@ func NewCmdInstanceList(f cmdutil.Factory) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&opts.Filter, "filter", "f", "", "Filter instances by service name (case-insensitive substring match)")
+	cmd.Flags().StringVarP(&opts.ProjectName, "project", "p", "", "Only list the instances of this project")
+	cmd.Flags().StringVarP(&opts.InstanceRegion, "instance-region", "", "", "Only list the instances deployed in this region, e.g. aws.euw1")
+	cmd.Flags().StringVarP(&opts.APIApplicationID, "app-id", "", "", "Only list the instances linked to this API application ID")
+	cmd.Flags().StringVarP(&opts.Runtime, "runtime", "", "", "Only list the instances running this runtime, e.g. nodejs22")
+	cmd.Flags().StringVarP(&opts.Sort, "sort", "s", api.InstanceSortUpdated, fmt.Sprintf("Sort the instances by one of: %s", strings.Join(api.InstanceSorts, ", ")))
+	cmd.Flags().StringSliceVarP(&opts.Columns, "columns", "c", nil, "Comma-separated columns to show (default is id,app-id,name,service)")
+	cmd.Flags().BoolVarP(&opts.Wide, "wide", "w", false, "Show all the columns")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", 0, "Maximum number of instances to list (default is all)")
+	cmd.Flags().BoolVarP(&opts.JSON, "json", "", false, "Print the instances as JSON")
 
 	return cmd
 }
@@ -66,21 +151,188 @@ func NewCmdInstanceList(f cmdutil.Factory) *cobra.Command {
 func runList(ctx context.Context, opts *Options) error {
 	io := opts.IOStreams()
 
-	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Fetching instances list...")
-	instances, err := opts.Datastore().ListInstances(ctx, opts.Filter)
-	spinner.Stop()
+	if opts.Limit < 0 {
+		return fmt.Errorf("invalid limit %d, must be 0 or more", opts.Limit)
+	}
+	if !slices.Contains(api.InstanceSorts, opts.Sort) {
+		return cmdutil.FlagErrorf("invalid sort %q, must be one of: %s", opts.Sort, strings.Join(api.InstanceSorts, ", "))
+	}
+	cols, err := selectColumns(opts)
 	if err != nil {
-		return fmt.Errorf("failed to list instances: %w", err)
+		return err
+	}
+	pageSize := api.DefaultPageSize
+	if opts.Limit > 0 && opts.Limit < pageSize {
+		pageSize = opts.Limit
+	}
+
+	var regions map[string]api.Region
+	if slices.ContainsFunc(cols, func(col column) bool { return col.name == "host" }) {
+		if regions, err = listRegions(ctx, opts); err != nil {
+			return err
+		}
+	}
+
+	if opts.JSON {
+		spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Fetching instances list...")
+		instances := []api.InstanceListItem{}
+		err := listInstances(ctx, opts, pageSize, func(inst api.InstanceListItem) error {
+			instances = append(instances, inst)
+			return nil
+		})
+		spinner.Stop()
+		if err != nil {
+			return err
+		}
+		return format.PrintJSON(io.Out, instances)
 	}
 
 	table := tablewriter.NewWriter(io.Out)
-	table.Header("Instance ID", "API Application ID", "Instance Name", "Service Name")
+	headers := make([]any, 0, len(cols))
+	for _, col := range cols {
+		headers = append(headers, col.header)
+	}
+	table.Header(headers...)
 
-	for _, inst := range instances {
-		if err := table.Append([]string{inst.ID, inst.APIApplicationID, inst.Name, inst.ServiceName}); err != nil {
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Fetching instances list...")
+	count := 0
+	err = listInstances(ctx, opts, pageSize, func(inst api.InstanceListItem) error {
+		count++
+		spinner.Suffix = fmt.Sprintf(" Fetching instances list... %d so far", count)
+		if err := table.Append(instanceRow(cols, inst, regions)); err != nil {
 			return fmt.Errorf("failed to append instance to table: %w", err)
 		}
+		return nil
+	})
+	spinner.Stop()
+	if err != nil {
+		return err
 	}
 
 	return table.Render()
 }
+
+// listInstances passes the instances to fn page by page, stopping after opts.Limit of them.
+func listInstances(ctx context.Context, opts *Options, pageSize int, fn func(inst api.InstanceListItem) error) error {
+	listed := 0
+	query := api.InstanceQuery{
+		ServiceName:      opts.Filter,
+		ProjectName:      opts.ProjectName,
+		Region:           opts.InstanceRegion,
+		APIApplicationID: opts.APIApplicationID,
+		Runtime:          opts.Runtime,
+		Sort:             opts.Sort,
+	}
+	err := opts.Datastore().ListInstancesPages(ctx, query, pageSize, func(page []api.InstanceListItem) error {
+		for _, inst := range page {
+			if opts.Limit > 0 && listed == opts.Limit {
+				return errLimitReached
+			}
+			if err := fn(inst); err != nil {
+				return err
+			}
+			listed++
+		}
+		if opts.Limit > 0 && listed == opts.Limit {
+			return errLimitReached
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errLimitReached) {
+		return fmt.Errorf("failed to list instances: %w", err)
+	}
+	return nil
+}
+
+// selectColumns returns the columns of --columns, all of them with --wide, or the default ones.
+func selectColumns(opts *Options) ([]column, error) {
+	if opts.Wide {
+		return columns, nil
+	}
+	names := opts.Columns
+	if len(names) == 0 {
+		names = defaultColumns
+	}
+	cols := make([]column, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		i := slices.IndexFunc(columns, func(col column) bool { return col.name == name })
+		if i < 0 {
+			return nil, cmdutil.FlagErrorf("unknown column %q, must be one of: %s", name, strings.Join(columnNames(), ", "))
+		}
+		cols = append(cols, columns[i])
+	}
+	return cols, nil
+}
+
+func columnNames() []string {
+	names := make([]string, 0, len(columns))
+	for _, col := range columns {
+		names = append(names, col.name)
+	}
+	return names
+}
+
+// listRegions returns the regions by alias, to render the host URLs of the instances.
+func listRegions(ctx context.Context, opts *Options) (map[string]api.Region, error) {
+	regions, err := opts.Datastore().ListRegions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list regions: %w", err)
+	}
+	byAlias := make(map[string]api.Region, len(regions))
+	for _, r := range regions {
+		byAlias[r.Alias] = r
+	}
+	return byAlias, nil
+}
+
+// hostURL returns the URL of an instance, or "-" when its region is unknown or has no valid host
+// template, so that an unrendered template is never shown as a URL.
+func hostURL(inst api.InstanceListItem, regions map[string]api.Region) string {
+	region, ok := regions[inst.Region]
+	if !ok || region.HostTemplate == "" {
+		return "-"
+	}
+	url, err := region.HostURL(inst.ServiceName)
+	if err != nil {
+		return "-"
+	}
+	return url
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// instanceRow returns the cells of an instance, regions are the known regions by alias.
+func instanceRow(cols []column, inst api.InstanceListItem, regions map[string]api.Region) []string {
+	row := make([]string, 0, len(cols))
+	for _, col := range cols {
+		switch col.name {
+		case "id":
+			row = append(row, inst.ID)
+		case "app-id":
+			row = append(row, inst.APIApplicationID)
+		case "name":
+			row = append(row, inst.Name)
+		case "service":
+			row = append(row, inst.ServiceName)
+		case "project":
+			row = append(row, orDash(inst.Project.Name))
+		case "region":
+			row = append(row, orDash(inst.Region))
+		case "runtime":
+			row = append(row, orDash(inst.Runtime))
+		case "created":
+			row = append(row, format.Timestamp(inst.CreatedAt))
+		case "updated":
+			row = append(row, format.Timestamp(inst.UpdatedAt))
+		case "host":
+			row = append(row, hostURL(inst, regions))
+		}
+	}
+	return row
+}
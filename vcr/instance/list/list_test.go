@@ -2,6 +2,7 @@ package list
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"testing"
@@ -47,16 +48,67 @@ func runCommand(t *testing.T, datastoreMock cmdutil.DatastoreInterface, cli stri
 
 func TestInstanceList(t *testing.T) {
 	type mock struct {
-		ListTimes           int
-		ListWantFilter      string
-		ListReturnInstances []api.InstanceListItem
-		ListReturnErr       error
+		ListTimes        int
+		ListWantQuery    api.InstanceQuery
+		ListWantPageSize int
+		ListReturnPages  [][]api.InstanceListItem
+		ListReturnErr    error
+		// ListWantPages is how many of the pages should be fetched, all of them when 0.
+		ListWantPages int
+
+		ListRegionsTimes     int
+		ListRegionsReturn    []api.Region
+		ListRegionsReturnErr error
 	}
 	type want struct {
-		errMsg   string
-		contains []string
+		errMsg      string
+		contains    []string
+		notContains []string
+		stdout      string
 	}
 
+	instances := []api.InstanceListItem{
+		{
+			ID:               "11111111-1111-1111-1111-111111111111",
+			APIApplicationID: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+			Name:             "dev",
+			ServiceName:      "my-service",
+		},
+		{
+			ID:               "22222222-2222-2222-2222-222222222222",
+			APIApplicationID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+			Name:             "prod",
+			ServiceName:      "my-service-prod",
+		},
+		{
+			ID:               "33333333-3333-3333-3333-333333333333",
+			APIApplicationID: "cccccccc-cccc-cccc-cccc-cccccccccccc",
+			Name:             "staging",
+			ServiceName:      "my-service-staging",
+		},
+	}
+	detailed := api.InstanceListItem{
+		ID:               "11111111-1111-1111-1111-111111111111",
+		APIApplicationID: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+		Name:             "dev",
+		ServiceName:      "my-service",
+		Region:           "aws.euw1",
+		Runtime:          "nodejs22",
+		CreatedAt:        "2024-05-01T10:30:00Z",
+		UpdatedAt:        "2024-06-02T08:00:00Z",
+		Project:          api.InstanceProject{Name: "my-project"},
+	}
+	// the host template of aws.use1 is a literal host, shown as is, and the one of aws.apse1 is invalid
+	regions := []api.Region{
+		{Alias: "aws.euw1", HostTemplate: "https://{{.ServiceName}}.euw1.runtime.vonage.cloud"},
+		{Alias: "aws.use1", HostTemplate: "https://region1.example.com"},
+		{Alias: "aws.apse1", HostTemplate: "https://{{.ServiceName.apse1.example.com"},
+	}
+	inUse1 := detailed
+	inUse1.Region = "aws.use1"
+	inApse1 := detailed
+	inApse1.Region = "aws.apse1"
+
 	tests := []struct {
 		name string
 		cli  string
@@ -67,22 +119,10 @@ func TestInstanceList(t *testing.T) {
 			name: "happy-path",
 			cli:  "",
 			mock: mock{
-				ListTimes:      1,
-				ListWantFilter: "",
-				ListReturnInstances: []api.InstanceListItem{
-					{
-						ID:               "11111111-1111-1111-1111-111111111111",
-						APIApplicationID: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
-						Name:             "dev",
-						ServiceName:      "my-service",
-					},
-					{
-						ID:               "22222222-2222-2222-2222-222222222222",
-						APIApplicationID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
-						Name:             "prod",
-						ServiceName:      "my-service-prod",
-					},
-				},
+				ListTimes:        1,
+				ListWantQuery:    api.InstanceQuery{Sort: api.InstanceSortUpdated},
+				ListWantPageSize: api.DefaultPageSize,
+				ListReturnPages:  [][]api.InstanceListItem{instances[:2]},
 			},
 			want: want{
 				contains: []string{
@@ -105,16 +145,10 @@ func TestInstanceList(t *testing.T) {
 			name: "with-filter",
 			cli:  "--filter=prod",
 			mock: mock{
-				ListTimes:      1,
-				ListWantFilter: "prod",
-				ListReturnInstances: []api.InstanceListItem{
-					{
-						ID:               "22222222-2222-2222-2222-222222222222",
-						APIApplicationID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
-						Name:             "prod",
-						ServiceName:      "my-service-prod",
-					},
-				},
+				ListTimes:        1,
+				ListWantQuery:    api.InstanceQuery{ServiceName: "prod", Sort: api.InstanceSortUpdated},
+				ListWantPageSize: api.DefaultPageSize,
+				ListReturnPages:  [][]api.InstanceListItem{instances[1:2]},
 			},
 			want: want{
 				contains: []string{
@@ -131,9 +165,9 @@ func TestInstanceList(t *testing.T) {
 			name: "no-items",
 			cli:  "",
 			mock: mock{
-				ListTimes:           1,
-				ListWantFilter:      "",
-				ListReturnInstances: []api.InstanceListItem{},
+				ListTimes:        1,
+				ListWantQuery:    api.InstanceQuery{Sort: api.InstanceSortUpdated},
+				ListWantPageSize: api.DefaultPageSize,
 			},
 			want: want{
 				contains: []string{
@@ -148,13 +182,209 @@ func TestInstanceList(t *testing.T) {
 			name: "with-api-error",
 			cli:  "",
 			mock: mock{
-				ListTimes:     1,
-				ListReturnErr: errors.New("api error"),
+				ListTimes:        1,
+				ListWantQuery:    api.InstanceQuery{Sort: api.InstanceSortUpdated},
+				ListWantPageSize: api.DefaultPageSize,
+				ListReturnErr:    errors.New("api error"),
 			},
 			want: want{
 				errMsg: "failed to list instances: api error",
 			},
 		},
+		{
+			name: "several-pages",
+			cli:  "",
+			mock: mock{
+				ListTimes:        1,
+				ListWantQuery:    api.InstanceQuery{Sort: api.InstanceSortUpdated},
+				ListWantPageSize: api.DefaultPageSize,
+				ListReturnPages:  [][]api.InstanceListItem{instances[:2], instances[2:]},
+			},
+			want: want{
+				contains: []string{
+					"INSTANCE ID",
+					"11111111-1111-1111-1111-111111111111",
+					"22222222-2222-2222-2222-222222222222",
+					"33333333-3333-3333-3333-333333333333",
+				},
+			},
+		},
+		{
+			name: "limit-stops-fetching",
+			cli:  "--limit 2",
+			mock: mock{
+				ListTimes:        1,
+				ListWantQuery:    api.InstanceQuery{Sort: api.InstanceSortUpdated},
+				ListWantPageSize: 2,
+				ListReturnPages:  [][]api.InstanceListItem{instances[:2], instances[2:]},
+				ListWantPages:    1,
+			},
+			want: want{
+				contains:    []string{"11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"},
+				notContains: []string{"33333333-3333-3333-3333-333333333333"},
+			},
+		},
+		{
+			name: "invalid-limit",
+			cli:  "--limit -1",
+			want: want{
+				errMsg: "invalid limit -1, must be 0 or more",
+			},
+		},
+		{
+			name: "filters-and-sort",
+			cli:  "--project my-project --instance-region aws.euw1 --app-id app-id --runtime nodejs22 --sort name",
+			mock: mock{
+				ListTimes: 1,
+				ListWantQuery: api.InstanceQuery{
+					ProjectName:      "my-project",
+					Region:           "aws.euw1",
+					APIApplicationID: "app-id",
+					Runtime:          "nodejs22",
+					Sort:             api.InstanceSortName,
+				},
+				ListWantPageSize: api.DefaultPageSize,
+				ListReturnPages:  [][]api.InstanceListItem{instances[:1]},
+			},
+			want: want{
+				contains: []string{"11111111-1111-1111-1111-111111111111", "my-service"},
+			},
+		},
+		{
+			name: "columns",
+			cli:  "--columns name,project,runtime,created,host",
+			mock: mock{
+				ListTimes:         1,
+				ListWantQuery:     api.InstanceQuery{Sort: api.InstanceSortUpdated},
+				ListWantPageSize:  api.DefaultPageSize,
+				ListReturnPages:   [][]api.InstanceListItem{{detailed, instances[0], inUse1, inApse1}},
+				ListRegionsTimes:  1,
+				ListRegionsReturn: regions,
+			},
+			want: want{
+				contains: []string{
+					"INSTANCE NAME",
+					"PROJECT",
+					"RUNTIME",
+					"CREATED",
+					"HOST URL",
+					"my-project",
+					"nodejs22",
+					"2024-05-01 10:30",
+					"https://my-service.euw1.runtime.vonage.cloud",
+					"https://region1.example.com",
+				},
+				notContains: []string{"INSTANCE ID", "11111111-1111-1111-1111-111111111111", "apse1.example.com"},
+			},
+		},
+		{
+			name: "json",
+			cli:  "--json --limit 1",
+			mock: mock{
+				ListTimes:        1,
+				ListWantQuery:    api.InstanceQuery{Sort: api.InstanceSortUpdated},
+				ListWantPageSize: 1,
+				ListReturnPages:  [][]api.InstanceListItem{{detailed}},
+			},
+			want: want{
+				stdout: `[
+  {
+    "id": "11111111-1111-1111-1111-111111111111",
+    "api_application_id": "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+    "name": "dev",
+    "service_name": "my-service",
+    "region": "aws.euw1",
+    "runtime": "nodejs22",
+    "created_at": "2024-05-01T10:30:00Z",
+    "updated_at": "2024-06-02T08:00:00Z",
+    "Project": {
+      "name": "my-project"
+    }
+  }
+]
+`,
+			},
+		},
+		{
+			name: "json-without-items",
+			cli:  "--json",
+			mock: mock{
+				ListTimes:        1,
+				ListWantQuery:    api.InstanceQuery{Sort: api.InstanceSortUpdated},
+				ListWantPageSize: api.DefaultPageSize,
+			},
+			want: want{
+				stdout: "[]\n",
+			},
+		},
+		{
+			name: "wide",
+			cli:  "--wide",
+			mock: mock{
+				ListTimes:         1,
+				ListWantQuery:     api.InstanceQuery{Sort: api.InstanceSortUpdated},
+				ListWantPageSize:  api.DefaultPageSize,
+				ListReturnPages:   [][]api.InstanceListItem{{detailed}},
+				ListRegionsTimes:  1,
+				ListRegionsReturn: regions,
+			},
+			want: want{
+				contains: []string{
+					"INSTANCE ID",
+					"PROJECT",
+					"REGION",
+					"RUNTIME",
+					"CREATED",
+					"UPDATED",
+					"HOST URL",
+					"my-project",
+					"aws.euw1",
+					"nodejs22",
+					"2024-05-01 10:30",
+					"2024-06-02 08:00",
+					"https://my-service.euw1.runtime.vonage.cloud",
+				},
+			},
+		},
+		{
+			name: "regions-error",
+			cli:  "--columns host",
+			mock: mock{
+				ListRegionsTimes:     1,
+				ListRegionsReturnErr: errors.New("api error"),
+			},
+			want: want{
+				errMsg: "failed to list regions: api error",
+			},
+		},
+		{
+			name: "unknown-column",
+			cli:  "--columns name,size",
+			want: want{
+				errMsg: `unknown column "size", must be one of: id, app-id, name, service, project, region, runtime, created, updated, host`,
+			},
+		},
+		{
+			name: "invalid-sort",
+			cli:  "--sort size",
+			want: want{
+				errMsg: `invalid sort "size", must be one of: updated, created, name`,
+			},
+		},
+		{
+			name: "columns-and-wide",
+			cli:  "--columns name --wide",
+			want: want{
+				errMsg: "specify only one of --columns, --wide or --json",
+			},
+		},
+		{
+			name: "json-and-columns",
+			cli:  "--columns name --json",
+			want: want{
+				errMsg: "specify only one of --columns, --wide or --json",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -163,9 +393,29 @@ func TestInstanceList(t *testing.T) {
 
 			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
 			datastoreMock.EXPECT().
-				ListInstances(gomock.Any(), tt.mock.ListWantFilter).
+				ListRegions(gomock.Any()).
+				Times(tt.mock.ListRegionsTimes).
+				Return(tt.mock.ListRegionsReturn, tt.mock.ListRegionsReturnErr)
+			datastoreMock.EXPECT().
+				ListInstancesPages(gomock.Any(), tt.mock.ListWantQuery, tt.mock.ListWantPageSize, gomock.Any()).
 				Times(tt.mock.ListTimes).
-				Return(tt.mock.ListReturnInstances, tt.mock.ListReturnErr)
+				DoAndReturn(func(_ context.Context, _ api.InstanceQuery, _ int, fn func([]api.InstanceListItem) error) error {
+					if tt.mock.ListReturnErr != nil {
+						return tt.mock.ListReturnErr
+					}
+					wantPages := tt.mock.ListWantPages
+					if wantPages == 0 {
+						wantPages = len(tt.mock.ListReturnPages)
+					}
+					for i, page := range tt.mock.ListReturnPages {
+						if err := fn(page); err != nil {
+							require.Equal(t, wantPages, i+1, "should stop fetching after the limit")
+							return err
+						}
+					}
+					require.Equal(t, wantPages, len(tt.mock.ListReturnPages), "should stop fetching after the limit")
+					return nil
+				})
 
 			cmdOut, err := runCommand(t, datastoreMock, tt.cli)
 			if tt.want.errMsg != "" {
@@ -174,9 +424,15 @@ func TestInstanceList(t *testing.T) {
 				return
 			}
 			require.NoError(t, err, "should not throw error")
+			if tt.want.stdout != "" {
+				require.Equal(t, tt.want.stdout, cmdOut.OutBuf.String())
+			}
 			for _, s := range tt.want.contains {
 				require.Contains(t, cmdOut.String(), s)
 			}
+			for _, s := range tt.want.notContains {
+				require.NotContains(t, cmdOut.String(), s)
+			}
 		})
 	}
 }
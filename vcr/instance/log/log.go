@@ -4,9 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/MakeNowJust/heredoc"
@@ -119,11 +116,9 @@ func NewCmdInstanceLog(f cmdutil.Factory) *cobra.Command {
 			# Combine filters with follow
 			$ vcr instance log -p my-app -n dev -l warn -s application -f
 		`),
-		RunE: func(_ *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
-			defer cancel()
-
-			return runLog(ctx, &opts)
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// --follow runs past the deadline, which only applies to each request
+			return runLog(cmd.Context(), &opts)
 		},
 	}
 
@@ -144,7 +139,9 @@ func runLog(ctx context.Context, opts *Options) error {
 		return fmt.Errorf("failed to validate flags: %w", err)
 	}
 
-	inst, err := getInstance(ctx, opts)
+	instCtx, cancel := context.WithDeadline(ctx, opts.Deadline())
+	inst, err := getInstance(instCtx, opts)
+	cancel()
 	if err != nil {
 		return fmt.Errorf("failed to get instance: %w", err)
 	}
@@ -153,7 +150,7 @@ func runLog(ctx context.Context, opts *Options) error {
 
 	// Without --follow just print the historical logs and exit.
 	if !opts.Follow {
-		fetchLogs(io, opts, time.Time{})
+		fetchLogs(ctx, io, opts, time.Time{})
 		return nil
 	}
 
@@ -161,26 +158,33 @@ func runLog(ctx context.Context, opts *Options) error {
 	defer ticker.Stop()
 	lastTimestamp := time.Time{}
 
-	interrupt := make(chan os.Signal, 1)
-	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
-
 	for {
 		select {
 		case <-ticker.C:
-			lastTimestamp = fetchLogs(io, opts, lastTimestamp)
-		case <-interrupt:
-			fmt.Println("Interrupt received, stopping...")
+			lastTimestamp = fetchLogs(ctx, io, opts, lastTimestamp)
+		case <-ctx.Done():
+			if !cmdutil.Interrupted(ctx) {
+				return ctx.Err()
+			}
+			fmt.Fprintf(io.ErrOut, "Interrupt received, stopping...\n")
+			if !lastTimestamp.IsZero() {
+				fmt.Fprintf(io.ErrOut, "Last log entry received at %s\n", lastTimestamp.In(time.Local).Format(time.RFC3339))
+			}
 			return nil
 		}
 	}
 }
 
-func fetchLogs(out *iostreams.IOStreams, opts *Options, lastTimestamp time.Time) time.Time {
+func fetchLogs(ctx context.Context, out *iostreams.IOStreams, opts *Options, lastTimestamp time.Time) time.Time {
 	c := out.ColorScheme()
-	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(opts.Timeout()))
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout())
 	defer cancel()
 	logs, err := opts.Datastore().ListLogsByInstanceID(ctx, opts.InstanceID, opts.Limit, lastTimestamp)
 	if err != nil {
+		if ctx.Err() != nil {
+			// the command is stopping, the error is expected
+			return lastTimestamp
+		}
 		fmt.Fprintf(out.ErrOut, "%s Error fetching logs: %v\n", c.WarningIcon(), err)
 		return lastTimestamp
 	}
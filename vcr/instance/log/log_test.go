@@ -2,9 +2,9 @@ package log
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
-	"os"
 	"testing"
 	"time"
 
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
 	"vonage-cloud-runtime-cli/testutil"
 	"vonage-cloud-runtime-cli/testutil/mocks"
 )
@@ -213,7 +214,7 @@ func Test_fetchLogs(t *testing.T) {
 				Factory: f,
 			}
 
-			fetchLogs(ios, opts, lastTimestamp)
+			fetchLogs(context.Background(), ios, opts, lastTimestamp)
 
 			cmdOut := &testutil.CmdOut{
 				OutBuf: stdout,
@@ -307,8 +308,10 @@ func TestLog_Follow(t *testing.T) {
 		Times(1).
 		Return(api.Instance{ID: "abc-123"}, nil)
 
-	// Track how many times ListLogsByInstanceID is called and send SIGTERM
-	// after the second tick so the follow loop exits cleanly.
+	// Track how many times ListLogsByInstanceID is called and interrupt the
+	// command after the second tick so the follow loop exits cleanly.
+	ctx, cancel := context.WithCancelCause(t.Context())
+	defer cancel(nil)
 	callCount := 0
 	datastoreMock.EXPECT().
 		ListLogsByInstanceID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
@@ -316,15 +319,12 @@ func TestLog_Follow(t *testing.T) {
 		DoAndReturn(func(_ interface{}, _ interface{}, _ interface{}, _ interface{}) ([]api.Log, error) {
 			callCount++
 			if callCount >= 2 {
-				// Send an interrupt to the current process so runLog's signal
-				// handler fires and the follow loop exits.
-				p, _ := os.FindProcess(os.Getpid())
-				_ = p.Signal(os.Interrupt)
+				cancel(cmdutil.ErrInterrupted)
 			}
 			return []api.Log{{Timestamp: time.Now(), SourceType: "application", Message: "streaming"}}, nil
 		})
 
-	ios, _, stdout, _ := iostreams.Test()
+	ios, _, stdout, stderr := iostreams.Test()
 
 	argv, err := shlex.Split("--id=abc-123 --follow")
 	require.NoError(t, err)
@@ -337,8 +337,9 @@ func TestLog_Follow(t *testing.T) {
 	cmd.SetOut(io.Discard)
 	cmd.SetErr(io.Discard)
 
-	_, err = cmd.ExecuteC()
+	_, err = cmd.ExecuteContextC(ctx)
 	require.NoError(t, err, "follow should exit cleanly on interrupt")
+	require.Contains(t, stderr.String(), "Interrupt received, stopping...\nLast log entry received at ")
 	require.GreaterOrEqual(t, callCount, 2, "logs should have been fetched at least twice")
 	require.Contains(t, stdout.String(), "[application] streaming")
 }
@@ -61,8 +61,8 @@ func NewCmdInstanceRemove(f cmdutil.Factory) *cobra.Command {
 			# Skip confirmation prompt (useful for CI/CD)
 			$ vcr instance rm --project-name my-app --instance-name dev --yes
 		`),
-		RunE: func(_ *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 
 			return runRemove(ctx, &opts)
@@ -0,0 +1,93 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/format"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	Name string
+}
+
+func NewCmdProjectDescribe(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "describe <project-name>",
+		Short: "Show a project and its instances",
+		Long: heredoc.Doc(`Show a project and its instances.
+
+			This command displays the ID of the project and when it was created and last
+			updated, followed by a table of its instances with their IDs, linked API
+			application IDs, instance names and service names.
+		`),
+		Args: cobra.ExactArgs(1),
+		Example: heredoc.Doc(`
+			$ vcr project describe my-app
+			ℹ id: 12345678-1234-1234-1234-123456789abc
+			ℹ name: my-app
+			ℹ created: 2024-01-31 09:30
+			ℹ updated: 2024-03-02 17:05
+			┌──────────────────────────────────────┬──────────────────────────────────────┬───────────────┬──────────────┐
+			│             INSTANCE ID              │          API APPLICATION ID          │ INSTANCE NAME │ SERVICE NAME │
+			├──────────────────────────────────────┼──────────────────────────────────────┼───────────────┼──────────────┤
+			│ 87654321-4321-4321-4321-cba987654321 │ aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa │ dev           │ my-app-dev   │
+			└──────────────────────────────────────┴──────────────────────────────────────┴───────────────┴──────────────┘
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
+			defer cancel()
+
+			opts.Name = args[0]
+			return runDescribe(ctx, &opts)
+		},
+	}
+
+	return cmd
+}
+
+func runDescribe(ctx context.Context, opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	project, err := cmdutil.GetProject(ctx, opts, opts.Name)
+	if err != nil {
+		return err
+	}
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving instances...")
+	instances, err := opts.Datastore().ListInstancesByProjectID(ctx, project.ID)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list the instances of project %q: %w", project.Name, err)
+	}
+
+	fmt.Fprintf(io.Out, "%s id: %s\n", c.Blue(cmdutil.InfoIcon), project.ID)
+	fmt.Fprintf(io.Out, "%s name: %s\n", c.Blue(cmdutil.InfoIcon), project.Name)
+	fmt.Fprintf(io.Out, "%s created: %s\n", c.Blue(cmdutil.InfoIcon), format.Timestamp(project.CreatedAt))
+	fmt.Fprintf(io.Out, "%s updated: %s\n", c.Blue(cmdutil.InfoIcon), format.Timestamp(project.UpdatedAt))
+	if len(instances) == 0 {
+		fmt.Fprintf(io.Out, "%s No instances, remove the project with 'vcr project remove %s'\n", c.Blue(cmdutil.InfoIcon), project.Name)
+		return nil
+	}
+
+	table := tablewriter.NewWriter(io.Out)
+	table.Header("Instance ID", "API Application ID", "Instance Name", "Service Name")
+	for _, inst := range instances {
+		if err := table.Append([]string{inst.ID, inst.APIApplicationID, inst.Name, inst.ServiceName}); err != nil {
+			return fmt.Errorf("failed to append instance to table: %w", err)
+		}
+	}
+	return table.Render()
+}
@@ -0,0 +1,129 @@
+package describe
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+func TestProjectDescribe(t *testing.T) {
+	type mock struct {
+		GetProjectReturn       api.Project
+		GetProjectReturnErr    error
+		ListInstancesTimes     int
+		ListInstancesReturn    []api.InstanceListItem
+		ListInstancesReturnErr error
+	}
+	type want struct {
+		errMsg   string
+		stdout   string
+		contains []string
+	}
+
+	project := api.Project{ID: "project-id", Name: "my-app", CreatedAt: "2024-01-31T09:30:15", UpdatedAt: "2024-03-02T17:05:00"}
+
+	tests := []struct {
+		name string
+		cli  string
+		mock mock
+		want want
+	}{
+		{
+			name: "happy-path",
+			cli:  "my-app",
+			mock: mock{
+				GetProjectReturn:   project,
+				ListInstancesTimes: 1,
+				ListInstancesReturn: []api.InstanceListItem{
+					{ID: "instance-1", APIApplicationID: "app-id", Name: "dev", ServiceName: "my-app-dev"},
+					{ID: "instance-2", APIApplicationID: "app-id", Name: "prod", ServiceName: "my-app-prod"},
+				},
+			},
+			want: want{
+				contains: []string{
+					"ℹ id: project-id\nℹ name: my-app\nℹ created: 2024-01-31 09:30\nℹ updated: 2024-03-02 17:05\n",
+					"INSTANCE ID", "API APPLICATION ID", "INSTANCE NAME", "SERVICE NAME",
+					"instance-1", "app-id", "dev", "my-app-dev",
+					"instance-2", "prod", "my-app-prod",
+				},
+			},
+		},
+		{
+			name: "no-instances",
+			cli:  "my-app",
+			mock: mock{
+				GetProjectReturn:   project,
+				ListInstancesTimes: 1,
+			},
+			want: want{
+				stdout: "ℹ id: project-id\nℹ name: my-app\nℹ created: 2024-01-31 09:30\nℹ updated: 2024-03-02 17:05\nℹ No instances, remove the project with 'vcr project remove my-app'\n",
+			},
+		},
+		{
+			name: "project-not-found",
+			cli:  "my-app",
+			mock: mock{GetProjectReturnErr: api.ErrNotFound},
+			want: want{errMsg: "project \"my-app\" not found, see 'vcr project list' for your projects"},
+		},
+		{
+			name: "list-instances-error",
+			cli:  "my-app",
+			mock: mock{
+				GetProjectReturn:       project,
+				ListInstancesTimes:     1,
+				ListInstancesReturnErr: errors.New("api error"),
+			},
+			want: want{errMsg: "failed to list the instances of project \"my-app\": api error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			datastoreMock.EXPECT().GetProject(gomock.Any(), testutil.DefaultAPIKey, "my-app").
+				Times(1).
+				Return(tt.mock.GetProjectReturn, tt.mock.GetProjectReturnErr)
+			datastoreMock.EXPECT().ListInstancesByProjectID(gomock.Any(), "project-id").
+				Times(tt.mock.ListInstancesTimes).
+				Return(tt.mock.ListInstancesReturn, tt.mock.ListInstancesReturnErr)
+
+			ios, _, stdout, _ := iostreams.Test()
+			argv, err := shlex.Split(tt.cli)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, datastoreMock, nil, nil, nil)
+
+			cmd := NewCmdProjectDescribe(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want.stdout != "" {
+				require.Equal(t, tt.want.stdout, stdout.String())
+			}
+			for _, s := range tt.want.contains {
+				require.Contains(t, stdout.String(), s)
+			}
+		})
+	}
+}
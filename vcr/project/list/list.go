@@ -0,0 +1,91 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/format"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	JSON bool
+}
+
+func NewCmdProjectList(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the projects of your account",
+		Long: heredoc.Doc(`List the projects of your account.
+
+			This command displays the projects of the account of your API key, showing
+			their name, ID, number of instances and when they were created and last
+			updated. Projects without instances are left over from removed instances
+			and can be deleted with 'vcr project remove'.
+
+			Use --json to print the projects as JSON.
+		`),
+		Example: heredoc.Doc(`
+			# List your projects
+			$ vcr project list
+			┌────────┬──────────────────────────────────────┬───────────┬──────────────────┬──────────────────┐
+			│  NAME  │                  ID                  │ INSTANCES │     CREATED      │     UPDATED      │
+			├────────┼──────────────────────────────────────┼───────────┼──────────────────┼──────────────────┤
+			│ my-app │ 12345678-1234-1234-1234-123456789abc │ 2         │ 2024-01-31 09:30 │ 2024-03-02 17:05 │
+			└────────┴──────────────────────────────────────┴───────────┴──────────────────┴──────────────────┘
+
+			# Print the names of the projects without instances
+			$ vcr project ls --json | jq -r '.[] | select(.instance_count == 0) | .name'
+		`),
+		Aliases: []string{"ls"},
+		Args:    cobra.MaximumNArgs(0),
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.JSON = cmdutil.JSONOutput(cmd, opts.CliConfig(), opts.JSON)
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
+			defer cancel()
+
+			return runList(ctx, &opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.JSON, "json", "", false, "Print the projects as JSON")
+
+	return cmd
+}
+
+func runList(ctx context.Context, opts *Options) error {
+	io := opts.IOStreams()
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving projects... ")
+	projects, err := opts.Datastore().ListProjects(ctx, opts.APIKey())
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	if opts.JSON {
+		return format.PrintJSON(io.Out, projects)
+	}
+
+	table := tablewriter.NewWriter(io.Out)
+	table.Header("Name", "ID", "Instances", "Created", "Updated")
+	for _, p := range projects {
+		row := []string{p.Name, p.ID, strconv.Itoa(p.InstanceCount), format.Timestamp(p.CreatedAt), format.Timestamp(p.UpdatedAt)}
+		if err := table.Append(row); err != nil {
+			return fmt.Errorf("failed to append project to table: %w", err)
+		}
+	}
+	return table.Render()
+}
@@ -0,0 +1,114 @@
+package list
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+func TestProjectList(t *testing.T) {
+	type mock struct {
+		ListReturnProjects []api.ProjectListItem
+		ListReturnErr      error
+	}
+	type want struct {
+		errMsg   string
+		stdout   string
+		contains []string
+	}
+
+	projects := []api.ProjectListItem{
+		{ID: "11111111-1111-1111-1111-111111111111", Name: "my-app", CreatedAt: "2024-01-31T09:30:15", UpdatedAt: "2024-03-02T17:05:00", InstanceCount: 2},
+		{ID: "22222222-2222-2222-2222-222222222222", Name: "old-app", CreatedAt: "2023-06-01T00:00:00"},
+	}
+
+	tests := []struct {
+		name string
+		cli  string
+		mock mock
+		want want
+	}{
+		{
+			name: "happy-path",
+			cli:  "",
+			mock: mock{ListReturnProjects: projects},
+			want: want{
+				contains: []string{
+					"NAME", "ID", "INSTANCES", "CREATED", "UPDATED",
+					"my-app", "11111111-1111-1111-1111-111111111111", "2", "2024-01-31 09:30", "2024-03-02 17:05",
+					"old-app", "22222222-2222-2222-2222-222222222222", "0", "2023-06-01 00:00", "-",
+				},
+			},
+		},
+		{
+			name: "json",
+			cli:  "--json",
+			mock: mock{ListReturnProjects: projects[:1]},
+			want: want{
+				stdout: `[
+  {
+    "id": "11111111-1111-1111-1111-111111111111",
+    "name": "my-app",
+    "created_at": "2024-01-31T09:30:15",
+    "updated_at": "2024-03-02T17:05:00",
+    "instance_count": 2
+  }
+]
+`,
+			},
+		},
+		{
+			name: "with-api-error",
+			cli:  "",
+			mock: mock{ListReturnErr: errors.New("api error")},
+			want: want{errMsg: "failed to list projects: api error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			datastoreMock.EXPECT().ListProjects(gomock.Any(), testutil.DefaultAPIKey).
+				Times(1).
+				Return(tt.mock.ListReturnProjects, tt.mock.ListReturnErr)
+
+			ios, _, stdout, _ := iostreams.Test()
+			argv, err := shlex.Split(tt.cli)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, datastoreMock, nil, nil, nil)
+
+			cmd := NewCmdProjectList(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want.stdout != "" {
+				require.Equal(t, tt.want.stdout, stdout.String())
+			}
+			for _, s := range tt.want.contains {
+				require.Contains(t, stdout.String(), s)
+			}
+		})
+	}
+}
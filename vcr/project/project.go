@@ -0,0 +1,44 @@
+package project
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	describeCmd "vonage-cloud-runtime-cli/vcr/project/describe"
+	listCmd "vonage-cloud-runtime-cli/vcr/project/list"
+	removeCmd "vonage-cloud-runtime-cli/vcr/project/remove"
+)
+
+func NewCmdProject(f cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project <command>",
+		Short: "Manage the projects of your VCR instances",
+		Long: heredoc.Doc(`Manage the projects of your VCR instances.
+
+			A project groups the instances deployed from the same vcr.yml manifest, e.g.
+			the dev and prod instances of an application. 'vcr deploy' creates the
+			project named in the manifest the first time it is deployed to.
+
+			AVAILABLE COMMANDS
+			  list (ls)     List the projects of your account
+			  describe      Show a project and its instances
+			  remove (rm)   Delete a project and all its instances
+		`),
+		Example: heredoc.Doc(`
+			# List your projects
+			$ vcr project list
+
+			# Show the instances of a project
+			$ vcr project describe my-app
+
+			# Delete a project and its instances without confirmation
+			$ vcr project remove my-app --yes
+		`),
+	}
+
+	cmd.AddCommand(listCmd.NewCmdProjectList(f))
+	cmd.AddCommand(describeCmd.NewCmdProjectDescribe(f))
+	cmd.AddCommand(removeCmd.NewCmdProjectRemove(f))
+	return cmd
+}
@@ -0,0 +1,142 @@
+package remove
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	Name string
+
+	SkipPrompts bool
+}
+
+func NewCmdProjectRemove(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "remove <project-name>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a project and all its instances",
+		Long: heredoc.Doc(`Delete a project and all its instances.
+
+			This command removes each instance of the project, like 'vcr instance remove',
+			and then deletes the project itself. If removing an instance fails or the
+			command is interrupted, it stops, keeps the project and prints the IDs of the
+			instances that were removed and of those that are left, so that it can be run
+			again.
+
+			WARNING: This action is irreversible. All data associated with the instances
+			will be permanently deleted. You will be prompted for confirmation unless
+			--yes is specified, which is required when the terminal is not interactive.
+		`),
+		Args: cobra.ExactArgs(1),
+		Example: heredoc.Doc(`
+			$ vcr project remove my-app
+			? are you sure you want to remove project "my-app" and its 2 instances (dev, prod) ? Yes
+			✓ Instance "87654321-4321-4321-4321-cba987654321" successfully removed
+			✓ Instance "12121212-3434-5656-7878-909090909090" successfully removed
+			✓ Project "my-app" successfully removed
+
+			# Skip confirmation prompt (useful for CI/CD)
+			$ vcr project rm my-app --yes
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
+			defer cancel()
+
+			opts.Name = args[0]
+			return runRemove(ctx, &opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.SkipPrompts, "yes", "y", false, "Skip confirmation prompt (use with caution)")
+
+	return cmd
+}
+
+func runRemove(ctx context.Context, opts *Options) (err error) {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	project, err := cmdutil.GetProject(ctx, opts, opts.Name)
+	if err != nil {
+		return err
+	}
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving instances...")
+	instances, err := opts.Datastore().ListInstancesByProjectID(ctx, project.ID)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list the instances of project %q: %w", project.Name, err)
+	}
+
+	if !opts.SkipPrompts {
+		if !io.CanPrompt() {
+			return fmt.Errorf("the terminal is not interactive, use --yes to confirm removing project %q and its %d instances", project.Name, len(instances))
+		}
+		question := fmt.Sprintf("are you sure you want to remove project %q ?", project.Name)
+		if len(instances) > 0 {
+			names := make([]string, 0, len(instances))
+			for _, inst := range instances {
+				names = append(names, inst.Name)
+			}
+			question = fmt.Sprintf("are you sure you want to remove project %q and its %d instances (%s) ?", project.Name, len(instances), strings.Join(names, ", "))
+		}
+		if !opts.Survey().AskYesNo(question) {
+			fmt.Fprintf(io.ErrOut, "%s Project removal aborted\n", c.WarningIcon())
+			return nil
+		}
+	}
+
+	// removed and remaining are reported when the removal stops partway, so that the user knows which
+	// instances are already gone before running the command again
+	var removed []string
+	remaining := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		remaining = append(remaining, inst.ID)
+	}
+	defer func() {
+		if err == nil || len(instances) == 0 {
+			return
+		}
+		status := "failed"
+		if cmdutil.Interrupted(ctx) {
+			status = "interrupted"
+		}
+		fmt.Fprintf(io.ErrOut, "%s Project removal %s, project %q is kept: removed instances=[%s], remaining instances=[%s]\n",
+			c.WarningIcon(), status, project.Name, strings.Join(removed, ", "), strings.Join(remaining, ", "))
+	}()
+
+	for _, inst := range instances {
+		spinner = cmdutil.DisplaySpinnerMessageWithHandle(fmt.Sprintf(" Removing instance with id=%q and service_name=%q...", inst.ID, inst.ServiceName))
+		err = opts.DeploymentClient().DeleteInstance(ctx, inst.ID)
+		spinner.Stop()
+		if err != nil {
+			return fmt.Errorf("failed to remove instance %q of project %q: %w", inst.ID, project.Name, err)
+		}
+		fmt.Fprintf(io.Out, "%s Instance %q successfully removed\n", c.SuccessIcon(), inst.ID)
+		removed = append(removed, inst.ID)
+		remaining = remaining[1:]
+	}
+
+	spinner = cmdutil.DisplaySpinnerMessageWithHandle(fmt.Sprintf(" Removing project %q...", project.Name))
+	err = opts.DeploymentClient().DeleteProject(ctx, project.ID)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to remove project %q: %w", project.Name, err)
+	}
+
+	fmt.Fprintf(io.Out, "%s Project %q successfully removed\n", c.SuccessIcon(), project.Name)
+	return nil
+}
@@ -0,0 +1,271 @@
+package remove
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+func TestProjectRemove(t *testing.T) {
+	project := api.Project{ID: "project-id", Name: "my-app"}
+	instances := []api.InstanceListItem{
+		{ID: "instance-1", Name: "dev", ServiceName: "my-app-dev"},
+		{ID: "instance-2", Name: "prod", ServiceName: "my-app-prod"},
+	}
+
+	type mock struct {
+		GetProjectReturnErr error
+		ListInstancesTimes  int
+		ListInstancesReturn []api.InstanceListItem
+
+		AskYesNoTimes    int
+		AskYesNoQuestion string
+		AskYesNoReturn   bool
+
+		DeleteInstanceTimes int
+		DeleteInstanceErrs  []error
+		// DeleteInstanceInterruptAt interrupts the command during the n-th instance removal
+		DeleteInstanceInterruptAt int
+		DeleteProjectTimes        int
+		DeleteProjectErr          error
+	}
+	type want struct {
+		errMsg string
+		stdout string
+		stderr string
+	}
+
+	tests := []struct {
+		name   string
+		cli    string
+		notTTY bool
+		mock   mock
+		want   want
+	}{
+		{
+			name: "happy-path-with-yes-flag",
+			cli:  "my-app --yes",
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+				DeleteInstanceTimes: 2,
+				DeleteProjectTimes:  1,
+			},
+			want: want{
+				stdout: "✓ Instance \"instance-1\" successfully removed\n✓ Instance \"instance-2\" successfully removed\n✓ Project \"my-app\" successfully removed\n",
+			},
+		},
+		{
+			name: "confirmed",
+			cli:  "my-app",
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+				AskYesNoTimes:       1,
+				AskYesNoQuestion:    "are you sure you want to remove project \"my-app\" and its 2 instances (dev, prod) ?",
+				AskYesNoReturn:      true,
+				DeleteInstanceTimes: 2,
+				DeleteProjectTimes:  1,
+			},
+			want: want{
+				stdout: "✓ Instance \"instance-1\" successfully removed\n✓ Instance \"instance-2\" successfully removed\n✓ Project \"my-app\" successfully removed\n",
+			},
+		},
+		{
+			name: "empty-project-confirmed",
+			cli:  "my-app",
+			mock: mock{
+				ListInstancesTimes: 1,
+				AskYesNoTimes:      1,
+				AskYesNoQuestion:   "are you sure you want to remove project \"my-app\" ?",
+				AskYesNoReturn:     true,
+				DeleteProjectTimes: 1,
+			},
+			want: want{
+				stdout: "✓ Project \"my-app\" successfully removed\n",
+			},
+		},
+		{
+			name: "aborted",
+			cli:  "my-app",
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+				AskYesNoTimes:       1,
+				AskYesNoQuestion:    "are you sure you want to remove project \"my-app\" and its 2 instances (dev, prod) ?",
+				AskYesNoReturn:      false,
+			},
+			want: want{
+				stderr: "! Project removal aborted\n",
+			},
+		},
+		{
+			name:   "not-interactive-without-yes",
+			cli:    "my-app",
+			notTTY: true,
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+			},
+			want: want{
+				errMsg: "the terminal is not interactive, use --yes to confirm removing project \"my-app\" and its 2 instances",
+			},
+		},
+		{
+			name:   "not-interactive-with-yes",
+			cli:    "my-app --yes",
+			notTTY: true,
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+				DeleteInstanceTimes: 2,
+				DeleteProjectTimes:  1,
+			},
+			want: want{
+				stdout: "✓ Instance \"instance-1\" successfully removed\n✓ Instance \"instance-2\" successfully removed\n✓ Project \"my-app\" successfully removed\n",
+			},
+		},
+		{
+			name: "project-not-found",
+			cli:  "my-app --yes",
+			mock: mock{GetProjectReturnErr: api.ErrNotFound},
+			want: want{errMsg: "project \"my-app\" not found, see 'vcr project list' for your projects"},
+		},
+		{
+			name: "instance-removal-fails-keeps-project",
+			cli:  "my-app --yes",
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+				DeleteInstanceTimes: 1,
+				DeleteInstanceErrs:  []error{errors.New("api error")},
+			},
+			want: want{
+				errMsg: "failed to remove instance \"instance-1\" of project \"my-app\": api error",
+				stderr: "! Project removal failed, project \"my-app\" is kept: removed instances=[], remaining instances=[instance-1, instance-2]\n",
+			},
+		},
+		{
+			name: "second-instance-removal-fails-reports-progress",
+			cli:  "my-app --yes",
+			mock: mock{
+				ListInstancesTimes:  1,
+				ListInstancesReturn: instances,
+				DeleteInstanceTimes: 2,
+				DeleteInstanceErrs:  []error{nil, errors.New("api error")},
+			},
+			want: want{
+				errMsg: "failed to remove instance \"instance-2\" of project \"my-app\": api error",
+				stdout: "✓ Instance \"instance-1\" successfully removed\n",
+				stderr: "! Project removal failed, project \"my-app\" is kept: removed instances=[instance-1], remaining instances=[instance-2]\n",
+			},
+		},
+		{
+			name: "interrupted-reports-progress",
+			cli:  "my-app --yes",
+			mock: mock{
+				ListInstancesTimes:        1,
+				ListInstancesReturn:       instances,
+				DeleteInstanceTimes:       2,
+				DeleteInstanceInterruptAt: 2,
+			},
+			want: want{
+				errMsg: "failed to remove instance \"instance-2\" of project \"my-app\": context canceled",
+				stdout: "✓ Instance \"instance-1\" successfully removed\n",
+				stderr: "! Project removal interrupted, project \"my-app\" is kept: removed instances=[instance-1], remaining instances=[instance-2]\n",
+			},
+		},
+		{
+			name: "project-removal-fails",
+			cli:  "my-app --yes",
+			mock: mock{
+				ListInstancesTimes: 1,
+				DeleteProjectTimes: 1,
+				DeleteProjectErr:   errors.New("api error"),
+			},
+			want: want{errMsg: "failed to remove project \"my-app\": api error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancelCause(t.Context())
+			defer cancel(nil)
+			ctrl := gomock.NewController(t)
+
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			datastoreMock.EXPECT().GetProject(gomock.Any(), testutil.DefaultAPIKey, "my-app").
+				Times(1).
+				Return(project, tt.mock.GetProjectReturnErr)
+			datastoreMock.EXPECT().ListInstancesByProjectID(gomock.Any(), "project-id").
+				Times(tt.mock.ListInstancesTimes).
+				Return(tt.mock.ListInstancesReturn, nil)
+
+			surveyMock := mocks.NewMockSurveyInterface(ctrl)
+			surveyMock.EXPECT().AskYesNo(tt.mock.AskYesNoQuestion).
+				Times(tt.mock.AskYesNoTimes).
+				Return(tt.mock.AskYesNoReturn)
+
+			deploymentMock := mocks.NewMockDeploymentInterface(ctrl)
+			deleteInstanceCalls := 0
+			deploymentMock.EXPECT().DeleteInstance(gomock.Any(), gomock.Any()).
+				Times(tt.mock.DeleteInstanceTimes).
+				DoAndReturn(func(_ context.Context, _ string) error {
+					defer func() { deleteInstanceCalls++ }()
+					if deleteInstanceCalls+1 == tt.mock.DeleteInstanceInterruptAt {
+						cancel(cmdutil.ErrInterrupted)
+						return context.Canceled
+					}
+					if deleteInstanceCalls < len(tt.mock.DeleteInstanceErrs) {
+						return tt.mock.DeleteInstanceErrs[deleteInstanceCalls]
+					}
+					return nil
+				})
+			deploymentMock.EXPECT().DeleteProject(gomock.Any(), "project-id").
+				Times(tt.mock.DeleteProjectTimes).
+				Return(tt.mock.DeleteProjectErr)
+
+			ios, _, stdout, stderr := iostreams.Test()
+			ios.SetStdinTTY(!tt.notTTY)
+			ios.SetStdoutTTY(!tt.notTTY)
+
+			argv, err := shlex.Split(tt.cli)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, datastoreMock, deploymentMock, surveyMock, nil)
+
+			cmd := NewCmdProjectRemove(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteContextC(ctx)
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				require.Equal(t, tt.want.stdout, stdout.String())
+				require.Equal(t, tt.want.stderr, stderr.String())
+				return
+			}
+			require.NoError(t, err)
+			if tt.want.stderr != "" {
+				require.Equal(t, tt.want.stderr, stderr.String())
+			}
+			require.Equal(t, tt.want.stdout, stdout.String())
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/format"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	JSON bool
+}
+
+func NewCmdRegionList(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the available regions",
+		Long: heredoc.Doc(`List the available regions.
+
+			This command displays the enabled regions of the platform, showing their alias,
+			which is the value to use with --region or in the manifest, their name, the
+			host template of the instances deployed there and the URLs of their APIs.
+
+			Use --json to print all the details of the regions as JSON.
+		`),
+		Example: heredoc.Doc(`
+			# List the regions
+			$ vcr region list
+
+			# Print the aliases of the regions
+			$ vcr region ls --json | jq -r '.[].alias'
+		`),
+		Aliases: []string{"ls"},
+		Args:    cobra.MaximumNArgs(0),
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.JSON = cmdutil.JSONOutput(cmd, opts.CliConfig(), opts.JSON)
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
+			defer cancel()
+
+			return runList(ctx, &opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.JSON, "json", "", false, "Print the regions as JSON")
+
+	return cmd
+}
+
+func runList(ctx context.Context, opts *Options) error {
+	io := opts.IOStreams()
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving regions... ")
+	regions, err := opts.Datastore().ListRegions(ctx)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list regions: %w", err)
+	}
+
+	if opts.JSON {
+		return format.PrintJSON(io.Out, regions)
+	}
+
+	table := tablewriter.NewWriter(io.Out)
+	table.Header("Alias", "Name", "Host Template", "Deployment API URL", "Assets API URL")
+	for _, r := range regions {
+		if err := table.Append([]string{r.Alias, r.Name, r.HostTemplate, r.DeploymentAPIURL, r.AssetsAPIURL}); err != nil {
+			return fmt.Errorf("failed to append region to table: %w", err)
+		}
+	}
+	return table.Render()
+}
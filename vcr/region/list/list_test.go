@@ -0,0 +1,129 @@
+package list
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+func TestRegionList(t *testing.T) {
+	type mock struct {
+		ListReturnRegions []api.Region
+		ListReturnErr     error
+	}
+	type want struct {
+		errMsg   string
+		stdout   string
+		contains []string
+	}
+
+	regions := []api.Region{
+		{
+			Name:             "AWS - Europe West 1",
+			Alias:            "aws.euw1",
+			HostTemplate:     "https://{{.ServiceName}}.euw1.runtime.vonage.cloud",
+			DeploymentAPIURL: "https://api.euw1.runtime.vonage.cloud",
+			AssetsAPIURL:     "https://assets.euw1.runtime.vonage.cloud",
+		},
+		{
+			Name:             "AWS - US East 1",
+			Alias:            "aws.use1",
+			HostTemplate:     "https://{{.ServiceName}}.use1.runtime.vonage.cloud",
+			DeploymentAPIURL: "https://api.use1.runtime.vonage.cloud",
+			AssetsAPIURL:     "https://assets.use1.runtime.vonage.cloud",
+		},
+	}
+
+	tests := []struct {
+		name string
+		cli  string
+		mock mock
+		want want
+	}{
+		{
+			name: "happy-path",
+			cli:  "",
+			mock: mock{ListReturnRegions: regions},
+			want: want{
+				contains: []string{
+					"ALIAS", "NAME", "HOST TEMPLATE", "DEPLOYMENT API URL", "ASSETS API URL",
+					"aws.euw1", "AWS - Europe West 1", "https://{{.ServiceName}}.euw1.runtime.vonage.cloud", "https://api.euw1.runtime.vonage.cloud",
+					"aws.use1", "https://assets.use1.runtime.vonage.cloud",
+				},
+			},
+		},
+		{
+			name: "json",
+			cli:  "--json",
+			mock: mock{ListReturnRegions: regions[:1]},
+			want: want{
+				stdout: `[
+  {
+    "name": "AWS - Europe West 1",
+    "alias": "aws.euw1",
+    "deployment_api_url": "https://api.euw1.runtime.vonage.cloud",
+    "assets_api_url": "https://assets.euw1.runtime.vonage.cloud",
+    "marketplace_api_url": "",
+    "endpoint_url_scheme": "",
+    "debugger_url_scheme": "",
+    "host_template": "https://{{.ServiceName}}.euw1.runtime.vonage.cloud"
+  }
+]
+`,
+			},
+		},
+		{
+			name: "with-api-error",
+			cli:  "",
+			mock: mock{ListReturnErr: errors.New("api error")},
+			want: want{errMsg: "failed to list regions: api error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			datastoreMock.EXPECT().ListRegions(gomock.Any()).
+				Times(1).
+				Return(tt.mock.ListReturnRegions, tt.mock.ListReturnErr)
+
+			ios, _, stdout, _ := iostreams.Test()
+			argv, err := shlex.Split(tt.cli)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, datastoreMock, nil, nil, nil)
+
+			cmd := NewCmdRegionList(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want.stdout != "" {
+				require.Equal(t, tt.want.stdout, stdout.String())
+			}
+			for _, s := range tt.want.contains {
+				require.Contains(t, stdout.String(), s)
+			}
+		})
+	}
+}
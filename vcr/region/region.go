@@ -0,0 +1,38 @@
+package region
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	listCmd "vonage-cloud-runtime-cli/vcr/region/list"
+)
+
+func NewCmdRegion(f cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "region <command>",
+		Short: "List the regions of the Vonage Cloud Runtime platform",
+		Long: heredoc.Doc(`List the regions of the Vonage Cloud Runtime platform.
+
+			A region is where your instances are deployed. Select it with --region, the
+			region of your vcr.yml manifest, or the default region set by 'vcr configure'.
+
+			AVAILABLE COMMANDS
+			  list (ls)  List the available regions
+
+			CACHE
+			  The list of regions is cached for a day in ~/.vcr-cli.d. Use --refresh to
+			  fetch it again.
+		`),
+		Example: heredoc.Doc(`
+			# List the available regions
+			$ vcr region list
+
+			# List the regions as JSON
+			$ vcr region list --json
+		`),
+	}
+
+	cmd.AddCommand(listCmd.NewCmdRegionList(f))
+	return cmd
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/MakeNowJust/heredoc"
+	"github.com/blang/semver"
 	"github.com/spf13/cobra"
 
 	"vonage-cloud-runtime-cli/pkg/cmdutil"
@@ -14,12 +15,17 @@ import (
 	"vonage-cloud-runtime-cli/pkg/format"
 
 	appCmd "vonage-cloud-runtime-cli/vcr/app"
+	configCmd "vonage-cloud-runtime-cli/vcr/config"
 	configureCmd "vonage-cloud-runtime-cli/vcr/configure"
 	debugCmd "vonage-cloud-runtime-cli/vcr/debug"
 	deployCmd "vonage-cloud-runtime-cli/vcr/deploy"
 	initCmd "vonage-cloud-runtime-cli/vcr/init"
 	instanceCmd "vonage-cloud-runtime-cli/vcr/instance"
+	projectCmd "vonage-cloud-runtime-cli/vcr/project"
+	regionCmd "vonage-cloud-runtime-cli/vcr/region"
+	runtimeCmd "vonage-cloud-runtime-cli/vcr/runtime"
 	secretCmd "vonage-cloud-runtime-cli/vcr/secret"
+	templateCmd "vonage-cloud-runtime-cli/vcr/template"
 	upgradeCmd "vonage-cloud-runtime-cli/vcr/upgrade"
 )
 
@@ -55,13 +61,57 @@ func NewCmdRoot(f cmdutil.Factory, version, buildDate, commit string, updateStre
 
 			CORE WORKFLOW
 			  • vcr configure  - Set up your Vonage API credentials and region
+			  • vcr config     - Read and change individual settings
 			  • vcr app        - Create and manage Vonage applications
 			  • vcr init       - Initialize a project from a template
 			  • vcr deploy     - Deploy your application to VCR
 			  • vcr debug      - Run your application locally in debug mode
 			  • vcr instance   - Manage deployed instances (logs, removal)
+			  • vcr project    - List, inspect and delete projects
+			  • vcr region     - List the regions of the platform
+			  • vcr runtime    - List the runtimes your applications can run on
 			  • vcr secret     - Manage secrets for your applications
+			  • vcr template   - Browse and download project templates
 			  • vcr upgrade    - Update the VCR CLI to the latest version
+
+			ENVIRONMENT VARIABLES
+			  Global options can be set with environment variables, which is convenient
+			  in CI. A flag always wins over its variable, and a variable wins over the
+			  config file:
+			    VCR_API_KEY, VCR_API_SECRET, VCR_REGION, VCR_GRAPHQL_ENDPOINT,
+			    VCR_TIMEOUT (e.g. 15m), VCR_RETRIES, VCR_PROFILE, VCR_DEBUG=api,
+			    VCR_CA_BUNDLE, VCR_PROXY
+
+			  When the API key and secret are provided this way, no config file is needed.
+
+			RETRIES
+			  Requests that are safe to repeat, i.e. reads, deletions and GraphQL queries,
+			  are retried after a connection error or a 429, 502, 503 or 504 response,
+			  waiting longer before each attempt, or as long as the server asks with
+			  Retry-After. Use --retries to change how many times, or 0 to disable it.
+
+			PROXIES AND CERTIFICATES
+			  API requests, including websocket connections, go through the proxy set
+			  with HTTPS_PROXY, HTTP_PROXY and NO_PROXY, or the one given with --proxy.
+			  Behind a TLS-intercepting proxy, use --ca-file with a PEM bundle of the
+			  certificates to trust on top of the system ones.
+
+			DEBUGGING REQUESTS
+			  Every request is sent with its own trace ID, which is shown when it fails;
+			  include it when contacting support. Use --debug-http, or VCR_DEBUG=api, to
+			  log each request and response to stderr with its status and latency. The
+			  Authorization header and secret values are masked.
+
+			METADATA CACHE
+			  The list of regions and runtimes is cached for a day under ~/.vcr-cli.d, per
+			  GraphQL endpoint and API key, so that commands don't fetch it each time. The
+			  region and runtime a command uses are always checked with the platform. Use
+			  --refresh to fetch the list again, e.g. to see a runtime released today.
+
+			UPDATE CHECK
+			  Commands check for a new CLI release at most once a day and never wait for
+			  the result. Disable the check with VCR_NO_UPDATE_NOTIFIER=1, 'vcr config set
+			  update_check false', or in CI, where it is off whenever CI is set.
 		`),
 		Example: heredoc.Doc(`
 			# Configure the CLI with your Vonage credentials
@@ -97,19 +147,23 @@ func NewCmdRoot(f cmdutil.Factory, version, buildDate, commit string, updateStre
 		SilenceErrors: true,
 		SilenceUsage:  true,
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := opts.ApplyEnv(cmd.Flags().Changed); err != nil {
+				close(updateStream)
+				return err
+			}
 			opts.Deadline = time.Now().Add(opts.Timeout)
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline)
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline)
 			defer cancel()
-			if cmd.Name() == "configure" {
+			if !cmdutil.IsAuthCheckEnabled(cmd) {
 				f.SetGlobalOptions(&opts)
 				close(updateStream)
 				return nil
 			}
 
 			if cmd.Name() == "upgrade" {
-				f.InitUpgrade(&opts)
+				err := f.InitUpgrade(&opts)
 				close(updateStream)
-				return nil
+				return err
 			}
 
 			cliConfig, err := config.ReadCLIConfig(opts.ConfigFilePath)
@@ -121,6 +175,7 @@ func NewCmdRoot(f cmdutil.Factory, version, buildDate, commit string, updateStre
 				var path string
 				cliConfig, path, err = config.ReadDefaultCLIConfig()
 				switch {
+				case errors.Is(err, config.ErrNoConfig) && opts.HasCredentials():
 				case errors.Is(err, config.ErrNoConfig):
 					fmt.Fprintf(io.Out, "%s Config file not found at %q, please use 'vcr configure' to create one. Trying to use flags...\n", c.WarningIcon(), opts.ConfigFilePath)
 				case err == nil:
@@ -139,6 +194,17 @@ func NewCmdRoot(f cmdutil.Factory, version, buildDate, commit string, updateStre
 				return fmt.Errorf("failed to initialize cli: %w", err)
 			}
 
+			if !config.UpdateCheckEnabled(cliConfig) {
+				close(updateStream)
+				return nil
+			}
+			if cache, err := config.ReadUpdateCheckCache(updateCheckCachePath); err == nil && cache.IsFresh(time.Now()) {
+				updateStream <- newerVersion(version, cache.LatestVersion)
+				close(updateStream)
+				return nil
+			}
+
+			// the result is only printed if it arrives before the command ends, see format.PrintUpdateMessage
 			go func() {
 				ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(opts.Timeout))
 				defer cancel()
@@ -168,28 +234,47 @@ func NewCmdRoot(f cmdutil.Factory, version, buildDate, commit string, updateStre
 	cmd.Flags().BoolP("version", "v", false, "Show VCR CLI version")
 	cmd.PersistentFlags().Bool("help", false, "Show help for command")
 	cmd.PersistentFlags().StringVarP(&opts.ConfigFilePath, "config-file", "", config.DefaultCLIConfigPath[0], "Path to config file (default is $HOME/.vcr-cli)")
+	cmd.PersistentFlags().StringVarP(&opts.Profile, "profile", "", "", "Named profile from the config file to use (default is $VCR_PROFILE or the top-level settings)")
 	cmd.PersistentFlags().StringVarP(&opts.GraphqlEndpoint, "graphql-endpoint", "", "", "Graphql endpoint used to fetch metadata")
 	cmd.PersistentFlags().StringVarP(&opts.Region, "region", "", "", "Vonage platform region")
 	cmd.PersistentFlags().StringVarP(&opts.APIKey, "api-key", "", "", "Vonage API key")
 	cmd.PersistentFlags().StringVarP(&opts.APISecret, "api-secret", "", "", "Vonage API secret")
 	cmd.PersistentFlags().DurationVarP(&opts.Timeout, "timeout", "t", defaultTimeout, "Timeout for requests to Vonage platform")
+	cmd.PersistentFlags().IntVarP(&opts.Retries, "retries", "", cmdutil.DefaultRetries, "Number of times a failed request that is safe to repeat is retried")
+	cmd.PersistentFlags().StringVarP(&opts.CAFile, "ca-file", "", "", "PEM bundle of extra certificate authorities to trust")
+	cmd.PersistentFlags().StringVarP(&opts.Proxy, "proxy", "", "", "URL of the proxy server to use instead of $HTTPS_PROXY")
+	cmd.PersistentFlags().BoolVarP(&opts.DebugHTTP, "debug-http", "", false, "Log the API requests and responses to stderr")
+	cmd.PersistentFlags().BoolVarP(&opts.Refresh, "refresh", "", false, "Fetch the region and runtime metadata again instead of using the cached one")
 
 	cmd.AddCommand(configureCmd.NewCmdConfigure(f))
+	cmd.AddCommand(configCmd.NewCmdConfig(f))
 	cmd.AddCommand(appCmd.NewCmdApp(f))
 	cmd.AddCommand(initCmd.NewCmdInit(f))
 	cmd.AddCommand(debugCmd.NewCmdDebug(f))
 	cmd.AddCommand(deployCmd.NewCmdDeploy(f))
 	cmd.AddCommand(instanceCmd.NewCmdInstance(f))
+	cmd.AddCommand(projectCmd.NewCmdProject(f))
+	cmd.AddCommand(regionCmd.NewCmdRegion(f))
+	cmd.AddCommand(runtimeCmd.NewCmdRuntime(f))
 	cmd.AddCommand(secretCmd.NewCmdSecret(f))
+	cmd.AddCommand(templateCmd.NewCmdTemplate(f))
 	cmd.AddCommand(upgradeCmd.NewCmdUpgrade(f, version))
 	return cmd
 }
 
+// updateCheckCachePath is where the result of the update check is cached between commands.
+var updateCheckCachePath = config.DefaultUpdateCheckCachePath
+
 func checkForUpdate(ctx context.Context, f cmdutil.Factory, version string) (string, error) {
 	current, err := upgradeCmd.GetCurrentVersion(version)
 	if err != nil {
 		return "", fmt.Errorf("current update is invalid: %w", err)
 	}
+	// the check is recorded before asking GitHub, keeping the version found last, so that a failing or
+	// slow request is not repeated by every command. Failing to write the cache only means that the next
+	// command checks again.
+	previous, _ := config.ReadUpdateCheckCache(updateCheckCachePath)
+	_ = config.WriteUpdateCheckCache(updateCheckCachePath, config.UpdateCheckCache{CheckedAt: time.Now(), LatestVersion: previous.LatestVersion})
 	release, err := f.ReleaseClient().GetLatestRelease(ctx)
 	if err != nil {
 		return "", err
@@ -198,8 +283,22 @@ func checkForUpdate(ctx context.Context, f cmdutil.Factory, version string) (str
 	if err != nil {
 		return "", fmt.Errorf("failed to get latest update: %w", err)
 	}
+	_ = config.WriteUpdateCheckCache(updateCheckCachePath, config.UpdateCheckCache{CheckedAt: time.Now(), LatestVersion: latest.String()})
 	if current.GTE(latest) {
 		return "", nil
 	}
 	return latest.String(), nil
 }
+
+// newerVersion returns latest if it is newer than the current version, and an empty string otherwise.
+func newerVersion(version, latest string) string {
+	current, err := upgradeCmd.GetCurrentVersion(version)
+	if err != nil {
+		return ""
+	}
+	latestVersion, err := semver.Parse(latest)
+	if err != nil || current.GTE(latestVersion) {
+		return ""
+	}
+	return latestVersion.String()
+}
@@ -2,20 +2,28 @@ package root
 
 import (
 	"errors"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/cli/cli/v2/pkg/iostreams"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/config"
 	"vonage-cloud-runtime-cli/testutil"
 	"vonage-cloud-runtime-cli/testutil/mocks"
 )
 
 func TestCheckForUpdate(t *testing.T) {
+	previousPath := updateCheckCachePath
+	t.Cleanup(func() { updateCheckCachePath = previousPath })
+
 	type mock struct {
 		RootCurrentVersion string
+		// CachedVersion is the latest version found by the previous check
+		CachedVersion string
 
 		RootGetLatestReleaseTimes       int
 		RootReturnRelease               api.Release
@@ -24,6 +32,8 @@ func TestCheckForUpdate(t *testing.T) {
 	type want struct {
 		output string
 		errMsg string
+		// cachedVersion is the latest version cached after the check
+		cachedVersion string
 	}
 
 	tests := []struct {
@@ -41,27 +51,39 @@ func TestCheckForUpdate(t *testing.T) {
 				RootGetLatestReleaseReturnError: nil,
 			},
 			want: want{
-				output: "1.0.1",
-				errMsg: "",
+				output:        "1.0.1",
+				errMsg:        "",
+				cachedVersion: "1.0.1",
 			},
 		},
 		{
 			name: "api-error",
 			mock: mock{
 				RootCurrentVersion: "0.0.1",
+				CachedVersion:      "0.0.5",
 
 				RootGetLatestReleaseTimes:       1,
 				RootReturnRelease:               api.Release{},
 				RootGetLatestReleaseReturnError: errors.New("api error"),
 			},
 			want: want{
-				output: "",
-				errMsg: "api error",
+				output:        "",
+				errMsg:        "api error",
+				cachedVersion: "0.0.5",
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			cachePath := filepath.Join(t.TempDir(), "update-check.json")
+			updateCheckCachePath = cachePath
+			if tt.mock.CachedVersion != "" {
+				require.NoError(t, config.WriteUpdateCheckCache(cachePath, config.UpdateCheckCache{
+					CheckedAt:     time.Now().Add(-2 * config.UpdateCheckInterval),
+					LatestVersion: tt.mock.CachedVersion,
+				}))
+			}
+
 			ctrl := gomock.NewController(t)
 			releaseMock := mocks.NewMockReleaseInterface(ctrl)
 
@@ -74,13 +96,25 @@ func TestCheckForUpdate(t *testing.T) {
 			f := testutil.DefaultFactoryMock(t, ios, nil, releaseMock, nil, nil, nil, nil)
 
 			output, err := checkForUpdate(t.Context(), f, tt.mock.RootCurrentVersion)
-			if err != nil && tt.want.errMsg != "" {
-				require.Error(t, err, "should throw error")
-				require.Equal(t, tt.want.errMsg, err.Error())
-				return
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+			} else {
+				require.NoError(t, err)
 			}
-
 			require.Equal(t, tt.want.output, output)
+
+			// the check is cached even when it fails, so that the next command doesn't check again
+			cache, err := config.ReadUpdateCheckCache(cachePath)
+			require.NoError(t, err)
+			require.True(t, cache.IsFresh(time.Now()))
+			require.Equal(t, tt.want.cachedVersion, cache.LatestVersion)
 		})
 	}
 }
+
+func TestNewerVersion(t *testing.T) {
+	require.Equal(t, "1.1.0", newerVersion("1.0.0", "1.1.0"))
+	require.Equal(t, "", newerVersion("1.1.0", "1.1.0"))
+	require.Equal(t, "", newerVersion("v1.2.0", "1.1.0"))
+	require.Equal(t, "", newerVersion("1.0.0", "invalid"))
+}
@@ -0,0 +1,110 @@
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/format"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	JSON bool
+}
+
+func NewCmdRuntimeList(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the available runtimes",
+		Long: heredoc.Doc(`List the available runtimes.
+
+			This command displays the runtimes your applications can be deployed with,
+			showing their name, programming language, API version, whether they are
+			deprecated and the comments of the platform about them.
+
+			Use --json to print the runtimes as JSON.
+		`),
+		Example: heredoc.Doc(`
+			# List the runtimes
+			$ vcr runtime list
+
+			# Print the names of the runtimes that are not deprecated
+			$ vcr runtime ls --json | jq -r '.[] | select(.deprecated | not) | .name'
+		`),
+		Aliases: []string{"ls"},
+		Args:    cobra.MaximumNArgs(0),
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.JSON = cmdutil.JSONOutput(cmd, opts.CliConfig(), opts.JSON)
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
+			defer cancel()
+
+			return runList(ctx, &opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.JSON, "json", "", false, "Print the runtimes as JSON")
+
+	return cmd
+}
+
+func runList(ctx context.Context, opts *Options) error {
+	io := opts.IOStreams()
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving runtimes... ")
+	runtimes, err := opts.Datastore().ListRuntimes(ctx)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to list runtimes: %w", err)
+	}
+
+	// the debug runtimes are the ones of 'vcr debug', which can't be deployed
+	var listed []api.Runtime
+	for _, r := range runtimes {
+		if r.Language != "debug" && r.Language != "" {
+			listed = append(listed, r)
+		}
+	}
+
+	if opts.JSON {
+		out := make([]format.RuntimeJSON, 0, len(listed))
+		for _, r := range listed {
+			out = append(out, format.NewRuntimeJSON(r))
+		}
+		return format.PrintJSON(io.Out, out)
+	}
+
+	table := tablewriter.NewWriter(io.Out)
+	table.Header("Name", "Language", "API Version", "Status", "Comments")
+	for _, r := range listed {
+		if err := table.Append([]string{r.Name, r.Language, orDash(r.APIVersion), status(r), orDash(r.Comments)}); err != nil {
+			return fmt.Errorf("failed to append runtime to table: %w", err)
+		}
+	}
+	return table.Render()
+}
+
+func status(r api.Runtime) string {
+	if r.Deprecated() {
+		return "deprecated"
+	}
+	return "supported"
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,123 @@
+package list
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+func TestRuntimeList(t *testing.T) {
+	type mock struct {
+		ListReturnRuntimes []api.Runtime
+		ListReturnErr      error
+	}
+	type want struct {
+		errMsg      string
+		stdout      string
+		contains    []string
+		notContains []string
+	}
+
+	runtimes := []api.Runtime{
+		{ID: "1", Name: "debug", Language: "debug"},
+		{ID: "2", Name: "nodejs16", Language: "nodejs", APIVersion: "v1", Comments: "deprecated, use nodejs22"},
+		{ID: "3", Name: "nodejs22", Language: "nodejs", APIVersion: "v1"},
+		{ID: "4", Name: "python3", Language: "python", Comments: "beta"},
+	}
+
+	tests := []struct {
+		name string
+		cli  string
+		mock mock
+		want want
+	}{
+		{
+			name: "happy-path",
+			cli:  "",
+			mock: mock{ListReturnRuntimes: runtimes},
+			want: want{
+				contains: []string{
+					"NAME", "LANGUAGE", "API VERSION", "STATUS", "COMMENTS",
+					"nodejs16", "deprecated, use nodejs22",
+					"nodejs22", "supported",
+					"python3", "beta",
+				},
+				notContains: []string{"debug"},
+			},
+		},
+		{
+			name: "json",
+			cli:  "--json",
+			mock: mock{ListReturnRuntimes: runtimes[:2]},
+			want: want{
+				stdout: `[
+  {
+    "id": "2",
+    "name": "nodejs16",
+    "language": "nodejs",
+    "api_version": "v1",
+    "comments": "deprecated, use nodejs22",
+    "deprecated": true
+  }
+]
+`,
+			},
+		},
+		{
+			name: "with-api-error",
+			cli:  "",
+			mock: mock{ListReturnErr: errors.New("api error")},
+			want: want{errMsg: "failed to list runtimes: api error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			datastoreMock.EXPECT().ListRuntimes(gomock.Any()).
+				Times(1).
+				Return(tt.mock.ListReturnRuntimes, tt.mock.ListReturnErr)
+
+			ios, _, stdout, _ := iostreams.Test()
+			argv, err := shlex.Split(tt.cli)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, datastoreMock, nil, nil, nil)
+
+			cmd := NewCmdRuntimeList(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want.stdout != "" {
+				require.Equal(t, tt.want.stdout, stdout.String())
+			}
+			for _, s := range tt.want.contains {
+				require.Contains(t, stdout.String(), s)
+			}
+			for _, s := range tt.want.notContains {
+				require.NotContains(t, stdout.String(), s)
+			}
+		})
+	}
+}
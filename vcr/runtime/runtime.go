@@ -0,0 +1,42 @@
+package runtime
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	listCmd "vonage-cloud-runtime-cli/vcr/runtime/list"
+	showCmd "vonage-cloud-runtime-cli/vcr/runtime/show"
+)
+
+func NewCmdRuntime(f cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "runtime <command>",
+		Short: "List the runtimes your applications can run on",
+		Long: heredoc.Doc(`List the runtimes your applications can run on.
+
+			The runtime of an instance, e.g. nodejs22 or python3, is set in the vcr.yml
+			manifest or with 'vcr deploy --runtime'. Deprecated runtimes still deploy,
+			but should be replaced before they are removed; 'vcr deploy' warns about them.
+
+			AVAILABLE COMMANDS
+			  list (ls)  List the available runtimes
+			  show       Show the details and deprecation notes of a runtime
+
+			CACHE
+			  The list of runtimes is cached for a day in ~/.vcr-cli.d. Use --refresh to
+			  fetch it again.
+		`),
+		Example: heredoc.Doc(`
+			# List the available runtimes
+			$ vcr runtime list
+
+			# Show a runtime
+			$ vcr runtime show nodejs22
+		`),
+	}
+
+	cmd.AddCommand(listCmd.NewCmdRuntimeList(f))
+	cmd.AddCommand(showCmd.NewCmdRuntimeShow(f))
+	return cmd
+}
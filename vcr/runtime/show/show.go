@@ -0,0 +1,104 @@
+package show
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/format"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	Name string
+	JSON bool
+}
+
+func NewCmdRuntimeShow(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "show <runtime-name>",
+		Short: "Show the details and deprecation notes of a runtime",
+		Long: heredoc.Doc(`Show the details and deprecation notes of a runtime.
+
+			This command displays the programming language, API version and comments of
+			a runtime. For a deprecated runtime, it shows what the platform recommends
+			instead, and 'vcr deploy' warns when a manifest still uses it.
+		`),
+		Args: cobra.ExactArgs(1),
+		Example: heredoc.Doc(`
+			$ vcr runtime show nodejs22
+			ℹ name: nodejs22
+			ℹ language: nodejs
+			ℹ api version: v1
+			ℹ status: supported
+
+			$ vcr runtime show nodejs16
+			ℹ name: nodejs16
+			ℹ language: nodejs
+			ℹ api version: v1
+			ℹ status: deprecated
+			! nodejs16 is deprecated: use nodejs22
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.JSON = cmdutil.JSONOutput(cmd, opts.CliConfig(), opts.JSON)
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
+			defer cancel()
+
+			opts.Name = args[0]
+			return runShow(ctx, &opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.JSON, "json", "", false, "Print the runtime as JSON")
+
+	return cmd
+}
+
+func runShow(ctx context.Context, opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving runtime... ")
+	runtime, err := opts.Datastore().GetRuntimeByName(ctx, opts.Name)
+	spinner.Stop()
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("runtime %q not found, see 'vcr runtime list' for the available runtimes", opts.Name)
+		}
+		return fmt.Errorf("failed to get runtime %q: %w", opts.Name, err)
+	}
+
+	if opts.JSON {
+		return format.PrintJSON(io.Out, format.NewRuntimeJSON(runtime))
+	}
+
+	fmt.Fprintf(io.Out, "%s name: %s\n", c.Blue(cmdutil.InfoIcon), runtime.Name)
+	fmt.Fprintf(io.Out, "%s language: %s\n", c.Blue(cmdutil.InfoIcon), runtime.Language)
+	if runtime.APIVersion != "" {
+		fmt.Fprintf(io.Out, "%s api version: %s\n", c.Blue(cmdutil.InfoIcon), runtime.APIVersion)
+	}
+	if !runtime.Deprecated() {
+		fmt.Fprintf(io.Out, "%s status: supported\n", c.Blue(cmdutil.InfoIcon))
+		if runtime.Comments != "" {
+			fmt.Fprintf(io.Out, "%s comments: %s\n", c.Blue(cmdutil.InfoIcon), runtime.Comments)
+		}
+		return nil
+	}
+	fmt.Fprintf(io.Out, "%s status: deprecated\n", c.Blue(cmdutil.InfoIcon))
+	if note := runtime.DeprecationNote(); note != "" {
+		fmt.Fprintf(io.Out, "%s %s is deprecated: %s\n", c.WarningIcon(), runtime.Name, note)
+	} else {
+		fmt.Fprintf(io.Out, "%s %s is deprecated, see 'vcr runtime list' for the supported runtimes\n", c.WarningIcon(), runtime.Name)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package show
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+func TestRuntimeShow(t *testing.T) {
+	type mock struct {
+		GetWantName      string
+		GetReturnRuntime api.Runtime
+		GetReturnErr     error
+	}
+	type want struct {
+		errMsg string
+		stdout string
+	}
+
+	tests := []struct {
+		name string
+		cli  string
+		mock mock
+		want want
+	}{
+		{
+			name: "supported",
+			cli:  "nodejs22",
+			mock: mock{
+				GetWantName:      "nodejs22",
+				GetReturnRuntime: api.Runtime{Name: "nodejs22", Language: "nodejs", APIVersion: "v1"},
+			},
+			want: want{
+				stdout: "ℹ name: nodejs22\nℹ language: nodejs\nℹ api version: v1\nℹ status: supported\n",
+			},
+		},
+		{
+			name: "deprecated-with-note",
+			cli:  "nodejs16",
+			mock: mock{
+				GetWantName:      "nodejs16",
+				GetReturnRuntime: api.Runtime{Name: "nodejs16", Language: "nodejs", APIVersion: "v1", Comments: "Deprecated: use nodejs22"},
+			},
+			want: want{
+				stdout: "ℹ name: nodejs16\nℹ language: nodejs\nℹ api version: v1\nℹ status: deprecated\n! nodejs16 is deprecated: use nodejs22\n",
+			},
+		},
+		{
+			name: "deprecated-without-note",
+			cli:  "nodejs14",
+			mock: mock{
+				GetWantName:      "nodejs14",
+				GetReturnRuntime: api.Runtime{Name: "nodejs14", Language: "nodejs", Comments: "deprecated"},
+			},
+			want: want{
+				stdout: "ℹ name: nodejs14\nℹ language: nodejs\nℹ status: deprecated\n! nodejs14 is deprecated, see 'vcr runtime list' for the supported runtimes\n",
+			},
+		},
+		{
+			name: "json",
+			cli:  "nodejs22 --json",
+			mock: mock{
+				GetWantName:      "nodejs22",
+				GetReturnRuntime: api.Runtime{ID: "3", Name: "nodejs22", Language: "nodejs", APIVersion: "v1"},
+			},
+			want: want{
+				stdout: "{\n  \"id\": \"3\",\n  \"name\": \"nodejs22\",\n  \"language\": \"nodejs\",\n  \"api_version\": \"v1\",\n  \"comments\": \"\",\n  \"deprecated\": false\n}\n",
+			},
+		},
+		{
+			name: "not-found",
+			cli:  "nodejs99",
+			mock: mock{
+				GetWantName:  "nodejs99",
+				GetReturnErr: api.ErrNotFound,
+			},
+			want: want{
+				errMsg: "runtime \"nodejs99\" not found, see 'vcr runtime list' for the available runtimes",
+			},
+		},
+		{
+			name: "with-api-error",
+			cli:  "nodejs22",
+			mock: mock{
+				GetWantName:  "nodejs22",
+				GetReturnErr: errors.New("api error"),
+			},
+			want: want{
+				errMsg: "failed to get runtime \"nodejs22\": api error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			datastoreMock.EXPECT().GetRuntimeByName(gomock.Any(), tt.mock.GetWantName).
+				Times(1).
+				Return(tt.mock.GetReturnRuntime, tt.mock.GetReturnErr)
+
+			ios, _, stdout, _ := iostreams.Test()
+			argv, err := shlex.Split(tt.cli)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, datastoreMock, nil, nil, nil)
+
+			cmd := NewCmdRuntimeShow(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.stdout, stdout.String())
+		})
+	}
+}
@@ -72,8 +72,8 @@ func NewCmdSecretCreate(f cmdutil.Factory) *cobra.Command {
 		Args:    cobra.MaximumNArgs(0),
 		Aliases: []string{"add"},
 
-		RunE: func(_ *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 
 			return runCreate(ctx, &opts)
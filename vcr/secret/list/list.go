@@ -48,8 +48,8 @@ func NewCmdSecretList(f cmdutil.Factory) *cobra.Command {
 		Args:    cobra.MaximumNArgs(0),
 		Aliases: []string{"ls"},
 
-		RunE: func(_ *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 
 			return runList(ctx, &opts)
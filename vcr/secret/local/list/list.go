@@ -0,0 +1,69 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	store *config.LocalSecretStore
+}
+
+func NewCmdSecretLocalList(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+		store:   config.NewDefaultLocalSecretStore(),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List local secret names",
+		Long: heredoc.Doc(`List the names of all secrets in the local encrypted secret store.
+
+			Secret values are never shown.
+		`),
+		Example: heredoc.Doc(`
+			# List local secrets
+			$ vcr secret local list
+			✓ Found 2 local secret(s):
+			  ℹ DATABASE_PASSWORD
+			  ℹ MY_API_KEY
+		`),
+		Args:    cobra.MaximumNArgs(0),
+		Aliases: []string{"ls"},
+
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runList(&opts)
+		},
+	}
+
+	return cmd
+}
+
+func runList(opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	names, err := opts.store.Names()
+	if err != nil {
+		return fmt.Errorf("failed to read local secrets: %w", err)
+	}
+
+	if len(names) == 0 {
+		fmt.Fprintf(io.Out, "%s No local secrets found\n", c.WarningIcon())
+		return nil
+	}
+
+	fmt.Fprintf(io.Out, "%s Found %d local secret(s):\n", c.SuccessIcon(), len(names))
+	for _, name := range names {
+		fmt.Fprintf(io.Out, "  %s %s\n", c.Blue(cmdutil.InfoIcon), name)
+	}
+	return nil
+}
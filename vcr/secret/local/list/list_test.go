@@ -0,0 +1,46 @@
+package list
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/config"
+	"vonage-cloud-runtime-cli/testutil"
+)
+
+func TestSecretLocalList(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets map[string]string
+		stdout  string
+	}{
+		{
+			name:    "happy-path",
+			secrets: map[string]string{"B_SECRET": "b", "A_SECRET": "a"},
+			stdout:  "✓ Found 2 local secret(s):\n  ℹ A_SECRET\n  ℹ B_SECRET\n",
+		},
+		{
+			name:   "empty-store",
+			stdout: "! No local secrets found\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			store := config.NewLocalSecretStore(filepath.Join(tempDir, "secrets.enc"), filepath.Join(tempDir, "secrets.key"), "")
+			if tt.secrets != nil {
+				require.NoError(t, store.Save(tt.secrets))
+			}
+
+			ios, _, stdout, _ := iostreams.Test()
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, nil, nil, nil, nil)
+
+			require.NoError(t, runList(&Options{Factory: f, store: store}))
+			require.Equal(t, tt.stdout, stdout.String())
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package local
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/vcr/secret/local/list"
+	"vonage-cloud-runtime-cli/vcr/secret/local/remove"
+	"vonage-cloud-runtime-cli/vcr/secret/local/set"
+)
+
+func NewCmdSecretLocal(f cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "local <command>",
+		Short: "Manage secrets stored on this machine for debug mode",
+		Long: heredoc.Doc(`Manage secrets stored on this machine for debug mode.
+
+			Local secrets are kept in an encrypted file at ~/.vcr-cli.d/secrets.enc and are
+			never sent to the VCR platform. When 'vcr debug' runs, every 'secret:' reference
+			in your manifest is resolved from an exported environment variable of the same
+			name first, and from the local secret store otherwise.
+
+			ENCRYPTION
+			  The store is encrypted with AES-GCM. By default the key is a random key file
+			  created at ~/.vcr-cli.d/secrets.key with owner-only permissions.
+
+			  Set the VCR_SECRETS_PASSPHRASE environment variable to derive the key from a
+			  passphrase instead. The store is re-encrypted with the passphrase on the next
+			  write, and the same passphrase is then required to read it.
+
+			AVAILABLE COMMANDS
+			  set            Add or replace a local secret
+			  list (ls)      List local secret names
+			  remove (rm)    Delete a local secret
+		`),
+		Example: heredoc.Doc(`
+			# Store a secret for debug mode
+			$ vcr secret local set MY_API_KEY --value "sk-12345..."
+
+			# List local secrets
+			$ vcr secret local list
+
+			# Remove a local secret
+			$ vcr secret local remove MY_API_KEY
+		`),
+	}
+
+	cmdutil.DisableAuthCheck(cmd)
+
+	cmd.AddCommand(set.NewCmdSecretLocalSet(f))
+	cmd.AddCommand(list.NewCmdSecretLocalList(f))
+	cmd.AddCommand(remove.NewCmdSecretLocalRemove(f))
+	return cmd
+}
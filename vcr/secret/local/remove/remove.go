@@ -0,0 +1,69 @@
+package remove
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	Name string
+
+	store *config.LocalSecretStore
+}
+
+func NewCmdSecretLocalRemove(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+		store:   config.NewDefaultLocalSecretStore(),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Delete a local secret",
+		Long: heredoc.Doc(`Delete a secret from the local encrypted secret store.
+
+			Secrets stored in your VCR account are not affected.
+		`),
+		Example: heredoc.Doc(`
+			# Remove a local secret
+			$ vcr secret local remove MY_API_KEY
+			✓ Local secret "MY_API_KEY" removed
+
+			# Using the 'rm' alias
+			$ vcr secret local rm MY_API_KEY
+		`),
+		Args:    cobra.ExactArgs(1),
+		Aliases: []string{"rm"},
+
+		RunE: func(_ *cobra.Command, args []string) error {
+			opts.Name = args[0]
+			return runRemove(&opts)
+		},
+	}
+
+	return cmd
+}
+
+func runRemove(opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	err := opts.store.Remove(opts.Name)
+	switch {
+	case errors.Is(err, config.ErrLocalSecretNotFound):
+		return fmt.Errorf("local secret %q not found", opts.Name)
+	case err != nil:
+		return fmt.Errorf("failed to remove local secret: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "%s Local secret %q removed\n", c.SuccessIcon(), opts.Name)
+	return nil
+}
@@ -0,0 +1,65 @@
+package remove
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/config"
+	"vonage-cloud-runtime-cli/testutil"
+)
+
+func TestSecretLocalRemove(t *testing.T) {
+	type want struct {
+		errMsg  string
+		stdout  string
+		secrets map[string]string
+	}
+
+	tests := []struct {
+		name       string
+		secretName string
+		want       want
+	}{
+		{
+			name:       "happy-path",
+			secretName: "A_SECRET",
+			want: want{
+				stdout:  "✓ Local secret \"A_SECRET\" removed\n",
+				secrets: map[string]string{"B_SECRET": "b"},
+			},
+		},
+		{
+			name:       "not-found",
+			secretName: "MISSING",
+			want: want{
+				errMsg: "local secret \"MISSING\" not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			store := config.NewLocalSecretStore(filepath.Join(tempDir, "secrets.enc"), filepath.Join(tempDir, "secrets.key"), "")
+			require.NoError(t, store.Save(map[string]string{"A_SECRET": "a", "B_SECRET": "b"}))
+
+			ios, _, stdout, _ := iostreams.Test()
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, nil, nil, nil, nil)
+
+			err := runRemove(&Options{Factory: f, Name: tt.secretName, store: store})
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.stdout, stdout.String())
+
+			secrets, err := store.Load()
+			require.NoError(t, err)
+			require.Equal(t, tt.want.secrets, secrets)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package set
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+type Options struct {
+	cmdutil.Factory
+
+	Name       string
+	Value      string
+	SecretFile string
+
+	store *config.LocalSecretStore
+}
+
+func NewCmdSecretLocalSet(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+		store:   config.NewDefaultLocalSecretStore(),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "set <name>",
+		Short: "Add or replace a local secret",
+		Long: heredoc.Doc(`Add or replace a secret in the local encrypted secret store.
+
+			The value is used by 'vcr debug' for manifest environment entries that reference
+			the secret by name. An existing local secret with the same name is replaced.
+
+			SECRET VALUE INPUT
+			  • --value: Pass the value directly (be careful with shell history)
+			  • --filename: Read the value from a file (recommended for multi-line values)
+
+			  If neither is provided, the value is read from standard input.
+		`),
+		Example: heredoc.Doc(`
+			# Store a secret with a direct value
+			$ vcr secret local set MY_API_KEY --value "sk-12345abcde"
+			✓ Local secret "MY_API_KEY" saved
+
+			# Store a secret from a file
+			$ vcr secret local set SSL_CERT --filename ./server.crt
+
+			# Store a secret from standard input
+			$ echo -n "hunter2" | vcr secret local set DATABASE_PASSWORD
+		`),
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(_ *cobra.Command, args []string) error {
+			opts.Name = args[0]
+			return runSet(&opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Value, "value", "v", "", "Secret value (or use --filename for file input)")
+	cmd.Flags().StringVarP(&opts.SecretFile, "filename", "f", "", "Path to file containing the secret value")
+
+	return cmd
+}
+
+func runSet(opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	if _, err := config.ValidateSecretName(opts.Name); err != nil {
+		return fmt.Errorf("invalid secret name: %w", err)
+	}
+
+	secret, err := config.GetSecretFromInputs(io, opts.Name, opts.Value, opts.SecretFile)
+	if err != nil {
+		return fmt.Errorf("can't read secret's value: %w", err)
+	}
+
+	if err := opts.store.Set(secret.Name, secret.Value); err != nil {
+		return fmt.Errorf("failed to save local secret: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "%s Local secret %q saved\n", c.SuccessIcon(), opts.Name)
+	return nil
+}
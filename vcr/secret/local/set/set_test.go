@@ -0,0 +1,75 @@
+package set
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/config"
+	"vonage-cloud-runtime-cli/testutil"
+)
+
+func TestSecretLocalSet(t *testing.T) {
+	type want struct {
+		errMsg  string
+		stdout  string
+		secrets map[string]string
+	}
+
+	tests := []struct {
+		name  string
+		input Options
+		want  want
+	}{
+		{
+			name:  "happy-path",
+			input: Options{Name: "MY_SECRET", Value: "value"},
+			want: want{
+				stdout:  "✓ Local secret \"MY_SECRET\" saved\n",
+				secrets: map[string]string{"MY_SECRET": "value"},
+			},
+		},
+		{
+			name:  "invalid-name",
+			input: Options{Name: "1-invalid", Value: "value"},
+			want: want{
+				errMsg: "invalid secret name: must follow the regex format \"^[a-zA-Z_]+[a-zA-Z0-9_]*$\"",
+			},
+		},
+		{
+			name:  "missing-secret-file",
+			input: Options{Name: "MY_SECRET", SecretFile: "testdata/missing.txt"},
+			want: want{
+				errMsg: "can't read secret's value: no secret file found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			store := config.NewLocalSecretStore(filepath.Join(tempDir, "secrets.enc"), filepath.Join(tempDir, "secrets.key"), "")
+
+			ios, _, stdout, _ := iostreams.Test()
+			f := testutil.DefaultFactoryMock(t, ios, nil, nil, nil, nil, nil, nil)
+
+			opts := tt.input
+			opts.Factory = f
+			opts.store = store
+
+			err := runSet(&opts)
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tt.want.stdout, stdout.String())
+			secrets, err := store.Load()
+			require.NoError(t, err)
+			require.Equal(t, tt.want.secrets, secrets)
+		})
+	}
+}
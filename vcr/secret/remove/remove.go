@@ -51,8 +51,8 @@ func NewCmdSecretRemove(f cmdutil.Factory) *cobra.Command {
 		Args:    cobra.MaximumNArgs(0),
 		Aliases: []string{"rm"},
 
-		RunE: func(_ *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 
 			return runRemove(ctx, &opts)
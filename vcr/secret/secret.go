@@ -7,6 +7,7 @@ import (
 	"vonage-cloud-runtime-cli/pkg/cmdutil"
 	"vonage-cloud-runtime-cli/vcr/secret/create"
 	"vonage-cloud-runtime-cli/vcr/secret/list"
+	"vonage-cloud-runtime-cli/vcr/secret/local"
 	"vonage-cloud-runtime-cli/vcr/secret/remove"
 	"vonage-cloud-runtime-cli/vcr/secret/update"
 )
@@ -39,6 +40,7 @@ func NewCmdSecret(f cmdutil.Factory) *cobra.Command {
 			  list (ls)      List all secrets
 			  update         Update an existing secret's value
 			  remove (rm)    Delete a secret
+			  local          Manage secrets stored on this machine for 'vcr debug'
 
 			SECRET NAMING
 			  Secret names must be valid environment variable names:
@@ -58,11 +60,15 @@ func NewCmdSecret(f cmdutil.Factory) *cobra.Command {
 
 			# Remove a secret
 			$ vcr secret remove --name MY_API_KEY
+
+			# Store a secret locally for debug mode
+			$ vcr secret local set MY_API_KEY --value "sk-12345..."
 		`),
 	}
 
 	cmd.AddCommand(create.NewCmdSecretCreate(f))
 	cmd.AddCommand(list.NewCmdSecretList(f))
+	cmd.AddCommand(local.NewCmdSecretLocal(f))
 	cmd.AddCommand(remove.NewCmdSecretRemove(f))
 	cmd.AddCommand(update.NewCmdSecretUpdate(f))
 	return cmd
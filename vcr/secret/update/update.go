@@ -67,8 +67,8 @@ func NewCmdSecretUpdate(f cmdutil.Factory) *cobra.Command {
 		`),
 		Args: cobra.MaximumNArgs(0),
 
-		RunE: func(_ *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
 
 			return runUpdate(ctx, &opts)
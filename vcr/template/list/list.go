@@ -0,0 +1,128 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+	"vonage-cloud-runtime-cli/pkg/format"
+)
+
+// templateCacheDir is where downloaded templates are cached.
+var templateCacheDir = config.DefaultTemplateCacheDir
+
+type Options struct {
+	cmdutil.Factory
+
+	Runtime  string
+	Language string
+}
+
+func NewCmdTemplateList(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the available project templates",
+		Long: heredoc.Doc(`List the available project templates.
+
+			This command lists the product templates of the marketplace together with the
+			templates of the asset store, showing their ID, name, source, language, latest
+			version and size. The size of a marketplace template is only known once it has
+			been downloaded, e.g. with 'vcr template show' or 'vcr init'.
+
+			Use --runtime to list the templates for a runtime, or --language to filter by
+			programming language.
+		`),
+		Example: heredoc.Doc(`
+			# List all templates
+			$ vcr template list
+
+			# List the templates for a runtime
+			$ vcr template list --runtime nodejs18
+
+			# List the templates for a language using the short alias
+			$ vcr template ls --language python
+		`),
+		Aliases: []string{"ls"},
+		Args:    cobra.MaximumNArgs(0),
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
+			defer cancel()
+
+			if err := cmdutil.MutuallyExclusive("specify only one of --runtime or --language", opts.Runtime != "", opts.Language != ""); err != nil {
+				return err
+			}
+			return runList(ctx, &opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Runtime, "runtime", "r", "", "List the templates for this runtime, e.g. nodejs18")
+	cmd.Flags().StringVarP(&opts.Language, "language", "l", "", "List the templates for this programming language, e.g. nodejs")
+
+	return cmd
+}
+
+func runList(ctx context.Context, opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	language := strings.ToLower(opts.Language)
+	if opts.Runtime != "" {
+		spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving runtime... ")
+		runtime, err := opts.Datastore().GetRuntimeByName(ctx, opts.Runtime)
+		spinner.Stop()
+		if err != nil {
+			return fmt.Errorf("failed to get runtime %q: %w", opts.Runtime, err)
+		}
+		language = strings.ToLower(runtime.Language)
+	}
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving templates... ")
+	templates, err := cmdutil.ListTemplates(ctx, opts)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	cache, err := config.NewTemplateCache(templateCacheDir)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, t := range templates {
+		if language != "" && t.Language != language {
+			continue
+		}
+		size := t.Size
+		if cached, _, err := cache.Get(t.ID); err == nil && size == 0 && cached.Version == t.Version {
+			size = cached.Size
+		}
+		version := t.Version
+		if version == "" {
+			version = "-"
+		}
+		rows = append(rows, []string{t.ID, t.Name, t.Source, t.Language, version, format.ByteSize(size)})
+	}
+	if len(rows) == 0 {
+		fmt.Fprintf(io.ErrOut, "%s No templates found\n", c.WarningIcon())
+		return nil
+	}
+
+	table := tablewriter.NewWriter(io.Out)
+	table.Header("ID", "Name", "Source", "Language", "Version", "Size")
+	if err := table.Bulk(rows); err != nil {
+		return fmt.Errorf("failed to append templates to table: %w", err)
+	}
+	return table.Render()
+}
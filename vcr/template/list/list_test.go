@@ -0,0 +1,158 @@
+package list
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+func TestTemplateList(t *testing.T) {
+	products := []api.ProductTemplate{
+		{Product: api.Product{ID: "p1", Name: "Starter Project", ProgrammingLanguage: "NodeJS"}, LatestVersions: []api.ProductVersion{{ID: "v-p1"}}},
+		{Product: api.Product{ID: "p2", Name: "Voice Bot", ProgrammingLanguage: "Python"}, LatestVersions: []api.ProductVersion{{ID: "v-p2"}}},
+	}
+	assets := []api.Metadata{
+		{Name: "templates/nodejs/voice-ivr.tar.gz", LastModified: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), Size: 2048},
+	}
+
+	type mock struct {
+		GetRuntimeTimes  int
+		ListProductsErr  error
+		ListAssetsTimes  int
+		ListAssetsErr    error
+		CachedTemplateID string
+	}
+	type want struct {
+		errMsg string
+		stdout string
+		stderr string
+	}
+
+	tests := []struct {
+		name string
+		cli  string
+		mock mock
+		want want
+	}{
+		{
+			name: "all",
+			cli:  "",
+			mock: mock{ListAssetsTimes: 1, CachedTemplateID: "p1"},
+			want: want{
+				stdout: heredoc.Doc(`
+					┌───────────────────────────────────┬─────────────────┬─────────────┬──────────┬────────────┬────────┐
+					│                ID                 │      NAME       │   SOURCE    │ LANGUAGE │  VERSION   │  SIZE  │
+					├───────────────────────────────────┼─────────────────┼─────────────┼──────────┼────────────┼────────┤
+					│ p1                                │ Starter Project │ marketplace │ nodejs   │ v-p1       │ 7 B    │
+					│ p2                                │ Voice Bot       │ marketplace │ python   │ v-p2       │ -      │
+					│ templates/nodejs/voice-ivr.tar.gz │ voice-ivr       │ asset       │ nodejs   │ 2024-03-01 │ 2.0 KB │
+					└───────────────────────────────────┴─────────────────┴─────────────┴──────────┴────────────┴────────┘
+				`),
+			},
+		},
+		{
+			name: "runtime",
+			cli:  "--runtime python3",
+			mock: mock{GetRuntimeTimes: 1, ListAssetsTimes: 1},
+			want: want{
+				stdout: heredoc.Doc(`
+					┌────┬───────────┬─────────────┬──────────┬─────────┬──────┐
+					│ ID │   NAME    │   SOURCE    │ LANGUAGE │ VERSION │ SIZE │
+					├────┼───────────┼─────────────┼──────────┼─────────┼──────┤
+					│ p2 │ Voice Bot │ marketplace │ python   │ v-p2    │ -    │
+					└────┴───────────┴─────────────┴──────────┴─────────┴──────┘
+				`),
+			},
+		},
+		{
+			name: "no-match",
+			cli:  "--language java",
+			mock: mock{ListAssetsTimes: 1},
+			want: want{
+				stderr: "! No templates found\n",
+			},
+		},
+		{
+			name: "runtime-and-language",
+			cli:  "--runtime python3 --language python",
+			want: want{
+				errMsg: "specify only one of --runtime or --language",
+			},
+		},
+		{
+			name: "products-error",
+			cli:  "",
+			mock: mock{ListProductsErr: errors.New("api error")},
+			want: want{
+				errMsg: "failed to list product templates: api error",
+			},
+		},
+		{
+			name: "assets-error",
+			cli:  "",
+			mock: mock{ListAssetsTimes: 1, ListAssetsErr: errors.New("api error")},
+			want: want{
+				errMsg: "failed to list asset templates: api error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateCacheDir = t.TempDir()
+			if tt.mock.CachedTemplateID != "" {
+				cache, err := config.NewTemplateCache(templateCacheDir)
+				require.NoError(t, err)
+				require.NoError(t, cache.Put(config.CachedTemplate{ID: tt.mock.CachedTemplateID, Version: "v-" + tt.mock.CachedTemplateID}, []byte("archive")))
+			}
+
+			ctrl := gomock.NewController(t)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			assetMock := mocks.NewMockAssetInterface(ctrl)
+
+			datastoreMock.EXPECT().GetRuntimeByName(gomock.Any(), "python3").Times(tt.mock.GetRuntimeTimes).Return(api.Runtime{Name: "python3", Language: "python"}, nil)
+			if tt.want.errMsg != "specify only one of --runtime or --language" {
+				datastoreMock.EXPECT().ListProductTemplates(gomock.Any()).Times(1).Return(products, tt.mock.ListProductsErr)
+			}
+			assetMock.EXPECT().GetTemplateNameList(gomock.Any(), cmdutil.AssetTemplatePrefix, true, 0).
+				Times(tt.mock.ListAssetsTimes).
+				Return(assets, tt.mock.ListAssetsErr)
+
+			ios, _, stdout, stderr := iostreams.Test()
+			argv, err := shlex.Split(tt.cli)
+			require.NoError(t, err)
+
+			f := testutil.DefaultFactoryMock(t, ios, assetMock, nil, datastoreMock, nil, nil, nil)
+
+			cmd := NewCmdTemplateList(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			if tt.want.errMsg != "" {
+				require.Error(t, err, "should throw error")
+				require.Equal(t, tt.want.errMsg, err.Error())
+				return
+			}
+			require.NoError(t, err, "should not throw error")
+			require.Equal(t, tt.want.stdout, stdout.String())
+			require.Equal(t, tt.want.stderr, stderr.String())
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package pull
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/archive"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+)
+
+// templateCacheDir is where downloaded templates are cached.
+var templateCacheDir = config.DefaultTemplateCacheDir
+
+type Options struct {
+	cmdutil.Factory
+
+	ID  string
+	Dir string
+}
+
+func NewCmdTemplatePull(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "pull <template-id> <directory>",
+		Short: "Download the files of a template",
+		Long: heredoc.Doc(`Download the files of a template.
+
+			This command writes the files of a template to a directory, which is created
+			if it does not exist, exactly as they are in the template. Unlike 'vcr init',
+			it asks no questions and does not create or change a vcr.yml manifest.
+
+			Existing files are never overwritten: if any file of the template already
+			exists in the directory, nothing is written.
+
+			The template is also added to the local cache, so that 'vcr init --template'
+			can use it later, even when the marketplace cannot be reached.
+		`),
+		Args: cobra.ExactArgs(2),
+		Example: heredoc.Doc(`
+			$ vcr template pull "Starter Project" ./starter
+			✓ Template "Starter Project" pulled to /home/me/starter
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
+			defer cancel()
+
+			opts.ID = args[0]
+			opts.Dir = args[1]
+			return runPull(ctx, &opts)
+		},
+	}
+
+	return cmd
+}
+
+func runPull(ctx context.Context, opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	dir, err := filepath.Abs(opts.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path of %q: %w", opts.Dir, err)
+	}
+	cache, err := config.NewTemplateCache(templateCacheDir)
+	if err != nil {
+		return err
+	}
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving templates... ")
+	templates, err := cmdutil.ListTemplates(ctx, opts)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+	t, err := cmdutil.FindTemplateInfo(templates, opts.ID)
+	if err != nil {
+		return err
+	}
+
+	spinner = cmdutil.DisplaySpinnerMessageWithHandle(" Downloading template files... ")
+	data, err := cmdutil.FetchTemplate(ctx, opts, cache, t)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	if err := archive.Extract(data, dir); err != nil {
+		return fmt.Errorf("failed to write template files: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "%s Template %q pulled to %s\n", c.SuccessIcon(), t.Name, dir)
+	return nil
+}
@@ -0,0 +1,119 @@
+package pull
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+func tarGz(t *testing.T, name, body string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(body))}))
+	_, err := tw.Write([]byte(body))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+	return buf.Bytes()
+}
+
+func TestTemplatePull(t *testing.T) {
+	archive := tarGz(t, "vcr.yml", "project:\n  name: {{ .ProjectName }}\n")
+
+	type want struct {
+		errMsg string
+		stdout string
+	}
+
+	tests := []struct {
+		name     string
+		existing bool
+		want     want
+	}{
+		{
+			name: "pull",
+			want: want{
+				stdout: "✓ Template \"Starter Project\" pulled to %s\n",
+			},
+		},
+		{
+			name:     "existing-files",
+			existing: true,
+			want: want{
+				errMsg: "failed to write template files: 1 template file(s) already exist in %s: vcr.yml",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateCacheDir = t.TempDir()
+
+			ctrl := gomock.NewController(t)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			assetMock := mocks.NewMockAssetInterface(ctrl)
+			marketplaceMock := mocks.NewMockMarketplaceInterface(ctrl)
+
+			datastoreMock.EXPECT().ListProductTemplates(gomock.Any()).Times(1).Return([]api.ProductTemplate{
+				{Product: api.Product{ID: "p1", Name: "Starter Project", ProgrammingLanguage: "NodeJS"}, LatestVersions: []api.ProductVersion{{ID: "v1"}}},
+			}, nil)
+			assetMock.EXPECT().GetTemplateNameList(gomock.Any(), cmdutil.AssetTemplatePrefix, true, 0).Times(1).Return(nil, nil)
+			marketplaceMock.EXPECT().GetTemplate(gomock.Any(), "p1", "v1").Times(1).Return(archive, nil)
+
+			dir := filepath.Join(t.TempDir(), "starter")
+			if tt.existing {
+				require.NoError(t, os.MkdirAll(dir, 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "vcr.yml"), []byte("mine"), 0600))
+			}
+
+			ios, _, stdout, _ := iostreams.Test()
+			f := testutil.DefaultFactoryMock(t, ios, assetMock, nil, datastoreMock, nil, nil, marketplaceMock)
+
+			cmd := NewCmdTemplatePull(f)
+			cmd.SetArgs([]string{"Starter Project", dir})
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err := cmd.ExecuteC()
+			if tt.want.errMsg != "" {
+				require.Error(t, err, "should throw error")
+				require.Equal(t, fmt.Sprintf(tt.want.errMsg, dir), err.Error())
+				data, err := os.ReadFile(filepath.Join(dir, "vcr.yml"))
+				require.NoError(t, err)
+				require.Equal(t, "mine", string(data))
+				return
+			}
+			require.NoError(t, err, "should not throw error")
+			require.Equal(t, fmt.Sprintf(tt.want.stdout, dir), stdout.String())
+
+			// the files are not rendered, and no manifest is created
+			data, err := os.ReadFile(filepath.Join(dir, "vcr.yml"))
+			require.NoError(t, err)
+			require.Equal(t, "project:\n  name: {{ .ProjectName }}\n", string(data))
+
+			cache, err := config.NewTemplateCache(templateCacheDir)
+			require.NoError(t, err)
+			cached, _, err := cache.Find("starter project")
+			require.NoError(t, err)
+			require.Equal(t, "v1", cached.Version)
+		})
+	}
+}
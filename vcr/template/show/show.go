@@ -0,0 +1,147 @@
+package show
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+	"vonage-cloud-runtime-cli/pkg/format"
+)
+
+// templateCacheDir is where downloaded templates are cached.
+var templateCacheDir = config.DefaultTemplateCacheDir
+
+type Options struct {
+	cmdutil.Factory
+
+	ID string
+}
+
+func NewCmdTemplateShow(f cmdutil.Factory) *cobra.Command {
+	opts := Options{
+		Factory: f,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "show <template-id>",
+		Short: "Show the details and files of a template",
+		Long: heredoc.Doc(`Show the details and files of a template.
+
+			This command downloads the template, unless its latest version is already in
+			the local cache, and displays its source, language, version and size followed
+			by the files it contains.
+
+			The template is given by the ID or name shown by 'vcr template list'.
+		`),
+		Args: cobra.ExactArgs(1),
+		Example: heredoc.Doc(`
+			$ vcr template show "Starter Project"
+			ℹ id: 8d7a46b8-4d0c-4d5f-a4e0-9d2a1c1f6b0e
+			ℹ name: Starter Project
+			ℹ source: marketplace
+			ℹ language: nodejs
+			ℹ version: 2c3f9a10-8b1e-4f3a-9d4c-5e6f7a8b9c0d
+			ℹ size: 2.1 KB
+			┌──────────────┬────────┐
+			│     FILE     │  SIZE  │
+			├──────────────┼────────┤
+			│ index.js     │ 1.2 KB │
+			│ package.json │ 312 B  │
+			│ vcr.yml      │ 204 B  │
+			└──────────────┴────────┘
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
+			defer cancel()
+
+			opts.ID = args[0]
+			return runShow(ctx, &opts)
+		},
+	}
+
+	return cmd
+}
+
+func runShow(ctx context.Context, opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	cache, err := config.NewTemplateCache(templateCacheDir)
+	if err != nil {
+		return err
+	}
+
+	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Retrieving templates... ")
+	templates, err := cmdutil.ListTemplates(ctx, opts)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+	t, err := cmdutil.FindTemplateInfo(templates, opts.ID)
+	if err != nil {
+		return err
+	}
+
+	spinner = cmdutil.DisplaySpinnerMessageWithHandle(" Downloading template files... ")
+	archive, err := cmdutil.FetchTemplate(ctx, opts, cache, t)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+	files, err := listFiles(archive)
+	if err != nil {
+		return fmt.Errorf("failed to read template files: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "%s id: %s\n", c.Blue(cmdutil.InfoIcon), t.ID)
+	fmt.Fprintf(io.Out, "%s name: %s\n", c.Blue(cmdutil.InfoIcon), t.Name)
+	fmt.Fprintf(io.Out, "%s source: %s\n", c.Blue(cmdutil.InfoIcon), t.Source)
+	fmt.Fprintf(io.Out, "%s language: %s\n", c.Blue(cmdutil.InfoIcon), t.Language)
+	version := t.Version
+	if version == "" {
+		version = "-"
+	}
+	fmt.Fprintf(io.Out, "%s version: %s\n", c.Blue(cmdutil.InfoIcon), version)
+	fmt.Fprintf(io.Out, "%s size: %s\n", c.Blue(cmdutil.InfoIcon), format.ByteSize(int64(len(archive))))
+
+	table := tablewriter.NewWriter(io.Out)
+	table.Header("File", "Size")
+	if err := table.Bulk(files); err != nil {
+		return fmt.Errorf("failed to append files to table: %w", err)
+	}
+	return table.Render()
+}
+
+// listFiles returns the path and size of the regular files of a tar.gz archive.
+func listFiles(archive []byte) ([][]string, error) {
+	gzr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gzr.Close()
+
+	var files [][]string
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag == tar.TypeReg {
+			files = append(files, []string{header.Name, format.ByteSize(header.Size)})
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package show
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/golang/mock/gomock"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/require"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	"vonage-cloud-runtime-cli/pkg/config"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for _, name := range []string{"index.js", "vcr.yml"} {
+		body, ok := files[name]
+		if !ok {
+			continue
+		}
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(body))}))
+		_, err := tw.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+	return buf.Bytes()
+}
+
+func TestTemplateShow(t *testing.T) {
+	archive := tarGz(t, map[string]string{"index.js": "console.log()", "vcr.yml": "project:\n  name: test\n"})
+	products := []api.ProductTemplate{{Product: api.Product{ID: "p1", Name: "Starter Project", ProgrammingLanguage: "NodeJS"}, LatestVersions: []api.ProductVersion{{ID: "v1"}}}}
+	assets := []api.Metadata{{Name: "templates/python/bot.tar.gz", Size: 300}}
+
+	type mock struct {
+		DownloadTemplateTimes int
+		DownloadTemplateErr   error
+		DownloadAssetTimes    int
+		Cached                bool
+	}
+	type want struct {
+		errMsg string
+		stdout string
+	}
+
+	tests := []struct {
+		name string
+		cli  string
+		mock mock
+		want want
+	}{
+		{
+			name: "marketplace-by-name",
+			cli:  "'starter project'",
+			mock: mock{DownloadTemplateTimes: 1},
+			want: want{
+				stdout: heredoc.Docf(`
+					ℹ id: p1
+					ℹ name: Starter Project
+					ℹ source: marketplace
+					ℹ language: nodejs
+					ℹ version: v1
+					ℹ size: %d B
+					┌──────────┬──────┐
+					│   FILE   │ SIZE │
+					├──────────┼──────┤
+					│ index.js │ 13 B │
+					│ vcr.yml  │ 22 B │
+					└──────────┴──────┘
+				`, len(archive)),
+			},
+		},
+		{
+			name: "cached-version",
+			cli:  "p1",
+			mock: mock{Cached: true},
+			want: want{
+				stdout: heredoc.Docf(`
+					ℹ id: p1
+					ℹ name: Starter Project
+					ℹ source: marketplace
+					ℹ language: nodejs
+					ℹ version: v1
+					ℹ size: %d B
+					┌──────────┬──────┐
+					│   FILE   │ SIZE │
+					├──────────┼──────┤
+					│ index.js │ 13 B │
+					│ vcr.yml  │ 22 B │
+					└──────────┴──────┘
+				`, len(archive)),
+			},
+		},
+		{
+			name: "asset-by-id",
+			cli:  "templates/python/bot.tar.gz",
+			mock: mock{DownloadAssetTimes: 1},
+			want: want{
+				stdout: heredoc.Docf(`
+					ℹ id: templates/python/bot.tar.gz
+					ℹ name: bot
+					ℹ source: asset
+					ℹ language: python
+					ℹ version: -
+					ℹ size: %d B
+					┌──────────┬──────┐
+					│   FILE   │ SIZE │
+					├──────────┼──────┤
+					│ index.js │ 13 B │
+					│ vcr.yml  │ 22 B │
+					└──────────┴──────┘
+				`, len(archive)),
+			},
+		},
+		{
+			name: "not-found",
+			cli:  "missing",
+			want: want{
+				errMsg: "template \"missing\" not found, run 'vcr template list' to see the available templates",
+			},
+		},
+		{
+			name: "download-error",
+			cli:  "p1",
+			mock: mock{DownloadTemplateTimes: 1, DownloadTemplateErr: errors.New("api error")},
+			want: want{
+				errMsg: "failed to download template files: api error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateCacheDir = t.TempDir()
+			if tt.mock.Cached {
+				cache, err := config.NewTemplateCache(templateCacheDir)
+				require.NoError(t, err)
+				require.NoError(t, cache.Put(config.CachedTemplate{ID: "p1", Version: "v1"}, archive))
+			}
+
+			ctrl := gomock.NewController(t)
+			datastoreMock := mocks.NewMockDatastoreInterface(ctrl)
+			assetMock := mocks.NewMockAssetInterface(ctrl)
+			marketplaceMock := mocks.NewMockMarketplaceInterface(ctrl)
+
+			datastoreMock.EXPECT().ListProductTemplates(gomock.Any()).Times(1).Return(products, nil)
+			assetMock.EXPECT().GetTemplateNameList(gomock.Any(), cmdutil.AssetTemplatePrefix, true, 0).Times(1).Return(assets, nil)
+			assetMock.EXPECT().GetTemplate(gomock.Any(), "templates/python/bot.tar.gz").
+				Times(tt.mock.DownloadAssetTimes).
+				Return(api.Template{Key: "templates/python/bot.tar.gz", Content: archive}, nil)
+			marketplaceMock.EXPECT().GetTemplate(gomock.Any(), "p1", "v1").
+				Times(tt.mock.DownloadTemplateTimes).
+				Return(archive, tt.mock.DownloadTemplateErr)
+
+			ios, _, stdout, _ := iostreams.Test()
+			argv, err := shlex.Split(tt.cli)
+			require.NoError(t, err)
+
+			f := testutil.DefaultFactoryMock(t, ios, assetMock, nil, datastoreMock, nil, nil, marketplaceMock)
+
+			cmd := NewCmdTemplateShow(f)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			if tt.want.errMsg != "" {
+				require.Error(t, err, "should throw error")
+				require.Equal(t, tt.want.errMsg, err.Error())
+				return
+			}
+			require.NoError(t, err, "should not throw error")
+			require.Equal(t, tt.want.stdout, stdout.String())
+		})
+	}
+}
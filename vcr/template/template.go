@@ -0,0 +1,48 @@
+package template
+
+import (
+	"github.com/MakeNowJust/heredoc"
+	"github.com/spf13/cobra"
+
+	"vonage-cloud-runtime-cli/pkg/cmdutil"
+	listCmd "vonage-cloud-runtime-cli/vcr/template/list"
+	pullCmd "vonage-cloud-runtime-cli/vcr/template/pull"
+	showCmd "vonage-cloud-runtime-cli/vcr/template/show"
+)
+
+func NewCmdTemplate(f cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template <command>",
+		Short: "Browse and download project templates",
+		Long: heredoc.Doc(`Browse and download project templates.
+
+			Templates provide the starter code of a VCR project. They come from the
+			product marketplace and from the asset store, and are used by 'vcr init'.
+
+			AVAILABLE COMMANDS
+			  list (ls)  List the available templates
+			  show       Show the details and files of a template
+			  pull       Download the files of a template without creating a manifest
+
+			CACHE
+			  Downloaded templates are kept in ~/.vcr-cli.d/templates. A template is only
+			  downloaded again when a newer version is available, and 'vcr init' falls back
+			  to the cached version when the marketplace cannot be reached.
+		`),
+		Example: heredoc.Doc(`
+			# List the templates for Node.js
+			$ vcr template list --runtime nodejs18
+
+			# Show the files of a template
+			$ vcr template show "Starter Project"
+
+			# Download a template to a directory
+			$ vcr template pull "Starter Project" ./starter
+		`),
+	}
+
+	cmd.AddCommand(listCmd.NewCmdTemplateList(f))
+	cmd.AddCommand(pullCmd.NewCmdTemplatePull(f))
+	cmd.AddCommand(showCmd.NewCmdTemplateShow(f))
+	return cmd
+}
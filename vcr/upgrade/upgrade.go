@@ -3,7 +3,9 @@ package upgrade
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -25,8 +27,19 @@ type Options struct {
 
 	forceUpdate bool
 	path        string
+	version     string
+	channel     string
+	rollback    bool
+	check       bool
+	fromFile    string
+	insecure    bool
 }
 
+const (
+	channelStable     = "stable"
+	channelPrerelease = "prerelease"
+)
+
 func NewCmdUpgrade(f cmdutil.Factory, version string) *cobra.Command {
 	opts := Options{
 		Factory: f,
@@ -50,8 +63,49 @@ func NewCmdUpgrade(f cmdutil.Factory, version string) *cobra.Command {
 			  If a new version is available:
 			  1. You'll be prompted to confirm the update (unless --force is used)
 			  2. The new binary is downloaded from GitHub releases
-			  3. The current binary is replaced with the new one
-			  4. Success message confirms the update
+			  3. The download is verified against the release checksums.txt, and the
+			     checksums against their minisign signature when this build embeds the
+			     release public key. The version named in the signature must be the one
+			     of the release, so that an older release can't be served as the latest
+			  4. The current binary is replaced with the new one
+			  5. Success message confirms the update
+
+			  The update is refused when the checksum or signature does not match. It is
+			  also refused when the release has no checksums.txt, or no
+			  checksums.txt.minisig when this build embeds the release public key, which
+			  is the case of releases published before the checksums were signed. Use
+			  --insecure to install such a release anyway: the missing verification is
+			  skipped and a warning is printed.
+
+			VERSIONS AND CHANNELS
+			  By default the latest stable release is installed. Use --version to install
+			  a specific release instead, including an older one, or --channel prerelease
+			  to follow pre-releases as well.
+
+			ROLLBACK
+			  Each upgrade keeps the replaced binary next to the executable. Use --rollback
+			  to restore it; running --rollback again switches back.
+
+			OFFLINE UPGRADE
+			  Use --from-file to install a release archive downloaded beforehand, e.g. on
+			  a machine without access to GitHub. The release endpoint is not contacted.
+			  The archive must keep its release name, vcr_<os>_<arch>.tar.gz, and match
+			  the platform of this CLI. checksums.txt and checksums.txt.minisig must be in
+			  the same directory as the archive.
+
+			  The signature proves the checksums come from a release, and its trusted
+			  comment gives the version of the archive; the archived binary is never run.
+			  An archive older than the installed version is only installed after
+			  confirmation, or with --force. Use --insecure to install an archive that
+			  can't be verified, because this build has no release public key or
+			  checksums.txt or checksums.txt.minisig is missing; its version is then
+			  unknown.
+
+			CHECKING FOR UPDATES IN SCRIPTS
+			  --check only reports whether an update is available and never installs it.
+			  It exits with status 1 when the installed version is behind, or when it
+			  differs from the version given with --version, which lets CI jobs verify a
+			  pinned CLI version.
 
 			CUSTOM INSTALLATION PATH
 			  If you installed the CLI in a custom location (not the default), use
@@ -72,17 +126,51 @@ func NewCmdUpgrade(f cmdutil.Factory, version string) *cobra.Command {
 			$ vcr upgrade
 			vcr-cli version 1.2.3 (commit:abc123, date:2024-01-15)
 			? Are you sure you want to update to 1.3.0? Yes
+			✓ Verified sha256 checksum 5d41402abc4b2a76b9719d911017c592...
+			✓ Verified signature of checksums.txt with key 9D2B5A1F37C4E6B0
 			✓ Successfully updated to version 1.3.0
+			ℹ The previous version was kept, run 'vcr upgrade --rollback' to restore it
 
 			# Force update without prompt (useful for CI/CD)
 			$ vcr upgrade --force
 
 			# Update CLI installed in a custom location
 			$ vcr upgrade --path /opt/vonage/bin
+
+			# Install a specific version
+			$ vcr upgrade --version 1.4.2
+
+			# Follow pre-releases
+			$ vcr upgrade --channel prerelease
+
+			# Install a release published before the checksums were signed
+			$ vcr upgrade --version 1.0.3 --insecure
+
+			# Install a release archive downloaded beforehand, next to its checksums.txt and checksums.txt.minisig
+			$ vcr upgrade --from-file ./vcr_linux_amd64.tar.gz
+
+			# Restore the version replaced by the last upgrade
+			$ vcr upgrade --rollback
+
+			# Fail a CI job when the CLI is not at the pinned version
+			$ vcr upgrade --check --version 1.4.2
 		`),
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			ctx, cancel := context.WithDeadline(context.Background(), opts.Deadline())
+			ctx, cancel := context.WithDeadline(cmd.Context(), opts.Deadline())
 			defer cancel()
+			if err := cmdutil.MutuallyExclusive("specify only one of --version, --channel, --rollback or --from-file", opts.version != "", cmd.Flags().Changed("channel"), opts.rollback, opts.fromFile != ""); err != nil {
+				return err
+			}
+			if err := cmdutil.MutuallyExclusive("--check cannot be used with --rollback or --from-file", opts.check, opts.rollback || opts.fromFile != ""); err != nil {
+				return err
+			}
+			if err := cmdutil.MutuallyExclusive("--insecure cannot be used with --rollback or --check", opts.insecure, opts.rollback || opts.check); err != nil {
+				return err
+			}
+			if opts.channel != channelStable && opts.channel != channelPrerelease {
+				return cmdutil.FlagErrorf("invalid channel %q, must be one of %q or %q", opts.channel, channelStable, channelPrerelease)
+			}
+
 			fmt.Fprint(f.IOStreams().Out, cmd.Root().Annotations["versionInfo"])
 			if opts.path != "" {
 				absPath, err := config.GetAbsDir(opts.path)
@@ -91,6 +179,13 @@ func NewCmdUpgrade(f cmdutil.Factory, version string) *cobra.Command {
 				}
 				opts.path = absPath
 			}
+			if opts.fromFile != "" {
+				absPath, err := filepath.Abs(opts.fromFile)
+				if err != nil {
+					return fmt.Errorf("failed to get absolute path of %q: %w", opts.fromFile, err)
+				}
+				opts.fromFile = absPath
+			}
 
 			return runUpgrade(ctx, &opts, version)
 		},
@@ -98,6 +193,12 @@ func NewCmdUpgrade(f cmdutil.Factory, version string) *cobra.Command {
 
 	cmd.Flags().BoolVarP(&opts.forceUpdate, "force", "f", false, "Skip confirmation prompt and update automatically")
 	cmd.Flags().StringVarP(&opts.path, "path", "p", "", "Custom path to VCR CLI installation directory")
+	cmd.Flags().StringVarP(&opts.version, "version", "", "", "Install this version instead of the latest, e.g. 1.4.2")
+	cmd.Flags().StringVarP(&opts.channel, "channel", "", channelStable, "Release channel to follow: \"stable\" or \"prerelease\"")
+	cmd.Flags().BoolVarP(&opts.rollback, "rollback", "", false, "Restore the version replaced by the last upgrade")
+	cmd.Flags().StringVarP(&opts.fromFile, "from-file", "", "", "Install from a local release archive instead of downloading it")
+	cmd.Flags().BoolVarP(&opts.insecure, "insecure", "", false, "Install a release whose checksums or signature can't be verified, e.g. an older release")
+	cmd.Flags().BoolVarP(&opts.check, "check", "", false, "Only check for an update, exit with status 1 if one is available")
 	return cmd
 }
 
@@ -105,13 +206,20 @@ func runUpgrade(ctx context.Context, opts *Options, version string) error {
 	io := opts.IOStreams()
 	c := opts.IOStreams().ColorScheme()
 
+	if opts.rollback {
+		return runRollback(opts)
+	}
+	if opts.fromFile != "" {
+		return runUpgradeFromFile(opts, version)
+	}
+
 	current, err := GetCurrentVersion(version)
 	if err != nil {
 		return fmt.Errorf("current update is invalid: %w", err)
 	}
 
 	spinner := cmdutil.DisplaySpinnerMessageWithHandle(" Checking for update...")
-	release, err := opts.ReleaseClient().GetLatestRelease(ctx)
+	release, err := getRelease(ctx, opts)
 	spinner.Stop()
 	if err != nil {
 		return fmt.Errorf("failed to get assets: %w", err)
@@ -122,13 +230,17 @@ func runUpgrade(ctx context.Context, opts *Options, version string) error {
 		return fmt.Errorf("failed to get latest version: %w", err)
 	}
 
-	if latest.LTE(current) {
-		if latest.EQ(current) {
-			fmt.Fprintf(io.Out, "%s You are using the latest version of vcr-cli (%s)\n", c.SuccessIcon(), current.String())
-		}
-		if current.GT(latest) {
-			fmt.Fprintf(io.Out, "%s Current version (%s) is newer than the latest version (%s) !\n", c.SuccessIcon(), current.String(), latest.String())
-		}
+	if opts.check {
+		return checkVersion(opts, current, latest)
+	}
+
+	if latest.EQ(current) {
+		fmt.Fprintf(io.Out, "%s You are using the latest version of vcr-cli (%s)\n", c.SuccessIcon(), current.String())
+		return nil
+	}
+	// a pinned version is installed even if it is older than the current one
+	if current.GT(latest) && opts.version == "" {
+		fmt.Fprintf(io.Out, "%s Current version (%s) is newer than the latest version (%s) !\n", c.SuccessIcon(), current.String(), latest.String())
 		return nil
 	}
 
@@ -141,32 +253,275 @@ func runUpgrade(ctx context.Context, opts *Options, version string) error {
 		}
 	}
 
-	exePath, err := os.Executable()
+	exePath, err := getExecutablePath(opts)
 	if err != nil {
-		return fmt.Errorf("failed to get executable path: %w", err)
+		return err
 	}
 
-	if opts.path != "" {
-		exePath = opts.path + "/vcr"
+	fmt.Println(exePath)
+	spinner = cmdutil.DisplaySpinnerMessageWithHandle(fmt.Sprintf(" Updating CLI to version - v%s...", latestVersion))
+	verified, err := updateByAsset(ctx, opts, release, exePath)
+	spinner.Stop()
+	if err != nil {
+		return err
 	}
 
-	if !executableExists(exePath) {
-		return fmt.Errorf("failed to find executable CLI file at %s", exePath)
+	printUpdated(opts, verified, latestVersion)
+	return nil
+}
+
+// runUpgradeFromFile installs a release archive from disk. It is verified against the checksums.txt
+// and its signature next to it, and its version is read from the trusted comment of the signature.
+// The archived binary is never run.
+func runUpgradeFromFile(opts *Options, version string) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	current, err := GetCurrentVersion(version)
+	if err != nil {
+		return fmt.Errorf("current update is invalid: %w", err)
 	}
 
-	fmt.Println(exePath)
-	spinner = cmdutil.DisplaySpinnerMessageWithHandle(fmt.Sprintf(" Updating CLI to latest version - v%s...", latestVersion))
-	err = updateByAsset(ctx, opts, release, exePath)
-	spinner.Stop()
+	// the release name is the only platform information available without extracting the binary,
+	// and the signed checksums bind the archive to that name
+	name := filepath.Base(opts.fromFile)
+	if name != getAssetName() {
+		return fmt.Errorf("%s is not a release archive for %s %s, expected %s", name, runtime.GOOS, runtime.GOARCH, getAssetName())
+	}
+
+	asset, err := os.ReadFile(opts.fromFile)
+	if err != nil {
+		return fmt.Errorf("failed to read release archive: %w", err)
+	}
+	verified, err := verifyFile(opts.fromFile, asset, opts.insecure)
+	if err != nil {
+		return err
+	}
+
+	var question string
+	archivedVersion := "unknown"
+	if verified.Version == "" {
+		fmt.Fprintf(io.ErrOut, "%s The version of %s is unknown, as its signature was not verified\n", c.WarningIcon(), name)
+		if !io.CanPrompt() && !opts.forceUpdate {
+			return fmt.Errorf("refusing to install %s of unknown version without --force", name)
+		}
+		question = fmt.Sprintf("Are you sure you want to replace %s with an unverified build ?", current.String())
+	} else {
+		archived, err := GetCurrentVersion(verified.Version)
+		if err != nil {
+			return fmt.Errorf("invalid version in the signature of %s: %w", checksumsAssetName, err)
+		}
+		archivedVersion = archived.String()
+		switch {
+		case archived.EQ(current):
+			fmt.Fprintf(io.Out, "%s You are already using version %s of vcr-cli\n", c.SuccessIcon(), current.String())
+			return nil
+		case archived.LT(current):
+			if !io.CanPrompt() && !opts.forceUpdate {
+				return fmt.Errorf("%s contains version %s, older than the installed %s, use --force to downgrade", name, archivedVersion, current.String())
+			}
+			question = fmt.Sprintf("Are you sure you want to downgrade from %s to %s ?", current.String(), archivedVersion)
+		default:
+			question = fmt.Sprintf("Are you sure you want to update from %s to %s ?", current.String(), archivedVersion)
+		}
+	}
+	if io.CanPrompt() && !opts.forceUpdate {
+		if !opts.Survey().AskYesNo(question) {
+			fmt.Fprintf(io.ErrOut, "%s Update aborted\n", c.WarningIcon())
+			return nil
+		}
+	}
+
+	exePath, err := getExecutablePath(opts)
+	if err != nil {
+		return err
+	}
+	binary, err := uncompressCommand(asset, opts.fromFile, exePath)
+	if err != nil {
+		return err
+	}
+	if err := applyUpdate(binary, exePath); err != nil {
+		return err
+	}
+	printUpdated(opts, verified, archivedVersion)
+	return nil
+}
+
+// verifyFile verifies a release archive against the checksums.txt, and its signature, found in the same
+// directory. Without checksums, a release public key or a signature, the archive is only accepted when
+// insecure is set.
+func verifyFile(path string, asset []byte, insecure bool) (verification, error) {
+	dir := filepath.Dir(path)
+	checksums, err := os.ReadFile(filepath.Join(dir, checksumsAssetName))
+	if os.IsNotExist(err) {
+		if insecure {
+			return verification{}, nil
+		}
+		return verification{}, fmt.Errorf("%w: no %s found in %s, refusing to install an unverified binary, use --insecure to install it anyway", ErrVerificationFailed, checksumsAssetName, dir)
+	}
+	if err != nil {
+		return verification{}, fmt.Errorf("failed to read release checksums: %w", err)
+	}
+
+	// a checksums.txt next to the archive proves nothing by itself, only its signature does
+	var signature []byte
+	if ReleasePublicKey == "" {
+		if !insecure {
+			return verification{}, fmt.Errorf("%w: this build has no release public key to verify %s, use --insecure to install it anyway", ErrVerificationFailed, filepath.Base(path))
+		}
+	} else {
+		signature, err = os.ReadFile(filepath.Join(dir, signatureAssetName))
+		switch {
+		case os.IsNotExist(err):
+			if !insecure {
+				return verification{}, fmt.Errorf("%w: no %s found in %s, use --insecure to install an unsigned archive", ErrVerificationFailed, signatureAssetName, dir)
+			}
+			signature = nil
+		case err != nil:
+			return verification{}, fmt.Errorf("failed to read release signature: %w", err)
+		}
+	}
+	return verifyChecksums(filepath.Base(path), asset, checksums, signature)
+}
+
+func printUpdated(opts *Options, verified verification, version string) {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	if verified.Checksum == "" {
+		fmt.Fprintf(io.Out, "%s Checksum not verified, installed with --insecure\n", c.WarningIcon())
+	} else {
+		fmt.Fprintf(io.Out, "%s Verified sha256 checksum %s\n", c.SuccessIcon(), verified.Checksum)
+	}
+	if verified.KeyID != "" {
+		fmt.Fprintf(io.Out, "%s Verified signature of %s with key %s\n", c.SuccessIcon(), checksumsAssetName, verified.KeyID)
+	} else if opts.insecure {
+		fmt.Fprintf(io.Out, "%s Signature not verified, installed with --insecure\n", c.WarningIcon())
+	} else {
+		fmt.Fprintf(io.Out, "%s Signature not verified, this build has no release public key\n", c.WarningIcon())
+	}
+	fmt.Fprintf(io.Out, "%s Successfully updated to version %s\n", c.SuccessIcon(), version)
+	fmt.Fprintf(io.Out, "%s The previous version was kept, run 'vcr upgrade --rollback' to restore it\n", c.Blue(cmdutil.InfoIcon))
+}
+
+// getRelease returns the release to install: the pinned version, the newest release of the
+// pre-release channel, or the latest stable release.
+func getRelease(ctx context.Context, opts *Options) (api.Release, error) {
+	switch {
+	case opts.version != "":
+		tag := "v" + strings.TrimPrefix(opts.version, "v")
+		release, err := opts.ReleaseClient().GetReleaseByTag(ctx, tag)
+		if errors.Is(err, api.ErrNotFound) {
+			return api.Release{}, fmt.Errorf("release %s not found", tag)
+		}
+		return release, err
+	case opts.channel == channelPrerelease:
+		releases, err := opts.ReleaseClient().ListReleases(ctx)
+		if err != nil {
+			return api.Release{}, err
+		}
+		return newestRelease(releases)
+	default:
+		return opts.ReleaseClient().GetLatestRelease(ctx)
+	}
+}
+
+// newestRelease returns the published release with the highest version, pre-releases included.
+func newestRelease(releases []api.Release) (api.Release, error) {
+	var newest api.Release
+	var newestVersion semver.Version
+	for _, release := range releases {
+		if release.Draft {
+			continue
+		}
+		v, err := GetLatestVersion(release)
+		if err != nil {
+			continue
+		}
+		if newest.TagName == "" || v.GT(newestVersion) {
+			newest, newestVersion = release, v
+		}
+	}
+	if newest.TagName == "" {
+		return api.Release{}, errors.New("no releases found")
+	}
+	return newest, nil
+}
+
+// checkVersion reports whether an update is available and returns cmdutil.ErrSilent when it is,
+// so that scripts can rely on the exit code. With a pinned version, any other version is reported.
+func checkVersion(opts *Options, current, latest semver.Version) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	if latest.EQ(current) || (current.GT(latest) && opts.version == "") {
+		fmt.Fprintf(io.Out, "%s vcr-cli is up to date (%s)\n", c.SuccessIcon(), current.String())
+		return nil
+	}
+	fmt.Fprintf(io.Out, "%s vcr-cli %s is installed, but %s is expected\n", c.WarningIcon(), current.String(), latest.String())
+	return cmdutil.ErrSilent
+}
+
+// runRollback swaps the current binary with the one kept by the last upgrade.
+func runRollback(opts *Options) error {
+	io := opts.IOStreams()
+	c := io.ColorScheme()
+
+	exePath, err := getExecutablePath(opts)
 	if err != nil {
 		return err
 	}
+	previousPath := getPreviousPath(exePath)
+	previous, err := os.ReadFile(previousPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no previous version found at %s, a rollback is only possible after an upgrade", previousPath)
+		}
+		return fmt.Errorf("failed to read previous version: %w", err)
+	}
 
-	fmt.Fprintf(io.Out, "%s Successfully updated to version %s\n", c.SuccessIcon(), latestVersion)
+	if io.CanPrompt() && !opts.forceUpdate {
+		if !opts.Survey().AskYesNo("Are you sure you want to restore the previous version ?") {
+			fmt.Fprintf(io.ErrOut, "%s Rollback aborted\n", c.WarningIcon())
+			return nil
+		}
+	}
+
+	// the current binary takes the place of the previous one, so a second rollback undoes the first
+	err = update.Apply(bytes.NewReader(previous), update.Options{
+		TargetPath:  exePath,
+		OldSavePath: previousPath,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore previous version: %w", err)
+	}
 
+	fmt.Fprintf(io.Out, "%s Restored the previous version of %s\n", c.SuccessIcon(), exePath)
 	return nil
 }
 
+func getExecutablePath(opts *Options) (string, error) {
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	if opts.path != "" {
+		exePath = opts.path + "/vcr"
+	}
+
+	if !executableExists(exePath) {
+		return "", fmt.Errorf("failed to find executable CLI file at %s", exePath)
+	}
+	return exePath, nil
+}
+
+// getPreviousPath returns where the binary replaced by an upgrade is kept, next to the executable.
+func getPreviousPath(exePath string) string {
+	dir, name := filepath.Split(exePath)
+	return filepath.Join(dir, "."+name+".previous")
+}
+
 func Format(version, buildDate, commit string) string {
 	if version == "dev" {
 		version = "0.0.1"
@@ -199,27 +554,76 @@ func GetLatestVersion(release api.Release) (semver.Version, error) {
 	return parsedVersion, nil
 }
 
-func updateByAsset(ctx context.Context, opts *Options, release api.Release, exePath string) error {
+func updateByAsset(ctx context.Context, opts *Options, release api.Release, exePath string) (verification, error) {
 	latestAssetURL, err := getDownloadURL(release)
 	if err != nil {
-		return fmt.Errorf("failed to get download url: %w", err)
+		return verification{}, fmt.Errorf("failed to get download url: %w", err)
 	}
 
 	asset, err := opts.ReleaseClient().GetAsset(ctx, latestAssetURL)
 	if err != nil {
-		return fmt.Errorf("failed to get release asset: %w", err)
+		return verification{}, fmt.Errorf("failed to get release asset: %w", err)
+	}
+
+	verified, err := verifyAsset(ctx, opts, release, getAssetName(), asset)
+	if err != nil {
+		return verification{}, err
+	}
+	if err := checkSignedVersion(release, verified); err != nil {
+		return verification{}, err
+	}
+
+	binary, err := uncompressCommand(asset, latestAssetURL, exePath)
+	if err != nil {
+		return verification{}, err
+	}
+	if err := applyUpdate(binary, exePath); err != nil {
+		return verification{}, err
+	}
+	return verified, nil
+}
+
+// checkSignedVersion rejects a signed release whose trusted comment names another version than its tag,
+// so that an older release, validly signed, can't be served as the latest one.
+func checkSignedVersion(release api.Release, verified verification) error {
+	if verified.KeyID == "" {
+		return nil
+	}
+	latest, err := GetLatestVersion(release)
+	if err != nil {
+		return err
 	}
+	if verified.Version == "" {
+		return fmt.Errorf("%w: the signature of %s names no version, expected %s", ErrVerificationFailed, checksumsAssetName, latest.String())
+	}
+	signed, err := GetCurrentVersion(verified.Version)
+	if err != nil || !signed.EQ(latest) {
+		return fmt.Errorf("%w: release %s is signed as version %s", ErrVerificationFailed, release.TagName, verified.Version)
+	}
+	return nil
+}
 
+// uncompressCommand extracts the binary named like the executable from the release archive.
+func uncompressCommand(asset []byte, assetURL, exePath string) ([]byte, error) {
 	_, baseName := filepath.Split(exePath)
 	cmd := strings.TrimSuffix(baseName, filepath.Ext(baseName))
 
-	binary, err := selfupdate.UncompressCommand(bytes.NewReader(asset), latestAssetURL, cmd)
+	binary, err := selfupdate.UncompressCommand(bytes.NewReader(asset), assetURL, cmd)
 	if err != nil {
-		return fmt.Errorf("failed to uncompress command: %w", err)
+		return nil, fmt.Errorf("failed to uncompress command: %w", err)
 	}
+	data, err := io.ReadAll(binary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to uncompress command: %w", err)
+	}
+	return data, nil
+}
 
-	err = update.Apply(binary, update.Options{
-		TargetPath: exePath,
+// applyUpdate replaces the executable with the binary, keeping the replaced one for --rollback.
+func applyUpdate(binary []byte, exePath string) error {
+	err := update.Apply(bytes.NewReader(binary), update.Options{
+		TargetPath:  exePath,
+		OldSavePath: getPreviousPath(exePath),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to apply update: %w", err)
@@ -227,9 +631,13 @@ func updateByAsset(ctx context.Context, opts *Options, release api.Release, exeP
 	return nil
 }
 
+func getAssetName() string {
+	return fmt.Sprintf("vcr_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+}
+
 func getDownloadURL(release api.Release) (string, error) {
 	for _, asset := range release.Assets {
-		if asset.Name == fmt.Sprintf("vcr_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH) {
+		if asset.Name == getAssetName() {
 			if asset.BrowserDownloadURL == "" {
 				return "", fmt.Errorf("download url not found for %s %s", runtime.GOOS, runtime.GOARCH)
 			}
@@ -239,6 +647,16 @@ func getDownloadURL(release api.Release) (string, error) {
 	return "", fmt.Errorf("no asset found for %s %s", runtime.GOOS, runtime.GOARCH)
 }
 
+// getAssetURL returns the download URL of the release asset with the given name.
+func getAssetURL(release api.Release, name string) (string, error) {
+	for _, asset := range release.Assets {
+		if asset.Name == name && asset.BrowserDownloadURL != "" {
+			return asset.BrowserDownloadURL, nil
+		}
+	}
+	return "", fmt.Errorf("no asset named %s", name)
+}
+
 func executableExists(path string) bool {
 	info, err := os.Stat(path)
 	if err != nil {
@@ -1,11 +1,15 @@
 package upgrade
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 
@@ -32,6 +36,12 @@ func TestUpgrade(t *testing.T) {
 		UpgradeVersion           string
 		UpgradeBuildDate         string
 		UpgradeCommit            string
+
+		UpgradeGetReleaseByTagTimes     int
+		UpgradeTag                      string
+		UpgradeGetReleaseByTagReturnErr error
+		UpgradeListReleasesTimes        int
+		UpgradeReturnReleases           []api.Release
 	}
 	type want struct {
 		errMsg string
@@ -131,6 +141,124 @@ func TestUpgrade(t *testing.T) {
 				errMsg: "failed to get assets: api error",
 			},
 		},
+
+		{
+			name: "check-update-available",
+			cli:  "--check",
+			mock: mock{
+				UpgradeGetLatestReleaseTimes: 1,
+				UpgradeReturnRelease:         api.Release{TagName: "v1.1.0"},
+				UpgradeVersion:               "1.0.0",
+			},
+			want: want{
+				errMsg: "SilentError",
+			},
+		},
+
+		{
+			name: "check-up-to-date",
+			cli:  "--check",
+			mock: mock{
+				UpgradeGetLatestReleaseTimes: 1,
+				UpgradeReturnRelease:         api.Release{TagName: "v1.0.0"},
+				UpgradeVersion:               "1.0.0",
+			},
+			want: want{
+				stdout: "✓ vcr-cli is up to date (1.0.0)\n",
+			},
+		},
+
+		{
+			name: "check-pinned-version-differs",
+			cli:  "--check --version 0.9.0",
+			mock: mock{
+				UpgradeGetReleaseByTagTimes: 1,
+				UpgradeTag:                  "v0.9.0",
+				UpgradeReturnRelease:        api.Release{TagName: "v0.9.0"},
+				UpgradeVersion:              "1.0.0",
+			},
+			want: want{
+				errMsg: "SilentError",
+			},
+		},
+
+		{
+			name: "pinned-version-already-installed",
+			cli:  "--version v1.0.0",
+			mock: mock{
+				UpgradeGetReleaseByTagTimes: 1,
+				UpgradeTag:                  "v1.0.0",
+				UpgradeReturnRelease:        api.Release{TagName: "v1.0.0"},
+				UpgradeVersion:              "1.0.0",
+			},
+			want: want{
+				stdout: "✓ You are using the latest version of vcr-cli (1.0.0)\n",
+			},
+		},
+
+		{
+			name: "pinned-version-not-found",
+			cli:  "--version 9.9.9",
+			mock: mock{
+				UpgradeGetReleaseByTagTimes:     1,
+				UpgradeTag:                      "v9.9.9",
+				UpgradeGetReleaseByTagReturnErr: api.ErrNotFound,
+				UpgradeVersion:                  "1.0.0",
+			},
+			want: want{
+				errMsg: "failed to get assets: release v9.9.9 not found",
+			},
+		},
+
+		{
+			name: "prerelease-channel",
+			cli:  "--channel prerelease --check",
+			mock: mock{
+				UpgradeListReleasesTimes: 1,
+				UpgradeReturnReleases: []api.Release{
+					{TagName: "v1.2.0-beta.1", Draft: true},
+					{TagName: "v1.1.0-beta.2", Prerelease: true},
+					{TagName: "v1.0.0"},
+				},
+				UpgradeVersion: "1.1.0-beta.2",
+			},
+			want: want{
+				stdout: "✓ vcr-cli is up to date (1.1.0-beta.2)\n",
+			},
+		},
+
+		{
+			name: "invalid-channel",
+			cli:  "--channel nightly",
+			mock: mock{
+				UpgradeVersion: "1.0.0",
+			},
+			want: want{
+				errMsg: "invalid channel \"nightly\", must be one of \"stable\" or \"prerelease\"",
+			},
+		},
+
+		{
+			name: "version-and-rollback",
+			cli:  "--version 1.0.0 --rollback",
+			mock: mock{
+				UpgradeVersion: "1.0.0",
+			},
+			want: want{
+				errMsg: "specify only one of --version, --channel, --rollback or --from-file",
+			},
+		},
+
+		{
+			name: "check-and-from-file",
+			cli:  "--check --from-file vcr.tar.gz",
+			mock: mock{
+				UpgradeVersion: "1.0.0",
+			},
+			want: want{
+				errMsg: "--check cannot be used with --rollback or --from-file",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -148,6 +276,16 @@ func TestUpgrade(t *testing.T) {
 				Times(tt.mock.UpgradeGetAssetTimes).
 				Return(tt.mock.UpgradeReturnBytes, tt.mock.UpgradeGetAssetReturnErr)
 
+			releaseMock.EXPECT().
+				GetReleaseByTag(gomock.Any(), tt.mock.UpgradeTag).
+				Times(tt.mock.UpgradeGetReleaseByTagTimes).
+				Return(tt.mock.UpgradeReturnRelease, tt.mock.UpgradeGetReleaseByTagReturnErr)
+
+			releaseMock.EXPECT().
+				ListReleases(gomock.Any()).
+				Times(tt.mock.UpgradeListReleasesTimes).
+				Return(tt.mock.UpgradeReturnReleases, nil)
+
 			ios, _, stdout, stderr := iostreams.Test()
 
 			argv, err := shlex.Split(tt.cli)
@@ -196,21 +334,29 @@ func TestUpgradeByAsset(t *testing.T) {
 		require.Error(t, err, "should throw read file error")
 	}
 
+	sum := sha256.Sum256(byteSlice)
+	assetName := fmt.Sprintf("vcr_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	checksums := fmt.Appendf(nil, "%x  %s\n", sum, assetName)
+
 	type mock struct {
 		UpgradeExePath           string
 		UpgradeRelease           api.Release
 		UpgradeGetAssetTimes     int
 		UpgradeReturnAsset       []byte
 		UpgradeGetAssetReturnErr error
+
+		UpgradeGetChecksumsTimes int
+		UpgradeReturnChecksums   []byte
 	}
 	type want struct {
 		errMsg string
 	}
 
 	tests := []struct {
-		name string
-		mock mock
-		want want
+		name     string
+		insecure bool
+		mock     mock
+		want     want
 	}{
 		{
 			name: "happy-path",
@@ -218,10 +364,15 @@ func TestUpgradeByAsset(t *testing.T) {
 				UpgradeExePath: "testdata/vcr",
 				UpgradeRelease: api.Release{
 					TagName: "v0.0.1",
-					Assets:  []api.Asset{{Name: fmt.Sprintf("vcr_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH), BrowserDownloadURL: "test-DownloadURL"}}},
+					Assets: []api.Asset{
+						{Name: assetName, BrowserDownloadURL: "test-DownloadURL"},
+						{Name: "checksums.txt", BrowserDownloadURL: "test-ChecksumsURL"},
+					}},
 				UpgradeGetAssetTimes:     1,
 				UpgradeReturnAsset:       byteSlice,
 				UpgradeGetAssetReturnErr: nil,
+				UpgradeGetChecksumsTimes: 1,
+				UpgradeReturnChecksums:   checksums,
 			},
 
 			want: want{
@@ -229,6 +380,57 @@ func TestUpgradeByAsset(t *testing.T) {
 			},
 		},
 
+		{
+			name: "checksum-mismatch",
+			mock: mock{
+				UpgradeExePath: "testdata/vcr",
+				UpgradeRelease: api.Release{
+					TagName: "v0.0.1",
+					Assets: []api.Asset{
+						{Name: assetName, BrowserDownloadURL: "test-DownloadURL"},
+						{Name: "checksums.txt", BrowserDownloadURL: "test-ChecksumsURL"},
+					}},
+				UpgradeGetAssetTimes:     1,
+				UpgradeReturnAsset:       append([]byte("tampered"), byteSlice...),
+				UpgradeGetAssetReturnErr: nil,
+				UpgradeGetChecksumsTimes: 1,
+				UpgradeReturnChecksums:   checksums,
+			},
+
+			want: want{
+				errMsg: fmt.Sprintf("release asset verification failed: checksum mismatch for %s, expected sha256 %x but got %x", assetName, sum, sha256.Sum256(append([]byte("tampered"), byteSlice...))),
+			},
+		},
+
+		{
+			name: "release-without-checksums",
+			mock: mock{
+				UpgradeExePath: "testdata/vcr",
+				UpgradeRelease: api.Release{
+					TagName: "v0.0.1",
+					Assets:  []api.Asset{{Name: assetName, BrowserDownloadURL: "test-DownloadURL"}}},
+				UpgradeGetAssetTimes: 1,
+				UpgradeReturnAsset:   byteSlice,
+			},
+
+			want: want{
+				errMsg: "release asset verification failed: release v0.0.1 has no checksums.txt, refusing to install an unverified binary, use --insecure to install it anyway",
+			},
+		},
+
+		{
+			name:     "insecure-release-without-checksums",
+			insecure: true,
+			mock: mock{
+				UpgradeExePath: "testdata/vcr",
+				UpgradeRelease: api.Release{
+					TagName: "v0.0.1",
+					Assets:  []api.Asset{{Name: assetName, BrowserDownloadURL: "test-DownloadURL"}}},
+				UpgradeGetAssetTimes: 1,
+				UpgradeReturnAsset:   byteSlice,
+			},
+		},
+
 		{
 			name: "api-error",
 			mock: mock{
@@ -253,15 +455,24 @@ func TestUpgradeByAsset(t *testing.T) {
 
 			releaseMock := mocks.NewMockReleaseInterface(ctrl)
 			releaseMock.EXPECT().
-				GetAsset(gomock.Any(), gomock.Any()).
+				GetAsset(gomock.Any(), "test-DownloadURL").
 				Times(tt.mock.UpgradeGetAssetTimes).
 				Return(tt.mock.UpgradeReturnAsset, tt.mock.UpgradeGetAssetReturnErr)
+			releaseMock.EXPECT().
+				GetAsset(gomock.Any(), "test-ChecksumsURL").
+				Times(tt.mock.UpgradeGetChecksumsTimes).
+				Return(tt.mock.UpgradeReturnChecksums, nil)
+
+			// work on a copy, the replaced binary is kept next to it
+			exePath := filepath.Join(t.TempDir(), filepath.Base(tt.mock.UpgradeExePath))
+			require.NoError(t, os.WriteFile(exePath, []byte("old binary"), 0755))
 
 			f := testutil.DefaultFactoryMock(t, nil, nil, releaseMock, nil, nil, nil, nil)
 			opts := &Options{
-				Factory: f,
+				Factory:  f,
+				insecure: tt.insecure,
 			}
-			err := updateByAsset(t.Context(), opts, tt.mock.UpgradeRelease, tt.mock.UpgradeExePath)
+			_, err := updateByAsset(t.Context(), opts, tt.mock.UpgradeRelease, exePath)
 			if err != nil && tt.want.errMsg != "" {
 				require.Error(t, err, "should throw error")
 				require.Equal(t, tt.want.errMsg, err.Error())
@@ -269,10 +480,192 @@ func TestUpgradeByAsset(t *testing.T) {
 			}
 
 			require.NoError(t, err, "should not throw error")
+			previous, err := os.ReadFile(getPreviousPath(exePath))
+			require.NoError(t, err)
+			require.Equal(t, "old binary", string(previous))
 		})
 	}
 }
 
+func TestUpgradeByAssetSignedVersion(t *testing.T) {
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	publicKey, priv := minisignKey(t, keyID)
+	defer func(key string) { ReleasePublicKey = key }(ReleasePublicKey)
+	ReleasePublicKey = publicKey
+
+	asset := tarGz(t, "vcr", "new binary")
+	sum := sha256.Sum256(asset)
+	checksums := fmt.Appendf(nil, "%x  %s\n", sum, getAssetName())
+	release := api.Release{
+		TagName: "v1.3.0",
+		Assets: []api.Asset{
+			{Name: getAssetName(), BrowserDownloadURL: "https://example.com/" + getAssetName()},
+			{Name: checksumsAssetName, BrowserDownloadURL: "checksums-url"},
+			{Name: signatureAssetName, BrowserDownloadURL: "signature-url"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		trustedComment string
+		errMsg         string
+	}{
+		{
+			name:           "signed-as-release-version",
+			trustedComment: "vcr-cli v1.3.0",
+		},
+		{
+			name:           "signed-as-older-version",
+			trustedComment: "vcr-cli v1.2.0",
+			errMsg:         "release asset verification failed: release v1.3.0 is signed as version 1.2.0",
+		},
+		{
+			name:           "signed-without-version",
+			trustedComment: "timestamp:1700000000",
+			errMsg:         "release asset verification failed: the signature of checksums.txt names no version, expected 1.3.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			releaseMock := mocks.NewMockReleaseInterface(ctrl)
+			releaseMock.EXPECT().GetAsset(gomock.Any(), "https://example.com/"+getAssetName()).Return(asset, nil)
+			releaseMock.EXPECT().GetAsset(gomock.Any(), "checksums-url").Return(checksums, nil)
+			releaseMock.EXPECT().GetAsset(gomock.Any(), "signature-url").Return(minisignSign(priv, keyID, checksums, tt.trustedComment, false), nil)
+
+			exePath := filepath.Join(t.TempDir(), "vcr")
+			require.NoError(t, os.WriteFile(exePath, []byte("old binary"), 0755))
+
+			f := testutil.DefaultFactoryMock(t, nil, nil, releaseMock, nil, nil, nil, nil)
+			verified, err := updateByAsset(t.Context(), &Options{Factory: f}, release, exePath)
+			if tt.errMsg != "" {
+				require.EqualError(t, err, tt.errMsg)
+				data, err := os.ReadFile(exePath)
+				require.NoError(t, err)
+				require.Equal(t, "old binary", string(data), "the binary must not be replaced")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "0807060504030201", verified.KeyID)
+			data, err := os.ReadFile(exePath)
+			require.NoError(t, err)
+			require.Equal(t, "new binary", string(data))
+		})
+	}
+}
+
+func TestRollback(t *testing.T) {
+	dir := t.TempDir()
+	exePath := filepath.Join(dir, "vcr")
+	require.NoError(t, os.WriteFile(exePath, []byte("current"), 0755))
+
+	ios, _, stdout, _ := iostreams.Test()
+	f := testutil.DefaultFactoryMock(t, ios, nil, nil, nil, nil, nil, nil)
+	opts := &Options{Factory: f, path: dir, rollback: true}
+
+	err := runUpgrade(t.Context(), opts, "1.0.0")
+	require.EqualError(t, err, fmt.Sprintf("no previous version found at %s, a rollback is only possible after an upgrade", getPreviousPath(exePath)))
+
+	require.NoError(t, os.WriteFile(getPreviousPath(exePath), []byte("previous"), 0755))
+
+	require.NoError(t, runUpgrade(t.Context(), opts, "1.0.0"))
+	require.Equal(t, fmt.Sprintf("✓ Restored the previous version of %s\n", exePath), stdout.String())
+	restored, err := os.ReadFile(exePath)
+	require.NoError(t, err)
+	require.Equal(t, "previous", string(restored))
+
+	require.NoError(t, runUpgrade(t.Context(), opts, "1.0.0"), "a second rollback should undo the first")
+	restored, err = os.ReadFile(exePath)
+	require.NoError(t, err)
+	require.Equal(t, "current", string(restored))
+}
+
+func TestUpgradeFromFile(t *testing.T) {
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	publicKey, priv := minisignKey(t, keyID)
+	defer func(key string) { ReleasePublicKey = key }(ReleasePublicKey)
+	ReleasePublicKey = ""
+
+	dir := t.TempDir()
+	exePath := filepath.Join(dir, "vcr")
+	require.NoError(t, os.WriteFile(exePath, []byte("old binary"), 0755))
+
+	// the archived binary is never run, so it doesn't have to be executable on this platform
+	archive := tarGz(t, "vcr", "new binary")
+	archivePath := filepath.Join(dir, getAssetName())
+	require.NoError(t, os.WriteFile(archivePath, archive, 0600))
+
+	// the release client is not set, any call to the release endpoint would fail the test
+	ios, _, stdout, stderr := iostreams.Test()
+	f := testutil.DefaultFactoryMock(t, ios, nil, nil, nil, nil, nil, nil)
+	opts := &Options{Factory: f, path: dir, fromFile: archivePath}
+
+	otherPath := filepath.Join(dir, "vcr_plan9_mips.tar.gz")
+	require.NoError(t, os.WriteFile(otherPath, archive, 0600))
+	err := runUpgrade(t.Context(), &Options{Factory: f, path: dir, fromFile: otherPath}, "1.0.0")
+	require.EqualError(t, err, fmt.Sprintf("vcr_plan9_mips.tar.gz is not a release archive for %s %s, expected %s", runtime.GOOS, runtime.GOARCH, getAssetName()))
+
+	err = runUpgrade(t.Context(), opts, "1.0.0")
+	require.EqualError(t, err, fmt.Sprintf("release asset verification failed: no checksums.txt found in %s, refusing to install an unverified binary, use --insecure to install it anyway", dir))
+
+	sum := sha256.Sum256(archive)
+	checksums := fmt.Appendf(nil, "%x  %s\n", sum, getAssetName())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, checksumsAssetName), checksums, 0600))
+
+	err = runUpgrade(t.Context(), opts, "1.0.0")
+	require.EqualError(t, err, fmt.Sprintf("release asset verification failed: this build has no release public key to verify %s, use --insecure to install it anyway", getAssetName()))
+
+	ReleasePublicKey = publicKey
+	err = runUpgrade(t.Context(), opts, "1.0.0")
+	require.EqualError(t, err, fmt.Sprintf("release asset verification failed: no checksums.txt.minisig found in %s, use --insecure to install an unsigned archive", dir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, signatureAssetName), minisignSign(priv, keyID, checksums, "vcr-cli v1.2.0", false), 0600))
+
+	require.NoError(t, runUpgrade(t.Context(), opts, "1.2.0"))
+	require.Equal(t, "✓ You are already using version 1.2.0 of vcr-cli\n", stdout.String())
+	stdout.Reset()
+
+	err = runUpgrade(t.Context(), opts, "1.3.0")
+	require.EqualError(t, err, fmt.Sprintf("%s contains version 1.2.0, older than the installed 1.3.0, use --force to downgrade", getAssetName()))
+
+	require.NoError(t, runUpgrade(t.Context(), opts, "1.0.0"))
+	require.Contains(t, stdout.String(), fmt.Sprintf("✓ Verified sha256 checksum %x\n", sum))
+	require.Contains(t, stdout.String(), "✓ Verified signature of checksums.txt with key 0807060504030201\n")
+	require.Contains(t, stdout.String(), "✓ Successfully updated to version 1.2.0\n")
+	previous, err := os.ReadFile(getPreviousPath(exePath))
+	require.NoError(t, err)
+	require.Equal(t, "old binary", string(previous))
+	stdout.Reset()
+
+	require.NoError(t, runUpgrade(t.Context(), &Options{Factory: f, path: dir, fromFile: archivePath, forceUpdate: true}, "1.3.0"), "--force allows a downgrade")
+	require.Contains(t, stdout.String(), "✓ Successfully updated to version 1.2.0\n")
+	stdout.Reset()
+
+	require.NoError(t, os.Remove(filepath.Join(dir, signatureAssetName)))
+	insecure := &Options{Factory: f, path: dir, fromFile: archivePath, insecure: true}
+	err = runUpgrade(t.Context(), insecure, "1.0.0")
+	require.EqualError(t, err, fmt.Sprintf("refusing to install %s of unknown version without --force", getAssetName()))
+
+	insecure.forceUpdate = true
+	require.NoError(t, runUpgrade(t.Context(), insecure, "1.0.0"))
+	require.Contains(t, stderr.String(), fmt.Sprintf("! The version of %s is unknown, as its signature was not verified\n", getAssetName()))
+	require.Contains(t, stdout.String(), "! Signature not verified, installed with --insecure\n")
+	require.Contains(t, stdout.String(), "✓ Successfully updated to version unknown\n")
+}
+
+func tarGz(t *testing.T, name, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content))}))
+	_, err := tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
 func TestFormat(t *testing.T) {
 	version := "1.0.0"
 	buildDate := "2022-01-01"
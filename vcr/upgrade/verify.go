@@ -0,0 +1,193 @@
+package upgrade
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+)
+
+const (
+	checksumsAssetName = "checksums.txt"
+	signatureAssetName = checksumsAssetName + ".minisig"
+
+	minisignAlgorithm         = "Ed"
+	minisignHashedAlgorithm   = "ED"
+	minisignKeyIDSize         = 8
+	minisignTrustedCommentTag = "trusted comment: "
+)
+
+// ReleasePublicKey is the minisign public key used to verify the signature of the release checksums.
+// It is set at build time with -ldflags "-X vonage-cloud-runtime-cli/vcr/upgrade.ReleasePublicKey=<key>".
+// When it is empty, only the checksum of a downloaded asset is verified, and a --from-file archive is
+// refused unless --insecure is set.
+var ReleasePublicKey = ""
+
+var ErrVerificationFailed = errors.New("release asset verification failed")
+
+// verification describes how a downloaded release asset was verified.
+type verification struct {
+	Checksum string
+	// KeyID is the minisign key ID that signed the checksums, empty when no signature was verified.
+	KeyID string
+	// Version is the release version named in the trusted comment of the signature, e.g. "vcr-cli v1.2.3".
+	// It is empty when no signature was verified, as the version can't be trusted then.
+	Version string
+}
+
+// trustedCommentRegexp matches the trusted comment the release workflow signs the checksums with.
+var trustedCommentRegexp = regexp.MustCompile(`^vcr-cli v?(\S+)$`)
+
+// verifyAsset checks the asset against the release checksums file, and the checksums file against its
+// minisign signature when the binary embeds a release public key. With --insecure, a release without
+// checksums file or signature, such as one published before they were added, is accepted unverified.
+func verifyAsset(ctx context.Context, opts *Options, release api.Release, assetName string, asset []byte) (verification, error) {
+	checksumsURL, err := getAssetURL(release, checksumsAssetName)
+	if err != nil {
+		if opts.insecure {
+			return verification{}, nil
+		}
+		return verification{}, fmt.Errorf("%w: release %s has no %s, refusing to install an unverified binary, use --insecure to install it anyway", ErrVerificationFailed, release.TagName, checksumsAssetName)
+	}
+	checksums, err := opts.ReleaseClient().GetAsset(ctx, checksumsURL)
+	if err != nil {
+		return verification{}, fmt.Errorf("failed to get release checksums: %w", err)
+	}
+
+	var signature []byte
+	if ReleasePublicKey != "" {
+		signatureURL, err := getAssetURL(release, signatureAssetName)
+		switch {
+		case err == nil:
+			signature, err = opts.ReleaseClient().GetAsset(ctx, signatureURL)
+			if err != nil {
+				return verification{}, fmt.Errorf("failed to get release signature: %w", err)
+			}
+		case !opts.insecure:
+			return verification{}, fmt.Errorf("%w: release %s has no %s, use --insecure to install it anyway", ErrVerificationFailed, release.TagName, signatureAssetName)
+		}
+	}
+	return verifyChecksums(assetName, asset, checksums, signature)
+}
+
+// verifyChecksums checks the asset against the checksums, and the checksums against their minisign
+// signature when one is given, which requires the binary to embed a release public key. Callers decide
+// whether a signature is required.
+func verifyChecksums(assetName string, asset, checksums, signature []byte) (verification, error) {
+	var result verification
+	if signature != nil {
+		keyID, trustedComment, err := verifyMinisign(ReleasePublicKey, checksums, signature)
+		if err != nil {
+			return verification{}, fmt.Errorf("%w: invalid signature of %s: %w", ErrVerificationFailed, checksumsAssetName, err)
+		}
+		result.KeyID = keyID
+		if match := trustedCommentRegexp.FindStringSubmatch(trustedComment); match != nil {
+			result.Version = match[1]
+		}
+	}
+
+	expected, err := findChecksum(checksums, assetName)
+	if err != nil {
+		return verification{}, fmt.Errorf("%w: %w", ErrVerificationFailed, err)
+	}
+	sum := sha256.Sum256(asset)
+	actual := hex.EncodeToString(sum[:])
+	if !strings.EqualFold(expected, actual) {
+		return verification{}, fmt.Errorf("%w: checksum mismatch for %s, expected sha256 %s but got %s", ErrVerificationFailed, assetName, expected, actual)
+	}
+	result.Checksum = actual
+	return result, nil
+}
+
+// findChecksum returns the sha256 checksum listed for the file name, in the format written by sha256sum.
+func findChecksum(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		// sha256sum marks files read in binary mode with a leading "*"
+		if strings.TrimPrefix(fields[1], "*") == name {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no checksum found for %s", name)
+}
+
+// verifyMinisign verifies a minisign signature of data and returns the hex encoded ID of the signing key
+// and the trusted comment. The public key can be given as the content of a minisign .pub file or as its
+// base64 line only.
+func verifyMinisign(publicKey string, data, signature []byte) (string, string, error) {
+	keyBytes, err := base64.StdEncoding.DecodeString(lastLine(publicKey))
+	if err != nil || len(keyBytes) != 2+minisignKeyIDSize+ed25519.PublicKeySize || string(keyBytes[:2]) != minisignAlgorithm {
+		return "", "", errors.New("malformed public key")
+	}
+	keyID := keyBytes[2 : 2+minisignKeyIDSize]
+	key := ed25519.PublicKey(keyBytes[2+minisignKeyIDSize:])
+
+	lines := strings.Split(strings.TrimSpace(string(signature)), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[2], minisignTrustedCommentTag) {
+		return "", "", errors.New("malformed signature")
+	}
+	sigBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(sigBytes) != 2+minisignKeyIDSize+ed25519.SignatureSize {
+		return "", "", errors.New("malformed signature")
+	}
+	globalSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return "", "", errors.New("malformed signature")
+	}
+
+	if !bytes.Equal(sigBytes[2:2+minisignKeyIDSize], keyID) {
+		return "", "", fmt.Errorf("signed with key %X, expected key %X", reverse(sigBytes[2:2+minisignKeyIDSize]), reverse(keyID))
+	}
+	sig := sigBytes[2+minisignKeyIDSize:]
+
+	message := data
+	switch string(sigBytes[:2]) {
+	case minisignAlgorithm:
+	case minisignHashedAlgorithm:
+		digest := blake2b.Sum512(data)
+		message = digest[:]
+	default:
+		return "", "", fmt.Errorf("unsupported signature algorithm %q", sigBytes[:2])
+	}
+	if !ed25519.Verify(key, message, sig) {
+		return "", "", errors.New("signature does not match")
+	}
+
+	trustedComment := strings.TrimPrefix(strings.TrimRight(lines[2], "\r"), minisignTrustedCommentTag)
+	if !ed25519.Verify(key, append(bytes.Clone(sig), trustedComment...), globalSig) {
+		return "", "", errors.New("trusted comment signature does not match")
+	}
+	return fmt.Sprintf("%X", reverse(keyID)), trustedComment, nil
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// reverse returns a reversed copy of b. minisign stores key IDs little-endian but prints them big-endian.
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
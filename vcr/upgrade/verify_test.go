@@ -0,0 +1,213 @@
+package upgrade
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	"vonage-cloud-runtime-cli/pkg/api"
+	"vonage-cloud-runtime-cli/testutil"
+	"vonage-cloud-runtime-cli/testutil/mocks"
+)
+
+// minisignKey generates a key pair and returns its minisign public key file content.
+func minisignKey(t *testing.T, keyID []byte) (string, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyBytes := append(append([]byte(minisignAlgorithm), keyID...), pub...)
+	return "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(keyBytes) + "\n", priv
+}
+
+// minisignSign returns a minisign signature file of data, prehashed unless legacy is set.
+func minisignSign(priv ed25519.PrivateKey, keyID, data []byte, trustedComment string, legacy bool) []byte {
+	algorithm, message := minisignHashedAlgorithm, data
+	if legacy {
+		algorithm = minisignAlgorithm
+	} else {
+		digest := blake2b.Sum512(data)
+		message = digest[:]
+	}
+	sig := ed25519.Sign(priv, message)
+	sigBytes := append(append([]byte(algorithm), keyID...), sig...)
+	globalSig := ed25519.Sign(priv, append(append([]byte{}, sig...), trustedComment...))
+	return fmt.Appendf(nil, "untrusted comment: signature\n%s\n%s%s\n%s\n",
+		base64.StdEncoding.EncodeToString(sigBytes), minisignTrustedCommentTag, trustedComment, base64.StdEncoding.EncodeToString(globalSig))
+}
+
+func TestVerifyMinisign(t *testing.T) {
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	publicKey, priv := minisignKey(t, keyID)
+	otherKey, _ := minisignKey(t, keyID)
+	data := []byte("checksums")
+
+	id, comment, err := verifyMinisign(publicKey, data, minisignSign(priv, keyID, data, "timestamp:1", false))
+	require.NoError(t, err)
+	require.Equal(t, "0807060504030201", id)
+	require.Equal(t, "timestamp:1", comment)
+
+	_, _, err = verifyMinisign(publicKey, data, minisignSign(priv, keyID, data, "timestamp:1", true))
+	require.NoError(t, err, "legacy signatures should be accepted")
+
+	_, _, err = verifyMinisign(publicKey, []byte("tampered"), minisignSign(priv, keyID, data, "timestamp:1", false))
+	require.EqualError(t, err, "signature does not match")
+
+	_, _, err = verifyMinisign(otherKey, data, minisignSign(priv, keyID, data, "timestamp:1", false))
+	require.EqualError(t, err, "signature does not match")
+
+	_, _, err = verifyMinisign(publicKey, data, minisignSign(priv, []byte{8, 7, 6, 5, 4, 3, 2, 1}, data, "timestamp:1", false))
+	require.EqualError(t, err, "signed with key 0102030405060708, expected key 0807060504030201")
+
+	_, _, err = verifyMinisign(publicKey, data, []byte("not a signature"))
+	require.EqualError(t, err, "malformed signature")
+
+	_, _, err = verifyMinisign("RWQ", data, minisignSign(priv, keyID, data, "timestamp:1", false))
+	require.EqualError(t, err, "malformed public key")
+}
+
+func TestVerifyAsset(t *testing.T) {
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	publicKey, priv := minisignKey(t, keyID)
+
+	asset := []byte("asset")
+	sum := sha256.Sum256(asset)
+	checksum := hex.EncodeToString(sum[:])
+	checksums := fmt.Appendf(nil, "%s  vcr_linux_amd64.tar.gz\n%s *other.tar.gz\n", checksum, hex.EncodeToString(make([]byte, 32)))
+	signature := minisignSign(priv, keyID, checksums, "vcr release", false)
+
+	release := api.Release{
+		TagName: "v1.0.0",
+		Assets: []api.Asset{
+			{Name: checksumsAssetName, BrowserDownloadURL: "checksums-url"},
+			{Name: signatureAssetName, BrowserDownloadURL: "signature-url"},
+		},
+	}
+
+	type mock struct {
+		PublicKey         string
+		Release           api.Release
+		Checksums         []byte
+		ChecksumsErr      error
+		Signature         []byte
+		GetSignatureTimes int
+	}
+	type want struct {
+		errMsg string
+		result verification
+	}
+
+	tests := []struct {
+		name      string
+		assetName string
+		insecure  bool
+		mock      mock
+		want      want
+	}{
+		{
+			name:      "checksum-and-signature",
+			assetName: "vcr_linux_amd64.tar.gz",
+			mock:      mock{PublicKey: publicKey, Release: release, Checksums: checksums, Signature: signature, GetSignatureTimes: 1},
+			want:      want{result: verification{Checksum: checksum, KeyID: "0807060504030201"}},
+		},
+		{
+			name:      "checksum-only-without-public-key",
+			assetName: "vcr_linux_amd64.tar.gz",
+			mock:      mock{Release: release, Checksums: checksums},
+			want:      want{result: verification{Checksum: checksum}},
+		},
+		{
+			name:      "checksum-mismatch",
+			assetName: "other.tar.gz",
+			mock:      mock{Release: release, Checksums: checksums},
+			want: want{
+				errMsg: fmt.Sprintf("release asset verification failed: checksum mismatch for other.tar.gz, expected sha256 %s but got %s", hex.EncodeToString(make([]byte, 32)), checksum),
+			},
+		},
+		{
+			name:      "asset-missing-from-checksums",
+			assetName: "vcr_windows_amd64.tar.gz",
+			mock:      mock{Release: release, Checksums: checksums},
+			want:      want{errMsg: "release asset verification failed: no checksum found for vcr_windows_amd64.tar.gz"},
+		},
+		{
+			name:      "tampered-checksums",
+			assetName: "vcr_linux_amd64.tar.gz",
+			mock:      mock{PublicKey: publicKey, Release: release, Checksums: append([]byte("x"), checksums...), Signature: signature, GetSignatureTimes: 1},
+			want:      want{errMsg: "release asset verification failed: invalid signature of checksums.txt: signature does not match"},
+		},
+		{
+			name:      "release-without-checksums",
+			assetName: "vcr_linux_amd64.tar.gz",
+			mock:      mock{Release: api.Release{TagName: "v1.0.0"}},
+			want:      want{errMsg: "release asset verification failed: release v1.0.0 has no checksums.txt, refusing to install an unverified binary, use --insecure to install it anyway"},
+		},
+		{
+			name:      "insecure-release-without-checksums",
+			assetName: "vcr_linux_amd64.tar.gz",
+			insecure:  true,
+			mock:      mock{PublicKey: publicKey, Release: api.Release{TagName: "v1.0.0"}},
+			want:      want{result: verification{}},
+		},
+		{
+			name:      "release-without-signature",
+			assetName: "vcr_linux_amd64.tar.gz",
+			mock:      mock{PublicKey: publicKey, Release: api.Release{TagName: "v1.0.0", Assets: release.Assets[:1]}, Checksums: checksums},
+			want:      want{errMsg: "release asset verification failed: release v1.0.0 has no checksums.txt.minisig, use --insecure to install it anyway"},
+		},
+		{
+			name:      "insecure-release-without-signature",
+			assetName: "vcr_linux_amd64.tar.gz",
+			insecure:  true,
+			mock:      mock{PublicKey: publicKey, Release: api.Release{TagName: "v1.0.0", Assets: release.Assets[:1]}, Checksums: checksums},
+			want:      want{result: verification{Checksum: checksum}},
+		},
+		{
+			name:      "insecure-still-checks-signature",
+			assetName: "vcr_linux_amd64.tar.gz",
+			insecure:  true,
+			mock:      mock{PublicKey: publicKey, Release: release, Checksums: append([]byte("x"), checksums...), Signature: signature, GetSignatureTimes: 1},
+			want:      want{errMsg: "release asset verification failed: invalid signature of checksums.txt: signature does not match"},
+		},
+		{
+			name:      "checksums-download-error",
+			assetName: "vcr_linux_amd64.tar.gz",
+			mock:      mock{Release: release, ChecksumsErr: errors.New("api error")},
+			want:      want{errMsg: "failed to get release checksums: api error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previousKey := ReleasePublicKey
+			ReleasePublicKey = tt.mock.PublicKey
+			t.Cleanup(func() { ReleasePublicKey = previousKey })
+
+			ctrl := gomock.NewController(t)
+			releaseMock := mocks.NewMockReleaseInterface(ctrl)
+			releaseMock.EXPECT().GetAsset(gomock.Any(), "checksums-url").
+				MaxTimes(1).
+				Return(tt.mock.Checksums, tt.mock.ChecksumsErr)
+			releaseMock.EXPECT().GetAsset(gomock.Any(), "signature-url").
+				Times(tt.mock.GetSignatureTimes).
+				Return(tt.mock.Signature, nil)
+
+			f := testutil.DefaultFactoryMock(t, nil, nil, releaseMock, nil, nil, nil, nil)
+			result, err := verifyAsset(t.Context(), &Options{Factory: f, insecure: tt.insecure}, tt.mock.Release, tt.assetName, asset)
+			if tt.want.errMsg != "" {
+				require.EqualError(t, err, tt.want.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.result, result)
+		})
+	}
+}